@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterExternalSecretSpec defines the desired state of ClusterExternalSecret.
+type ClusterExternalSecretSpec struct {
+	// The spec for the ExternalSecrets to be created
+	ExternalSecretSpec ExternalSecretSpec `json:"externalSecretSpec"`
+
+	// The name of the external secrets to be created
+	// Defaults to the .metadata.name of the ClusterExternalSecret resource
+	// +optional
+	ExternalSecretName string `json:"externalSecretName,omitempty"`
+
+	// The labels to select by to find the Namespaces to create the ExternalSecrets in.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// RefreshInterval is the amount of time before the Namespaces are checked again.
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h"
+	// Defaults to 1m.
+	// +kubebuilder:default="1m"
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+type ClusterExternalSecretConditionType string
+
+const (
+	ClusterExternalSecretReady ClusterExternalSecretConditionType = "Ready"
+)
+
+type ClusterExternalSecretStatusCondition struct {
+	Type   ClusterExternalSecretConditionType `json:"type"`
+	Status corev1.ConditionStatus             `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+const (
+	// ConditionReasonNamespacesProvisioned indicates that the ExternalSecrets
+	// of all selected namespaces are ready.
+	ConditionReasonNamespacesProvisioned = "NamespacesProvisioned"
+	// ConditionReasonNamespacesFailed indicates that the ExternalSecret
+	// of at least one selected namespace could not be created or is not ready.
+	ConditionReasonNamespacesFailed = "NamespacesFailed"
+	// ConditionReasonInvalidSelector indicates that the namespace selector is invalid.
+	ConditionReasonInvalidSelector = "InvalidNamespaceSelector"
+)
+
+// ClusterExternalSecretNamespaceFailure represents a failed namespace deployment and it's reason.
+type ClusterExternalSecretNamespaceFailure struct {
+	// Namespace is the namespace that failed when trying to apply an ExternalSecret
+	Namespace string `json:"namespace"`
+
+	// Reason is why the ExternalSecret failed to apply to the namespace
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClusterExternalSecretStatus defines the observed state of ClusterExternalSecret.
+type ClusterExternalSecretStatus struct {
+	// ProvisionedNamespaces are the namespaces the ExternalSecret is ready in
+	// +optional
+	ProvisionedNamespaces []string `json:"provisionedNamespaces,omitempty"`
+
+	// FailedNamespaces are the namespaces that failed to apply an ExternalSecret
+	// +optional
+	FailedNamespaces []ClusterExternalSecretNamespaceFailure `json:"failedNamespaces,omitempty"`
+
+	// +optional
+	Conditions []ClusterExternalSecretStatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterExternalSecret is the Schema for the clusterexternalsecrets API.
+// It creates the same ExternalSecret in every Namespace matching the namespace selector.
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={externalsecrets},shortName=ces
+// +kubebuilder:printcolumn:name="Store",type=string,JSONPath=`.spec.externalSecretSpec.secretStoreRef.name`
+// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshInterval`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+type ClusterExternalSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterExternalSecretSpec   `json:"spec,omitempty"`
+	Status ClusterExternalSecretStatus `json:"status,omitempty"`
+}
+
+const (
+	// LabelClusterExternalSecretName is set on every ExternalSecret
+	// created by a ClusterExternalSecret to the name of its owner.
+	LabelClusterExternalSecretName = "external-secrets.io/cluster-external-secret-name"
+)
+
+// +kubebuilder:object:root=true
+
+// ClusterExternalSecretList contains a list of ClusterExternalSecret resources.
+type ClusterExternalSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterExternalSecret `json:"items"`
+}
@@ -42,6 +42,56 @@ const (
 
 	// None does not create a Secret (future use with injector).
 	None ExternalSecretCreationPolicy = "None"
+
+	// Orphan creates the Secret without .metadata.ownerReferences, it is kept when the ExternalSecret is deleted.
+	Orphan ExternalSecretCreationPolicy = "Orphan"
+)
+
+// ExternalSecretRefreshPolicy defines when the Secret is refreshed.
+// +kubebuilder:validation:Enum=Periodic;CreatedOnce;OnChange
+type ExternalSecretRefreshPolicy string
+
+const (
+	// RefreshPolicyPeriodic refreshes the Secret every refreshInterval and on changes.
+	// A refreshInterval of zero only refreshes it on changes.
+	RefreshPolicyPeriodic ExternalSecretRefreshPolicy = "Periodic"
+
+	// RefreshPolicyCreatedOnce creates the Secret and never updates it afterwards,
+	// even if the ExternalSecret changes. A deleted Secret is created again.
+	RefreshPolicyCreatedOnce ExternalSecretRefreshPolicy = "CreatedOnce"
+
+	// RefreshPolicyOnChange refreshes the Secret only if the ExternalSecret, its store or
+	// the Secrets and ConfigMaps it depends on change, the refreshInterval is ignored.
+	RefreshPolicyOnChange ExternalSecretRefreshPolicy = "OnChange"
+)
+
+// ExternalSecretDeletionPolicy defines rules on how to handle the resulting Secret
+// when the data is deleted from the provider.
+// +kubebuilder:validation:Enum=Retain;Delete;Merge
+type ExternalSecretDeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the Secret and its data, the ExternalSecret reports an error.
+	DeletionPolicyRetain ExternalSecretDeletionPolicy = "Retain"
+
+	// DeletionPolicyDelete deletes the Secret once all of its provider data is gone.
+	DeletionPolicyDelete ExternalSecretDeletionPolicy = "Delete"
+
+	// DeletionPolicyMerge removes the keys whose provider data is gone and keeps the Secret.
+	DeletionPolicyMerge ExternalSecretDeletionPolicy = "Merge"
+)
+
+// ExternalSecretDriftPolicy defines rules on how to handle changes of the resulting Secret
+// that were not made by the controller.
+// +kubebuilder:validation:Enum=Correct;Report
+type ExternalSecretDriftPolicy string
+
+const (
+	// DriftPolicyCorrect syncs the Secret again as soon as it drifted.
+	DriftPolicyCorrect ExternalSecretDriftPolicy = "Correct"
+
+	// DriftPolicyReport keeps the changed Secret until its next refresh and only reports the drift.
+	DriftPolicyReport ExternalSecretDriftPolicy = "Report"
 )
 
 // ExternalSecretTemplateMetadata defines metadata fields for the Secret blueprint.
@@ -67,6 +117,12 @@ type ExternalSecretTemplate struct {
 
 	// +optional
 	TemplateFrom []TemplateFrom `json:"templateFrom,omitempty"`
+
+	// Manifest is the template of a target resource of a kind other than Secret or ConfigMap.
+	// It is rendered with the secret data like the template data and must result in a
+	// YAML or JSON object. Its apiVersion, kind, name and namespace are set from the target.
+	// +optional
+	Manifest string `json:"manifest,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties=1
@@ -100,6 +156,20 @@ type ExternalSecretTarget struct {
 	// +kubebuilder:default="Owner"
 	CreationPolicy ExternalSecretCreationPolicy `json:"creationPolicy,omitempty"`
 
+	// DeletionPolicy defines rules on how to handle the resulting Secret
+	// when the data is deleted from the provider
+	// Defaults to 'Retain'
+	// +optional
+	// +kubebuilder:default="Retain"
+	DeletionPolicy ExternalSecretDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// DriftPolicy defines rules on how to handle changes of the data, labels or
+	// annotations of the resulting Secret since it was last synced
+	// Defaults to 'Correct'
+	// +optional
+	// +kubebuilder:default="Correct"
+	DriftPolicy ExternalSecretDriftPolicy `json:"driftPolicy,omitempty"`
+
 	// Template defines a blueprint for the created Secret resource.
 	// +optional
 	Template *ExternalSecretTemplate `json:"template,omitempty"`
@@ -107,6 +177,21 @@ type ExternalSecretTarget struct {
 	// Immutable defines if the final secret will be immutable
 	// +optional
 	Immutable bool `json:"immutable,omitempty"`
+
+	// Manifest defines the kind of the resource the data is written to,
+	// defaults to a Secret. Kinds other than Secret and ConfigMap are rendered
+	// from the template manifest and must be allowed in the controller.
+	// +optional
+	Manifest *ExternalSecretTargetManifest `json:"manifest,omitempty"`
+}
+
+// ExternalSecretTargetManifest defines the apiVersion and kind of the target resource.
+type ExternalSecretTargetManifest struct {
+	// APIVersion of the target resource, e.g. v1 or argoproj.io/v1alpha1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the target resource, e.g. ConfigMap
+	Kind string `json:"kind"`
 }
 
 // ExternalSecretData defines the connection between the Kubernetes Secret key (spec.data.<key>) and the Provider data.
@@ -128,11 +213,187 @@ type ExternalSecretDataRemoteRef struct {
 	// +optional
 	// Used to select a specific property of the Provider value (if a map), if supported
 	Property string `json:"property,omitempty"`
+
+	// Used to decode the value fetched from the Provider.
+	// Defaults to None
+	// +optional
+	// +kubebuilder:default="None"
+	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+}
+
+// ExternalSecretDecodingStrategy defines how the values fetched from the Provider are decoded.
+// +kubebuilder:validation:Enum=None;Base64;Base64URL;Auto
+type ExternalSecretDecodingStrategy string
+
+const (
+	// ExternalSecretDecodeNone uses the values as they are.
+	ExternalSecretDecodeNone ExternalSecretDecodingStrategy = "None"
+	// ExternalSecretDecodeBase64 decodes the values with the standard base64 encoding.
+	ExternalSecretDecodeBase64 ExternalSecretDecodingStrategy = "Base64"
+	// ExternalSecretDecodeBase64URL decodes the values with the URL safe base64 encoding.
+	ExternalSecretDecodeBase64URL ExternalSecretDecodingStrategy = "Base64URL"
+	// ExternalSecretDecodeAuto decodes the values with the base64 encoding that fits,
+	// values that are not base64 encoded are used as they are.
+	ExternalSecretDecodeAuto ExternalSecretDecodingStrategy = "Auto"
+)
+
+// ExternalSecretDataFromRemoteRef defines the Provider data fetched by dataFrom.
+// Exactly one of key, find or generatorRef must be set.
+type ExternalSecretDataFromRemoteRef struct {
+	// Key is the key used in the Provider
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Used to select a specific version of the Provider value, if supported
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	// Used to select a specific property of the Provider value (if a map), if supported
+	Property string `json:"property,omitempty"`
+
+	// Find is used to fetch all secrets of the Provider that match the given criteria.
+	// Every match is added to the Secret as a single key.
+	// +optional
+	Find *ExternalSecretFind `json:"find,omitempty"`
+
+	// Used to decode the values fetched from the Provider or produced by the generator.
+	// Defaults to None
+	// +optional
+	// +kubebuilder:default="None"
+	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// Rewrite is applied to the keys of the fetched data in the given order.
+	// The resulting keys must be valid Secret keys.
+	// +optional
+	Rewrite []ExternalSecretRewrite `json:"rewrite,omitempty"`
+
+	// GeneratorRef references a generator that produces the data
+	// instead of fetching it from the SecretStore.
+	// The data is generated again on every refresh.
+	// +optional
+	GeneratorRef *GeneratorRef `json:"generatorRef,omitempty"`
+}
+
+// ExternalSecretRewrite is a rule that rewrites the keys of the data fetched by dataFrom.
+// Exactly one of regexp, prefix or transform must be set.
+type ExternalSecretRewrite struct {
+	// Regexp replaces all matches of a regular expression in the keys.
+	// +optional
+	Regexp *ExternalSecretRewriteRegexp `json:"regexp,omitempty"`
+
+	// Prefix is added to the beginning of the keys.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Transform renders the keys with a Go template.
+	// +optional
+	Transform *ExternalSecretRewriteTransform `json:"transform,omitempty"`
+}
+
+// ExternalSecretRewriteRegexp replaces all matches of Source with Target.
+type ExternalSecretRewriteRegexp struct {
+	// Source is the regular expression the keys are matched against.
+	Source string `json:"source"`
+
+	// Target is the replacement of the matches, it may reference
+	// capture groups of the regular expression like $1.
+	Target string `json:"target"`
+}
+
+// ExternalSecretRewriteTransform renders the keys with a Go template.
+type ExternalSecretRewriteTransform struct {
+	// Template renders the new key, the current key is available as .value.
+	// The functions of the target template like upper and lower can be used.
+	Template string `json:"template"`
+}
+
+// GeneratorRef references a generator resource in the namespace of the ExternalSecret.
+type GeneratorRef struct {
+	// APIVersion of the generator resource
+	// +kubebuilder:default="generators.external-secrets.io/v1alpha1"
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the generator resource
+	// +kubebuilder:validation:Enum=Password;SSHKey;ECRAuthorizationToken;GCRAccessToken
+	Kind string `json:"kind"`
+
+	// Name of the generator resource
+	Name string `json:"name"`
+}
+
+// UsesSecretStore returns true if any data of the spec is fetched from the SecretStore.
+func (spec *ExternalSecretSpec) UsesSecretStore() bool {
+	if len(spec.Data) > 0 {
+		return true
+	}
+	for _, ref := range spec.DataFrom {
+		if ref.GeneratorRef == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRemoteRef returns the ExternalSecretDataRemoteRef the key, version and property point to.
+func (r *ExternalSecretDataFromRemoteRef) GetRemoteRef() ExternalSecretDataRemoteRef {
+	return ExternalSecretDataRemoteRef{
+		Key:              r.Key,
+		Version:          r.Version,
+		Property:         r.Property,
+		DecodingStrategy: r.DecodingStrategy,
+	}
 }
 
+// ExternalSecretFind defines the criteria the secrets of the Provider are matched against.
+// All criteria that are set must match.
+type ExternalSecretFind struct {
+	// Name matches the name of the secrets
+	// +optional
+	Name *FindName `json:"name,omitempty"`
+
+	// Path is a prefix the name of the secrets must start with
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Tags the secrets must have (labels or custom metadata, depending on the Provider)
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// KeyNaming defines how the name of a secret is turned into the key of the Secret.
+	// Defaults to 'Default'
+	// +optional
+	// +kubebuilder:default="Default"
+	KeyNaming ExternalSecretFindKeyNaming `json:"keyNaming,omitempty"`
+}
+
+// FindName matches the name of the secrets.
+type FindName struct {
+	// RegExp is a regular expression the name of the secrets must match
+	// +optional
+	RegExp string `json:"regexp,omitempty"`
+}
+
+// ExternalSecretFindKeyNaming defines how the name of a found secret is turned into a key of the Secret.
+// +kubebuilder:validation:Enum=Default;Base
+type ExternalSecretFindKeyNaming string
+
+const (
+	// FindKeyNamingDefault uses the full name of the secret.
+	// Every character that is not allowed in a Secret key is replaced with '_'.
+	FindKeyNamingDefault ExternalSecretFindKeyNaming = "Default"
+
+	// FindKeyNamingBase uses the part of the name after the last '/'.
+	// Every character that is not allowed in a Secret key is replaced with '_'.
+	FindKeyNamingBase ExternalSecretFindKeyNaming = "Base"
+)
+
 // ExternalSecretSpec defines the desired state of ExternalSecret.
 type ExternalSecretSpec struct {
-	SecretStoreRef SecretStoreRef `json:"secretStoreRef"`
+	// SecretStoreRef references the SecretStore the data is fetched from.
+	// It may be omitted if all data is produced by generators.
+	// +optional
+	SecretStoreRef *SecretStoreRef `json:"secretStoreRef,omitempty"`
 
 	Target ExternalSecretTarget `json:"target"`
 
@@ -142,14 +403,26 @@ type ExternalSecretSpec struct {
 	// +kubebuilder:default="1h"
 	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
 
+	// RefreshPolicy defines when the Secret is refreshed, the refreshInterval
+	// only applies to Periodic. Defaults to 'Periodic'.
+	// +optional
+	// +kubebuilder:default="Periodic"
+	RefreshPolicy ExternalSecretRefreshPolicy `json:"refreshPolicy,omitempty"`
+
 	// Data defines the connection between the Kubernetes Secret keys and the Provider data
 	// +optional
 	Data []ExternalSecretData `json:"data,omitempty"`
 
 	// DataFrom is used to fetch all properties from a specific Provider data
+	// or all Provider data that matches the find criteria.
 	// If multiple entries are specified, the Secret keys are merged in the specified order
 	// +optional
-	DataFrom []ExternalSecretDataRemoteRef `json:"dataFrom,omitempty"`
+	DataFrom []ExternalSecretDataFromRemoteRef `json:"dataFrom,omitempty"`
+
+	// RecordProvenance lists the source of every key of the Secret
+	// in .status.provenance after each successful sync.
+	// +optional
+	RecordProvenance bool `json:"recordProvenance,omitempty"`
 }
 
 type ExternalSecretConditionType string
@@ -157,6 +430,7 @@ type ExternalSecretConditionType string
 const (
 	ExternalSecretReady   ExternalSecretConditionType = "Ready"
 	ExternalSecretDeleted ExternalSecretConditionType = "Deleted"
+	ExternalSecretDrifted ExternalSecretConditionType = "Drifted"
 )
 
 type ExternalSecretStatusCondition struct {
@@ -180,6 +454,22 @@ const (
 	ConditionReasonSecretSyncedError = "SecretSyncedError"
 	// ConditionReasonSecretDeleted indicates that the secret has been deleted.
 	ConditionReasonSecretDeleted = "SecretDeleted"
+	// ConditionReasonSecretDrifted indicates that the secret was changed since it was last synced.
+	ConditionReasonSecretDrifted = "SecretDrifted"
+	// ConditionReasonInvalidKeys indicates that the data contains keys that are not valid Secret keys.
+	ConditionReasonInvalidKeys = "InvalidKeys"
+	// ConditionReasonSecretNotFound indicates that a referenced secret does not exist in the provider.
+	ConditionReasonSecretNotFound = "SecretNotFound"
+	// ConditionReasonPermissionDenied indicates that the provider denied access to a secret.
+	ConditionReasonPermissionDenied = "PermissionDenied"
+	// ConditionReasonUnauthenticated indicates that the provider rejected the credentials of the store.
+	ConditionReasonUnauthenticated = "Unauthenticated"
+	// ConditionReasonRateLimited indicates that the provider throttled the requests.
+	ConditionReasonRateLimited = "RateLimited"
+	// ConditionReasonInvalidRef indicates that a remote reference can't be resolved, e.g. a missing property.
+	ConditionReasonInvalidRef = "InvalidRef"
+	// ConditionReasonProviderUnavailable indicates that the provider could not be reached or failed temporarily.
+	ConditionReasonProviderUnavailable = "ProviderUnavailable"
 )
 
 type ExternalSecretStatus struct {
@@ -191,8 +481,50 @@ type ExternalSecretStatus struct {
 	// SyncedResourceVersion keeps track of the last synced version
 	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
 
+	// ForceSync is the value of the force-sync annotation handled by the last successful sync.
+	// +optional
+	ForceSync string `json:"forceSync,omitempty"`
+
 	// +optional
 	Conditions []ExternalSecretStatusCondition `json:"conditions,omitempty"`
+
+	// Provenance lists the source of every key of the Secret, sorted by key.
+	// It is only set if spec.recordProvenance is true and never contains values.
+	// +optional
+	Provenance []ExternalSecretKeyProvenance `json:"provenance,omitempty"`
+}
+
+// ExternalSecretKeyProvenance describes where the value of a Secret key was read from.
+type ExternalSecretKeyProvenance struct {
+	// SecretKey is the key of the fetched data.
+	// It is the key of the Secret unless the data is rendered with a template.
+	SecretKey string `json:"secretKey"`
+
+	// RemoteKey is the key of the Provider data the value was read from
+	// +optional
+	RemoteKey string `json:"remoteKey,omitempty"`
+
+	// Property is the property of the Provider data the value was read from.
+	// For dataFrom it is the property of the fetched map before the rewrite rules were applied.
+	// +optional
+	Property string `json:"property,omitempty"`
+
+	// Version is the version of the Provider data that was requested
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// VersionID is the version the Provider resolved the data to, if the Provider reports it
+	// (AWS VersionId or parameter version, GCP version number, Vault KV v2 metadata version, Azure version)
+	// +optional
+	VersionID string `json:"versionID,omitempty"`
+
+	// StoreRef is the store the value was read from
+	// +optional
+	StoreRef *SecretStoreRef `json:"storeRef,omitempty"`
+
+	// GeneratorRef is the generator that produced the value
+	// +optional
+	GeneratorRef *GeneratorRef `json:"generatorRef,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -214,6 +546,15 @@ type ExternalSecret struct {
 const (
 	// AnnotationDataHash is used to ensure consistency.
 	AnnotationDataHash = "reconcile.external-secrets.io/data-hash"
+
+	// AnnotationForceSync forces a sync of the ExternalSecret within its refreshInterval
+	// whenever it is set to a new value, e.g. the current timestamp.
+	// The handled value is reported in status.forceSync.
+	AnnotationForceSync = "force-sync"
+
+	// FinalizerMergedFields removes the fields the ExternalSecret merged into its target
+	// with creationPolicy=Merge before the ExternalSecret is deleted.
+	FinalizerMergedFields = "externalsecrets.external-secrets.io/merged-fields"
 )
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,136 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PushSecretSelector defines the Kubernetes Secret the data is read from.
+type PushSecretSelector struct {
+	// Secret is the name of the source Secret in the namespace of the PushSecret.
+	Secret PushSecretSecretRef `json:"secret"`
+}
+
+// PushSecretSecretRef references a Secret in the namespace of the PushSecret.
+type PushSecretSecretRef struct {
+	// Name of the Secret
+	Name string `json:"name"`
+}
+
+// PushSecretData defines the connection between a key of the source Secret and the Provider data.
+type PushSecretData struct {
+	// SecretKey is the key of the source Secret whose value is pushed
+	SecretKey string `json:"secretKey"`
+
+	RemoteRef PushSecretRemoteRef `json:"remoteRef"`
+}
+
+// PushSecretRemoteRef defines the Provider location the data is written to.
+type PushSecretRemoteRef struct {
+	// RemoteKey is the key used in the Provider, mandatory
+	RemoteKey string `json:"remoteKey"`
+
+	// +optional
+	// Property is used to write the value into a specific property of the Provider value (if a map).
+	// Other properties of the Provider value are left untouched.
+	// If not set, the whole Provider value is replaced.
+	Property string `json:"property,omitempty"`
+}
+
+// PushSecretSpec defines the desired state of PushSecret.
+type PushSecretSpec struct {
+	SecretStoreRef SecretStoreRef `json:"secretStoreRef"`
+
+	Selector PushSecretSelector `json:"selector"`
+
+	// RefreshInterval is the amount of time before the values are pushed again to the SecretStore provider
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h"
+	// May be set to zero to push them once. Defaults to 1h.
+	// +kubebuilder:default="1h"
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+
+	// Data defines the connection between the Kubernetes Secret keys and the Provider data
+	// +kubebuilder:validation:MinItems=1
+	Data []PushSecretData `json:"data"`
+}
+
+type PushSecretConditionType string
+
+const (
+	PushSecretReady PushSecretConditionType = "Ready"
+)
+
+type PushSecretStatusCondition struct {
+	Type   PushSecretConditionType `json:"type"`
+	Status corev1.ConditionStatus  `json:"status"`
+
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+const (
+	// ConditionReasonSecretPushed indicates that the secret was pushed to the provider.
+	ConditionReasonSecretPushed = "SecretPushed"
+	// ConditionReasonSecretPushedError indicates that there was an error pushing the secret.
+	ConditionReasonSecretPushedError = "SecretPushedError"
+)
+
+type PushSecretStatus struct {
+	// +nullable
+	// refreshTime is the time and date the source secret was last
+	// pushed to the provider
+	RefreshTime metav1.Time `json:"refreshTime,omitempty"`
+
+	// SyncedResourceVersion keeps track of the last pushed version
+	// of the PushSecret and its source Secret
+	SyncedResourceVersion string `json:"syncedResourceVersion,omitempty"`
+
+	// +optional
+	Conditions []PushSecretStatusCondition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PushSecret is the Schema for the pushsecrets API.
+// It writes the data of a Kubernetes Secret to a SecretStore provider.
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={externalsecrets},shortName=ps
+// +kubebuilder:printcolumn:name="Store",type=string,JSONPath=`.spec.secretStoreRef.name`
+// +kubebuilder:printcolumn:name="Refresh Interval",type=string,JSONPath=`.spec.refreshInterval`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+type PushSecret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PushSecretSpec   `json:"spec,omitempty"`
+	Status PushSecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PushSecretList contains a list of PushSecret resources.
+type PushSecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PushSecret `json:"items"`
+}
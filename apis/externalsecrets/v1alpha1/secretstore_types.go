@@ -29,7 +29,7 @@ type SecretStoreSpec struct {
 	// Used to configure the provider. Only one provider may be set
 	Provider *SecretStoreProvider `json:"provider"`
 
-	// Used to configure http retries if failed
+	// Used to configure the retries of transient provider errors
 	// +optional
 	RetrySettings *SecretStoreRetrySettings `json:"retrySettings,omitempty"`
 }
@@ -83,8 +83,15 @@ type SecretStoreProvider struct {
 	Webhook *WebhookProvider `json:"webhook,omitempty"`
 }
 
+// SecretStoreRetrySettings configures how often and how fast transient provider errors are retried.
 type SecretStoreRetrySettings struct {
-	MaxRetries    *int32  `json:"maxRetries,omitempty"`
+	// MaxRetries is the number of retries of a failed provider call, defaults to 3.
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// RetryInterval is the wait before the first retry, defaults to 5s.
+	// It doubles with every further retry.
+	// +optional
 	RetryInterval *string `json:"retryInterval,omitempty"`
 }
 
@@ -92,6 +99,15 @@ type SecretStoreConditionType string
 
 const (
 	SecretStoreReady SecretStoreConditionType = "Ready"
+
+	// ReasonInvalidStore indicates that the store spec could not be processed.
+	ReasonInvalidStore = "InvalidStoreConfiguration"
+	// ReasonInvalidProviderConfig indicates that the provider client could not be constructed.
+	ReasonInvalidProviderConfig = "InvalidProviderConfig"
+	// ReasonValidationFailed indicates that the provider could not be reached with the given configuration.
+	ReasonValidationFailed = "ValidationFailed"
+	// ReasonStoreValid indicates that the store has been validated successfully.
+	ReasonStoreValid = "Valid"
 )
 
 type SecretStoreStatusCondition struct {
@@ -118,6 +134,7 @@ type SecretStoreStatus struct {
 
 // SecretStore represents a secure external location for storing secrets, which can be referenced as part of `storeRef` fields.
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,categories={externalsecrets},shortName=ss
 type SecretStore struct {
@@ -141,6 +158,7 @@ type SecretStoreList struct {
 
 // ClusterSecretStore represents a secure external location for storing secrets, which can be referenced as part of `storeRef` fields.
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={externalsecrets},shortName=css
 type ClusterSecretStore struct {
@@ -44,6 +44,12 @@ type WebhookProvider struct {
 	// Result formatting
 	Result WebhookResult `json:"result"`
 
+	// NotFound defines the error responses of the webhook for a secret that does not exist.
+	// Other error responses, including a plain 404, are not treated as a missing secret,
+	// so the target is not deleted with deletionPolicy=Delete.
+	// +optional
+	NotFound *WebhookNotFound `json:"notFound,omitempty"`
+
 	// Secrets to fill in templates
 	// These secrets will be passed to the templating function as key value pairs under the given name
 	// +optional
@@ -92,6 +98,22 @@ type WebhookResult struct {
 	JSONPath string `json:"jsonPath,omitempty"`
 }
 
+// WebhookNotFound matches the error responses of a webhook for a secret that does not exist.
+// All of the given fields must match.
+type WebhookNotFound struct {
+	// Status code of the response, e.g. 404.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Json path of a field in the response body which is set for a missing secret.
+	// +optional
+	JSONPath string `json:"jsonPath,omitempty"`
+
+	// Value the field at jsonPath must have, any value matches if not set.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
 type WebhookSecret struct {
 	// Name of this secret in templates
 	Name string `json:"name"`
@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// GCRAccessTokenSpec configures the GCP project the access token is requested for.
+type GCRAccessTokenSpec struct {
+	// Auth defines how to authenticate with GCP, it works like the auth of a GCPSM SecretStore.
+	// +optional
+	Auth esv1alpha1.GCPSMAuth `json:"auth,omitempty"`
+
+	// ProjectID defines which project to use to authenticate with
+	ProjectID string `json:"projectID"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCRAccessToken generates an OAuth2 access token for the Google Container Registry.
+// It returns the keys "username", "password" and "expiry", expiry is a unix timestamp.
+// +kubebuilder:resource:scope=Namespaced,categories={externalsecrets,generators}
+type GCRAccessToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GCRAccessTokenSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCRAccessTokenList contains a list of GCRAccessToken resources.
+type GCRAccessTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCRAccessToken `json:"items"`
+}
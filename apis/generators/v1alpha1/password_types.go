@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PasswordSpec controls the behavior of the password generator.
+type PasswordSpec struct {
+	// Length of the password to be generated.
+	// Defaults to 24
+	// +kubebuilder:default=24
+	// +kubebuilder:validation:Minimum=1
+	Length int `json:"length"`
+
+	// Digits specifies the number of digits in the generated
+	// password. If omitted it defaults to 25% of the length of the password
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Digits *int `json:"digits,omitempty"`
+
+	// Symbols specifies the number of symbol characters in the generated
+	// password. If omitted it defaults to 25% of the length of the password
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Symbols *int `json:"symbols,omitempty"`
+
+	// SymbolCharacters specifies the special characters that should be used
+	// in the generated password.
+	// Defaults to "~!@#$%^&*()_+`-={}|[]\:"<>?,./"
+	// +optional
+	SymbolCharacters *string `json:"symbolCharacters,omitempty"`
+
+	// Set NoUpper to disable uppercase characters
+	// +optional
+	NoUpper bool `json:"noUpper"`
+
+	// Set AllowRepeat to allow repeating characters.
+	// +optional
+	AllowRepeat bool `json:"allowRepeat"`
+}
+
+// +kubebuilder:object:root=true
+
+// Password generates a random password on every refresh of the ExternalSecret referencing it.
+// The password is returned with the key "password".
+// +kubebuilder:resource:scope=Namespaced,categories={externalsecrets,generators}
+type Password struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PasswordSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PasswordList contains a list of Password resources.
+type PasswordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Password `json:"items"`
+}
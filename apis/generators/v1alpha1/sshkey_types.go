@@ -0,0 +1,71 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SSHKeyType is the type of the generated SSH key.
+// +kubebuilder:validation:Enum=rsa;ed25519
+type SSHKeyType string
+
+const (
+	// SSHKeyTypeRSA generates a RSA key pair.
+	SSHKeyTypeRSA SSHKeyType = "rsa"
+	// SSHKeyTypeED25519 generates an ED25519 key pair.
+	SSHKeyTypeED25519 SSHKeyType = "ed25519"
+)
+
+// SSHKeySpec controls the behavior of the SSH key generator.
+type SSHKeySpec struct {
+	// KeyType is the type of the key pair.
+	// Defaults to rsa
+	// +optional
+	// +kubebuilder:default="rsa"
+	KeyType SSHKeyType `json:"keyType,omitempty"`
+
+	// KeySize is the size of a RSA key in bits, it is ignored for other key types.
+	// Defaults to 4096
+	// +optional
+	// +kubebuilder:validation:Minimum=2048
+	KeySize *int `json:"keySize,omitempty"`
+
+	// Comment is added to the public key
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSHKey generates a new SSH key pair on every refresh of the ExternalSecret referencing it.
+// The private key is returned in the OpenSSH format with the key "privateKey",
+// the public key in the authorized_keys format with the key "publicKey".
+// +kubebuilder:resource:scope=Namespaced,categories={externalsecrets,generators}
+type SSHKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SSHKeySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSHKeyList contains a list of SSHKey resources.
+type SSHKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSHKey `json:"items"`
+}
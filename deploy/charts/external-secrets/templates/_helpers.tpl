@@ -60,3 +60,32 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Create the name of the webhook service
+*/}}
+{{- define "external-secrets.webhookName" -}}
+{{- printf "%s-webhook" (include "external-secrets.fullname" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/*
+Create the name of the secret holding the webhook serving certificate
+*/}}
+{{- define "external-secrets.webhookCertSecretName" -}}
+{{- printf "%s-tls" (include "external-secrets.webhookName" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
+
+{{/*
+Join the targetKinds as Kind or Kind.group for the --target-kinds flag
+*/}}
+{{- define "external-secrets.targetKinds" -}}
+{{- $kinds := list }}
+{{- range . }}
+{{- if .group }}
+{{- $kinds = append $kinds (printf "%s.%s" .kind .group) }}
+{{- else }}
+{{- $kinds = append $kinds .kind }}
+{{- end }}
+{{- end }}
+{{- join "," $kinds }}
+{{- end }}
@@ -86,7 +86,7 @@ func makeDefaultTestCase(f *Framework) *TestCase {
 				Namespace: f.Namespace.Name,
 			},
 			Spec: esv1alpha1.ExternalSecretSpec{
-				SecretStoreRef: esv1alpha1.SecretStoreRef{
+				SecretStoreRef: &esv1alpha1.SecretStoreRef{
 					Name: f.Namespace.Name,
 				},
 				Target: esv1alpha1.ExternalSecretTarget{
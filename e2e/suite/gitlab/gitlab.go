@@ -12,13 +12,14 @@ limitations under the License.
 */
 package gitlab
 
-// TODO - Gitlab only accepts variable names with alphanumeric and '_'
-// whereas ESO only accepts names with alphanumeric and '-'.
-// Current workaround is to remove all hyphens and underscores set in e2e/framework/util/util.go
-// and in e2e/suite/common/common.go, but this breaks Azure provider.
+// Gitlab only accepts variable names with alphanumeric and '_'
+// whereas the common test cases use names with alphanumeric and '-'.
+// The provider creates the variables with '_' instead of '-',
+// withVariableKeys makes the ExternalSecret reference them accordingly.
 
 import (
 	"os"
+	"strings"
 
 	// nolint
 	. "github.com/onsi/ginkgo"
@@ -41,12 +42,27 @@ var _ = Describe("[gitlab] ", func() {
 	}
 
 	DescribeTable("sync secrets", framework.TableFunc(f, prov),
-		Entry(common.SimpleDataSync(f)),
-		Entry(common.JSONDataWithProperty(f)),
-		Entry(common.JSONDataFromSync(f)),
-		Entry(common.NestedJSONWithGJSON(f)),
-		Entry(common.JSONDataWithTemplate(f)),
-		Entry(common.SyncWithoutTargetName(f)),
-		Entry(common.JSONDataWithoutTargetName(f)),
+		Entry(withVariableKeys(common.SimpleDataSync(f))),
+		Entry(withVariableKeys(common.JSONDataWithProperty(f))),
+		Entry(withVariableKeys(common.JSONDataFromSync(f))),
+		Entry(withVariableKeys(common.NestedJSONWithGJSON(f))),
+		Entry(withVariableKeys(common.JSONDataWithTemplate(f))),
+		Entry(withVariableKeys(common.SyncWithoutTargetName(f))),
+		Entry(withVariableKeys(common.JSONDataWithoutTargetName(f))),
 	)
 })
+
+// withVariableKeys replaces '-' with '_' in the remote keys of the ExternalSecret.
+func withVariableKeys(desc string, tweak func(*framework.TestCase)) (string, func(*framework.TestCase)) {
+	return desc, func(tc *framework.TestCase) {
+		tweak(tc)
+		for i := range tc.ExternalSecret.Spec.Data {
+			ref := &tc.ExternalSecret.Spec.Data[i].RemoteRef
+			ref.Key = strings.ReplaceAll(ref.Key, "-", "_")
+		}
+		for i := range tc.ExternalSecret.Spec.DataFrom {
+			ref := &tc.ExternalSecret.Spec.DataFrom[i]
+			ref.Key = strings.ReplaceAll(ref.Key, "-", "_")
+		}
+	}
+}
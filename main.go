@@ -15,8 +15,11 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -28,8 +31,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/clusterexternalsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+	"github.com/external-secrets/external-secrets/pkg/controllers/pushsecret"
 	"github.com/external-secrets/external-secrets/pkg/controllers/secretstore"
+	"github.com/external-secrets/external-secrets/pkg/events"
+	"github.com/external-secrets/external-secrets/pkg/provider/clientcache"
+	"github.com/external-secrets/external-secrets/pkg/provider/responsecache"
+	"github.com/external-secrets/external-secrets/pkg/tracing"
+	"github.com/external-secrets/external-secrets/pkg/webhook"
 )
 
 var (
@@ -40,6 +51,7 @@ var (
 func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = esv1alpha1.AddToScheme(scheme)
+	_ = genv1alpha1.AddToScheme(scheme)
 }
 
 func main() {
@@ -47,16 +59,42 @@ func main() {
 	var controllerClass string
 	var enableLeaderElection bool
 	var concurrent int
+	var providerConcurrency int
 	var loglevel string
 	var namespace string
+	var storeRequeueInterval time.Duration
+	var clientCacheTTL time.Duration
+	var responseCacheTTL time.Duration
+	var responseCacheSize int
+	var eventInterval time.Duration
+	var otlpEndpoint string
+	var otlpInsecure bool
+	var traceSampleRatio float64
+	var targetKinds string
+	var enableWebhook bool
+	var webhookPort int
+	var webhookCertDir string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&controllerClass, "controller-class", "default", "the controller is instantiated with a specific controller name and filters ES based on this property")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.IntVar(&concurrent, "concurrent", 1, "The number of concurrent ExternalSecret reconciles.")
+	flag.IntVar(&providerConcurrency, "provider-concurrency", 5, "The number of concurrent provider calls of an ExternalSecret reconcile for providers that can't read secrets in a batch but support concurrent calls.")
 	flag.StringVar(&loglevel, "loglevel", "info", "loglevel to use, one of: debug, info, warn, error, dpanic, panic, fatal")
 	flag.StringVar(&namespace, "namespace", "", "watch external secrets scoped in the provided namespace only")
+	flag.BoolVar(&enableWebhook, "enable-webhook", false, "Serve the validating admission webhook for (Cluster)ExternalSecrets and (Cluster)SecretStores.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "The directory that contains the webhook server key and certificate (tls.key and tls.crt).")
+	flag.DurationVar(&storeRequeueInterval, "store-requeue-interval", time.Minute*5, "Time duration between reconciling (Cluster)SecretStores")
+	flag.DurationVar(&clientCacheTTL, "client-cache-ttl", time.Minute*5, "Time duration a provider client is shared between ExternalSecret reconciles, 0 disables the client cache")
+	flag.DurationVar(&responseCacheTTL, "response-cache-ttl", 0, "Time duration the secrets fetched from a provider are shared between ExternalSecrets using the same store, 0 disables the response cache")
+	flag.IntVar(&responseCacheSize, "response-cache-size", 1000, "The maximum number of provider responses held by the response cache")
+	flag.DurationVar(&eventInterval, "event-interval", events.DefaultInterval, "Time duration identical Kubernetes events of a resource are dropped for, 0 emits every event")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "", "The host:port of the OTLP gRPC collector the traces are exported to, empty disables tracing")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false, "Connect to the OTLP collector without TLS")
+	flag.Float64Var(&traceSampleRatio, "trace-sample-ratio", 1, "The ratio of the reconciles that are traced, between 0 and 1")
+	flag.StringVar(&targetKinds, "target-kinds", "", "Comma separated kinds besides Secret the ExternalSecrets may write to, as Kind or Kind.group, e.g. ConfigMap,Application.argoproj.io. The controller needs write permissions for them")
 	flag.Parse()
 
 	var lvl zapcore.Level
@@ -71,7 +109,8 @@ func main() {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
-		Port:               9443,
+		Port:               webhookPort,
+		CertDir:            webhookCertDir,
 		LeaderElection:     enableLeaderElection,
 		LeaderElectionID:   "external-secrets-controller",
 		Namespace:          namespace,
@@ -81,27 +120,104 @@ func main() {
 		os.Exit(1)
 	}
 
+	if otlpEndpoint != "" {
+		tracer, err := tracing.Setup(context.Background(), tracing.Options{
+			Endpoint:    otlpEndpoint,
+			Insecure:    otlpInsecure,
+			SampleRatio: traceSampleRatio,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		if err = mgr.Add(tracer); err != nil {
+			setupLog.Error(err, "unable to add tracing")
+			os.Exit(1)
+		}
+	}
+
+	recorder := events.NewRateLimitedRecorder(mgr.GetEventRecorderFor("external-secrets"), eventInterval)
 	if err = (&secretstore.Reconciler{
 		Client:          mgr.GetClient(),
 		Log:             ctrl.Log.WithName("controllers").WithName("SecretStore"),
 		Scheme:          mgr.GetScheme(),
+		Recorder:        recorder,
 		ControllerClass: controllerClass,
+		RequeueInterval: storeRequeueInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SecretStore")
 		os.Exit(1)
 	}
-	if err = (&externalsecret.Reconciler{
+	if err = (&secretstore.ClusterStoreReconciler{
 		Client:          mgr.GetClient(),
-		Log:             ctrl.Log.WithName("controllers").WithName("ExternalSecret"),
+		Log:             ctrl.Log.WithName("controllers").WithName("ClusterSecretStore"),
 		Scheme:          mgr.GetScheme(),
+		Recorder:        recorder,
 		ControllerClass: controllerClass,
-		RequeueInterval: time.Hour,
+		RequeueInterval: storeRequeueInterval,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterSecretStore")
+		os.Exit(1)
+	}
+	var clientCache *clientcache.Cache
+	if clientCacheTTL > 0 {
+		clientCache = clientcache.New(mgr.GetClient(), clientCacheTTL)
+		if err = mgr.Add(clientCache); err != nil {
+			setupLog.Error(err, "unable to add provider client cache")
+			os.Exit(1)
+		}
+	}
+	var responseCache *responsecache.Cache
+	if responseCacheTTL > 0 {
+		if responseCacheSize <= 0 {
+			setupLog.Error(errors.New("response-cache-size must be positive"), "invalid response cache size")
+			os.Exit(1)
+		}
+		responseCache = responsecache.New(mgr.GetClient(), responseCacheTTL, responseCacheSize)
+	}
+	if err = (&externalsecret.Reconciler{
+		Client:              mgr.GetClient(),
+		Log:                 ctrl.Log.WithName("controllers").WithName("ExternalSecret"),
+		Scheme:              mgr.GetScheme(),
+		Recorder:            recorder,
+		ControllerClass:     controllerClass,
+		RequeueInterval:     time.Hour,
+		ClientCache:         clientCache,
+		ResponseCache:       responseCache,
+		ProviderConcurrency: providerConcurrency,
+		TargetKinds:         externalsecret.ParseTargetKinds(strings.Split(targetKinds, ",")),
 	}).SetupWithManager(mgr, controller.Options{
 		MaxConcurrentReconciles: concurrent,
 	}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ExternalSecret")
 		os.Exit(1)
 	}
+	if err = (&clusterexternalsecret.Reconciler{
+		Client:          mgr.GetClient(),
+		Log:             ctrl.Log.WithName("controllers").WithName("ClusterExternalSecret"),
+		Scheme:          mgr.GetScheme(),
+		RequeueInterval: time.Minute,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterExternalSecret")
+		os.Exit(1)
+	}
+	if err = (&pushsecret.Reconciler{
+		Client:          mgr.GetClient(),
+		Log:             ctrl.Log.WithName("controllers").WithName("PushSecret"),
+		Scheme:          mgr.GetScheme(),
+		ControllerClass: controllerClass,
+		RequeueInterval: time.Hour,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PushSecret")
+		os.Exit(1)
+	}
+
+	if enableWebhook {
+		if err = webhook.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -0,0 +1,243 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterexternalsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/controllers/externalsecret"
+)
+
+const (
+	errGetCES               = "could not get ClusterExternalSecret"
+	errPatchStatus          = "unable to patch status"
+	errConvertLabelSelector = "unable to convert namespace selector: %w"
+	errListNamespaces       = "could not list namespaces: %w"
+	errListExternalSecrets  = "could not list ExternalSecrets of ClusterExternalSecret"
+	errDeleteES             = "could not delete ExternalSecret"
+	errSetCtrlReference     = "could not set ClusterExternalSecret controller reference: %w"
+	errSecretAlreadyExists  = "ExternalSecret %q already exists and is not managed by ClusterExternalSecret %q"
+	errNamespacesFailed     = "one or more namespaces failed"
+)
+
+// Reconciler reconciles a ClusterExternalSecret object.
+type Reconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	RequeueInterval time.Duration
+}
+
+// Reconcile creates or updates an ExternalSecret in every Namespace selected by
+// the ClusterExternalSecret and removes the ExternalSecrets of Namespaces that are
+// no longer selected. The ExternalSecrets themselves are synced by the ExternalSecret controller.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("ClusterExternalSecret", req.NamespacedName)
+
+	var ces esv1alpha1.ClusterExternalSecret
+	err := r.Get(ctx, req.NamespacedName, &ces)
+	if apierrors.IsNotFound(err) {
+		// the ExternalSecrets are garbage collected through their owner reference
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, errGetCES)
+		return ctrl.Result{}, nil
+	}
+
+	// patch status when done processing
+	p := client.MergeFrom(ces.DeepCopy())
+	defer func() {
+		err = r.Status().Patch(ctx, &ces, p)
+		if err != nil {
+			log.Error(err, errPatchStatus)
+		}
+	}()
+
+	refreshInt := r.RequeueInterval
+	if ces.Spec.RefreshInterval != nil {
+		refreshInt = ces.Spec.RefreshInterval.Duration
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&ces.Spec.NamespaceSelector)
+	if err != nil {
+		err = fmt.Errorf(errConvertLabelSelector, err)
+		log.Error(err, errConvertLabelSelector)
+		cond := NewClusterExternalSecretCondition(esv1alpha1.ClusterExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonInvalidSelector, err.Error())
+		SetClusterExternalSecretCondition(&ces, *cond)
+		// retrying does not help until the selector changes
+		return ctrl.Result{}, nil
+	}
+
+	var namespaces v1.NamespaceList
+	err = r.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		err = fmt.Errorf(errListNamespaces, err)
+		log.Error(err, errListNamespaces)
+		cond := NewClusterExternalSecretCondition(esv1alpha1.ClusterExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonNamespacesFailed, err.Error())
+		SetClusterExternalSecretCondition(&ces, *cond)
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
+	esName := ces.Spec.ExternalSecretName
+	if esName == "" {
+		esName = ces.Name
+	}
+
+	selected := make(map[string]struct{}, len(namespaces.Items))
+	provisioned := make([]string, 0, len(namespaces.Items))
+	failed := make([]esv1alpha1.ClusterExternalSecretNamespaceFailure, 0)
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		// nothing can be created in a namespace that is being deleted
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+		selected[ns.Name] = struct{}{}
+
+		err = r.createOrUpdateExternalSecret(ctx, &ces, ns.Name, esName)
+		if err != nil {
+			log.V(1).Info("failed to provision namespace", "namespace", ns.Name, "reason", err.Error())
+			failed = append(failed, esv1alpha1.ClusterExternalSecretNamespaceFailure{
+				Namespace: ns.Name,
+				Reason:    err.Error(),
+			})
+			continue
+		}
+		provisioned = append(provisioned, ns.Name)
+	}
+
+	r.deleteOutdatedExternalSecrets(ctx, log, &ces, esName, selected)
+
+	sort.Strings(provisioned)
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].Namespace < failed[j].Namespace
+	})
+	ces.Status.ProvisionedNamespaces = provisioned
+	ces.Status.FailedNamespaces = failed
+
+	cond := NewClusterExternalSecretCondition(esv1alpha1.ClusterExternalSecretReady, v1.ConditionTrue, esv1alpha1.ConditionReasonNamespacesProvisioned, "")
+	if len(failed) > 0 {
+		cond = NewClusterExternalSecretCondition(esv1alpha1.ClusterExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonNamespacesFailed, errNamespacesFailed)
+	}
+	SetClusterExternalSecretCondition(&ces, *cond)
+
+	return ctrl.Result{RequeueAfter: refreshInt}, nil
+}
+
+// createOrUpdateExternalSecret makes sure the ExternalSecret in the given namespace matches the ClusterExternalSecret.
+// It returns an error if the ExternalSecret could not be applied or is not ready.
+func (r *Reconciler) createOrUpdateExternalSecret(ctx context.Context, ces *esv1alpha1.ClusterExternalSecret, namespace, esName string) error {
+	es := &esv1alpha1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      esName,
+			Namespace: namespace,
+		},
+	}
+
+	mutateFunc := func() error {
+		// never take over an ExternalSecret that was created by somebody else
+		if es.UID != "" && !metav1.IsControlledBy(es, ces) {
+			return fmt.Errorf(errSecretAlreadyExists, esName, ces.Name)
+		}
+		if es.Labels == nil {
+			es.Labels = make(map[string]string)
+		}
+		es.Labels[esv1alpha1.LabelClusterExternalSecretName] = ces.Name
+		es.Spec = ces.Spec.ExternalSecretSpec
+		if err := controllerutil.SetControllerReference(ces, es, r.Scheme); err != nil {
+			return fmt.Errorf(errSetCtrlReference, err)
+		}
+		return nil
+	}
+
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, es, mutateFunc)
+	if err != nil {
+		return err
+	}
+
+	cond := externalsecret.GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretReady)
+	if cond != nil && cond.Status == v1.ConditionFalse {
+		return errors.New(cond.Message)
+	}
+	return nil
+}
+
+// deleteOutdatedExternalSecrets removes the ExternalSecrets of the ClusterExternalSecret
+// that live in a namespace that is no longer selected or have an outdated name.
+func (r *Reconciler) deleteOutdatedExternalSecrets(ctx context.Context, log logr.Logger, ces *esv1alpha1.ClusterExternalSecret, esName string, selected map[string]struct{}) {
+	var list esv1alpha1.ExternalSecretList
+	err := r.List(ctx, &list, client.MatchingLabels{esv1alpha1.LabelClusterExternalSecretName: ces.Name})
+	if err != nil {
+		log.Error(err, errListExternalSecrets)
+		return
+	}
+	for i := range list.Items {
+		es := &list.Items[i]
+		if _, ok := selected[es.Namespace]; ok && es.Name == esName {
+			continue
+		}
+		if !metav1.IsControlledBy(es, ces) {
+			continue
+		}
+		err = r.Delete(ctx, es)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, errDeleteES, "ExternalSecret", client.ObjectKeyFromObject(es))
+		}
+	}
+}
+
+// findClusterExternalSecretsForNamespace returns a request for every ClusterExternalSecret.
+// A label change of a namespace can select or deselect it for any of them.
+func (r *Reconciler) findClusterExternalSecretsForNamespace(obj client.Object) []reconcile.Request {
+	var list esv1alpha1.ClusterExternalSecretList
+	err := r.List(context.Background(), &list)
+	if err != nil {
+		r.Log.Error(err, "could not list ClusterExternalSecrets for namespace", "namespace", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+		})
+	}
+	return requests
+}
+
+// SetupWithManager returns a new controller builder that will be started by the provided Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&esv1alpha1.ClusterExternalSecret{}).
+		Owns(&esv1alpha1.ExternalSecret{}).
+		Watches(&source.Kind{Type: &v1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(r.findClusterExternalSecretsForNamespace)).
+		Complete(r)
+}
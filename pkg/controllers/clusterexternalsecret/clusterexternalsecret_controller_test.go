@@ -0,0 +1,224 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterexternalsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+var (
+	timeout  = time.Second * 10
+	interval = time.Millisecond * 250
+)
+
+var _ = Describe("ClusterExternalSecret controller", func() {
+	const (
+		labelKey  = "ces-test"
+		storeName = "test-store"
+	)
+
+	var (
+		ctx        context.Context
+		labelValue string
+		ces        *esv1alpha1.ClusterExternalSecret
+	)
+
+	// createNamespace creates a namespace with the given labels.
+	createNamespace := func(labels map[string]string) string {
+		ns := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "ctrl-test-ces-",
+				Labels:       labels,
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		return ns.Name
+	}
+
+	getExternalSecret := func(namespace, name string) (*esv1alpha1.ExternalSecret, error) {
+		var es esv1alpha1.ExternalSecret
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &es)
+		return &es, err
+	}
+
+	getStatus := func() esv1alpha1.ClusterExternalSecretStatus {
+		var created esv1alpha1.ClusterExternalSecret
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ces), &created)).To(Succeed())
+		return created.Status
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		// every test selects its own namespaces
+		labelValue = rand.String(8)
+		ces = &esv1alpha1.ClusterExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("test-ces-%s", labelValue),
+			},
+			Spec: esv1alpha1.ClusterExternalSecretSpec{
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{labelKey: labelValue},
+				},
+				ExternalSecretSpec: esv1alpha1.ExternalSecretSpec{
+					SecretStoreRef: &esv1alpha1.SecretStoreRef{
+						Name: storeName,
+					},
+					Data: []esv1alpha1.ExternalSecretData{
+						{
+							SecretKey: "foo",
+							RemoteRef: esv1alpha1.ExternalSecretDataRemoteRef{
+								Key: "bar",
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, ces))).To(Succeed())
+	})
+
+	It("should create ExternalSecrets in the selected namespaces only", func() {
+		selected := createNamespace(map[string]string{labelKey: labelValue})
+		other := createNamespace(map[string]string{labelKey: "other"})
+		Expect(k8sClient.Create(ctx, ces)).To(Succeed())
+
+		Eventually(func() error {
+			_, err := getExternalSecret(selected, ces.Name)
+			return err
+		}, timeout, interval).Should(Succeed())
+
+		es, err := getExternalSecret(selected, ces.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(es.Spec).To(Equal(ces.Spec.ExternalSecretSpec))
+		Expect(es.Labels).To(HaveKeyWithValue(esv1alpha1.LabelClusterExternalSecretName, ces.Name))
+		Expect(metav1.IsControlledBy(es, ces)).To(BeTrue())
+
+		_, err = getExternalSecret(other, ces.Name)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		Eventually(func() []string {
+			return getStatus().ProvisionedNamespaces
+		}, timeout, interval).Should(Equal([]string{selected}))
+		cond := GetClusterExternalSecretCondition(getStatus(), esv1alpha1.ClusterExternalSecretReady)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(v1.ConditionTrue))
+	})
+
+	It("should use the configured ExternalSecret name", func() {
+		selected := createNamespace(map[string]string{labelKey: labelValue})
+		ces.Spec.ExternalSecretName = "custom-name"
+		Expect(k8sClient.Create(ctx, ces)).To(Succeed())
+
+		Eventually(func() error {
+			_, err := getExternalSecret(selected, "custom-name")
+			return err
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("should remove the ExternalSecret when a namespace is no longer selected", func() {
+		selected := createNamespace(map[string]string{labelKey: labelValue})
+		Expect(k8sClient.Create(ctx, ces)).To(Succeed())
+
+		Eventually(func() error {
+			_, err := getExternalSecret(selected, ces.Name)
+			return err
+		}, timeout, interval).Should(Succeed())
+
+		var ns v1.Namespace
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: selected}, &ns)).To(Succeed())
+		ns.Labels = map[string]string{labelKey: "other"}
+		Expect(k8sClient.Update(ctx, &ns)).To(Succeed())
+
+		Eventually(func() bool {
+			_, err := getExternalSecret(selected, ces.Name)
+			return apierrors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+		Eventually(func() []string {
+			return getStatus().ProvisionedNamespaces
+		}, timeout, interval).Should(BeEmpty())
+	})
+
+	It("should update the ExternalSecrets when the spec changes", func() {
+		selected := createNamespace(map[string]string{labelKey: labelValue})
+		Expect(k8sClient.Create(ctx, ces)).To(Succeed())
+
+		Eventually(func() error {
+			_, err := getExternalSecret(selected, ces.Name)
+			return err
+		}, timeout, interval).Should(Succeed())
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(ces), ces)).To(Succeed())
+		ces.Spec.ExternalSecretSpec.Data[0].RemoteRef.Key = "baz"
+		Expect(k8sClient.Update(ctx, ces)).To(Succeed())
+
+		Eventually(func() string {
+			es, err := getExternalSecret(selected, ces.Name)
+			if err != nil {
+				return ""
+			}
+			return es.Spec.Data[0].RemoteRef.Key
+		}, timeout, interval).Should(Equal("baz"))
+	})
+
+	It("should report namespaces with a conflicting ExternalSecret as failed", func() {
+		selected := createNamespace(map[string]string{labelKey: labelValue})
+		existing := &esv1alpha1.ExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ces.Name,
+				Namespace: selected,
+			},
+			Spec: esv1alpha1.ExternalSecretSpec{
+				SecretStoreRef: &esv1alpha1.SecretStoreRef{
+					Name: "unrelated",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, existing)).To(Succeed())
+		Expect(k8sClient.Create(ctx, ces)).To(Succeed())
+
+		Eventually(func() []esv1alpha1.ClusterExternalSecretNamespaceFailure {
+			return getStatus().FailedNamespaces
+		}, timeout, interval).Should(Equal([]esv1alpha1.ClusterExternalSecretNamespaceFailure{
+			{
+				Namespace: selected,
+				Reason:    fmt.Sprintf(errSecretAlreadyExists, ces.Name, ces.Name),
+			},
+		}))
+		cond := GetClusterExternalSecretCondition(getStatus(), esv1alpha1.ClusterExternalSecretReady)
+		Expect(cond).ToNot(BeNil())
+		Expect(cond.Status).To(Equal(v1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(esv1alpha1.ConditionReasonNamespacesFailed))
+
+		es, err := getExternalSecret(selected, ces.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(es.Spec.SecretStoreRef.Name).To(Equal("unrelated"))
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/events"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	msgDrifted     = "%s %s was changed since it was last synced: %s"
+	msgNotDrifted  = "Secret matches the last sync"
+	driftFieldData = "data"
+)
+
+// secretDrift returns the fields of the existing Secret that were changed since it was last synced:
+// its data and the labels and annotations the sync merged into it.
+// A Secret that is missing or was never synced has not drifted. Only Secrets owned by the
+// ExternalSecret are checked, the data of a merged Secret is shared with other writers.
+// The same applies to other targets, except that the data of rendered manifests is not compared.
+func secretDrift(es esv1alpha1.ExternalSecret, existingSecret v1.Secret) []string {
+	if existingSecret.UID == "" || es.Status.SyncedResourceVersion == "" {
+		return nil
+	}
+	if es.Spec.Target.CreationPolicy == esv1alpha1.Merge || es.Spec.Target.CreationPolicy == esv1alpha1.None {
+		return nil
+	}
+	var drift []string
+	if hasTargetData(&es) && existingSecret.Annotations[esv1alpha1.AnnotationDataHash] != utils.ObjectHash(existingSecret.Data) {
+		drift = append(drift, driftFieldData)
+	}
+
+	// the labels and annotations the last sync merged into the Secret
+	var expected v1.Secret
+	mergeMetadata(&expected, &es)
+	drift = append(drift, changedMetadata("label", expected.Labels, existingSecret.Labels)...)
+	drift = append(drift, changedMetadata("annotation", expected.Annotations, existingSecret.Annotations)...)
+	return drift
+}
+
+// changedMetadata returns the sorted keys of expected whose value differs in actual.
+// Keys that are only in actual are not managed by the ExternalSecret and not compared.
+func changedMetadata(field string, expected, actual map[string]string) []string {
+	var changed []string
+	for k, v := range expected {
+		if actualVal, ok := actual[k]; !ok || actualVal != v {
+			changed = append(changed, fmt.Sprintf("%s %s", field, k))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// reportDrift emits an event, counts the drift and sets the Drifted condition.
+// A drift that is already reported by the condition is not reported again.
+func (r *Reconciler) reportDrift(es *esv1alpha1.ExternalSecret, secretName string, drift []string) {
+	msg := fmt.Sprintf(msgDrifted, targetKind(es), secretName, strings.Join(drift, ", "))
+	if cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretDrifted); cond != nil && cond.Status == v1.ConditionTrue && cond.Message == msg {
+		return
+	}
+	r.Recorder.Event(es, v1.EventTypeWarning, events.ReasonDrifted, msg)
+	countDrift(es)
+	conditionDrifted := NewExternalSecretCondition(esv1alpha1.ExternalSecretDrifted, v1.ConditionTrue, esv1alpha1.ConditionReasonSecretDrifted, msg)
+	SetExternalSecretCondition(es, *conditionDrifted)
+}
+
+// resolveDrift sets the Drifted condition to false once the Secret matches the last sync again.
+// ExternalSecrets that never drifted don't get the condition.
+func resolveDrift(es *esv1alpha1.ExternalSecret) {
+	if cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretDrifted); cond == nil || cond.Status != v1.ConditionTrue {
+		return
+	}
+	conditionDrifted := NewExternalSecretCondition(esv1alpha1.ExternalSecretDrifted, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretSynced, msgNotDrifted)
+	SetExternalSecretCondition(es, *conditionDrifted)
+}
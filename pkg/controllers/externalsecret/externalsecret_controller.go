@@ -15,8 +15,12 @@ limitations under the License.
 package externalsecret
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,23 +29,45 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/events"
+	"github.com/external-secrets/external-secrets/pkg/generator"
+
+	// Loading registered generators.
+	_ "github.com/external-secrets/external-secrets/pkg/generator/register"
 	"github.com/external-secrets/external-secrets/pkg/provider"
 
 	// Loading registered providers.
+	"github.com/external-secrets/external-secrets/pkg/provider/clientcache"
+	providermetrics "github.com/external-secrets/external-secrets/pkg/provider/metrics"
 	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
+	"github.com/external-secrets/external-secrets/pkg/provider/responsecache"
+	"github.com/external-secrets/external-secrets/pkg/provider/retry"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	providertracing "github.com/external-secrets/external-secrets/pkg/provider/tracing"
+	"github.com/external-secrets/external-secrets/pkg/tracing"
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
 	requeueAfter = time.Second * 30
+	// transient provider errors are retried sooner, throttled requests and
+	// errors that need to be fixed in the provider are retried less often.
+	requeueAfterTransient = time.Second * 5
+	requeueAfterBackoff   = time.Minute * 2
 
 	errGetES                 = "could not get ExternalSecret"
 	errReconcileES           = "could not reconcile ExternalSecret"
@@ -49,9 +75,12 @@ const (
 	errGetSecretStore        = "could not get SecretStore %q, %w"
 	errGetClusterSecretStore = "could not get ClusterSecretStore %q, %w"
 	errStoreRef              = "could not get store reference"
+	errMissingStoreRef       = "secretStoreRef is required to fetch data from a provider"
 	errStoreProvider         = "could not get store provider"
 	errStoreClient           = "could not get provider client"
+	errGetDependencies       = "could not get the dependencies of the ExternalSecret"
 	errGetExistingSecret     = "could not get existing secret: %w"
+	errInvalidTarget         = "invalid target"
 	errCloseStoreClient      = "could not close provider client"
 	errSetCtrlReference      = "could not set ExternalSecret controller reference: %w"
 	errFetchTplFrom          = "error fetching templateFrom data: %w"
@@ -62,18 +91,54 @@ const (
 	errPolicyMergeGetSecret  = "unable to get secret %s: %w"
 	errPolicyMergeMutate     = "unable to mutate secret %s: %w"
 	errPolicyMergePatch      = "unable to patch secret %s: %w"
+	errDeleteSecret          = "unable to delete secret %s: %w"
 	errGetSecretKey          = "key %q from ExternalSecret %q: %w"
+	errFindSecrets           = "dataFrom[%d].find from ExternalSecret %q: %w"
+	errGetGenerator          = "dataFrom[%d].generatorRef from ExternalSecret %q: %w"
+	errGenerate              = "dataFrom[%d].generatorRef from ExternalSecret %q: unable to generate data: %w"
+	errDecodeData            = "could not decode secretKey %q from ExternalSecret %q: %w"
+	errDecodeDataFrom        = "could not decode dataFrom[%d] from ExternalSecret %q: %w"
+	errRewriteDataFrom       = "could not rewrite keys of dataFrom[%d] from ExternalSecret %q: %w"
+	errInvalidKeys           = "invalid Secret keys %s"
 	errTplCMMissingKey       = "error in configmap %s: missing key %s"
 	errTplSecMissingKey      = "error in secret %s: missing key %s"
+
+	msgCreated             = "Created %s %s"
+	msgUpdated             = "Updated %s %s"
+	msgUpdatedKeys         = "Updated %s %s, changed keys: %s"
+	msgDeleted             = "Deleted %s %s since its data was deleted from the provider"
+	msgSkipControllerClass = "Skipped, store %s is managed by controller class %q"
+	msgCreatedOnce         = "Secret was created, it is not refreshed with refreshPolicy=CreatedOnce"
+	msgImmutableForceSync  = "Skipped force-sync %q, the immutable %s %s can't be refreshed"
 )
 
+// errSecretDataDeleted is returned when all data was deleted from the provider
+// and the target has deletionPolicy=Delete.
+var errSecretDataDeleted = errors.New("all secret data was deleted from the provider")
+
 // Reconciler reconciles a ExternalSecret object.
 type Reconciler struct {
 	client.Client
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
 	ControllerClass string
 	RequeueInterval time.Duration
+	// ClientCache shares the provider clients between reconciles,
+	// without it a new client is constructed on every reconcile.
+	ClientCache *clientcache.Cache
+	// ResponseCache shares the responses of the providers between ExternalSecrets,
+	// without it every ExternalSecret fetches its data from the provider.
+	ResponseCache *responsecache.Cache
+	// ProviderConcurrency is the number of concurrent GetSecret calls for the data
+	// of an ExternalSecret, if the provider can't read them in a batch and its
+	// clients are safe for concurrent use.
+	ProviderConcurrency int
+	// TargetKinds are the kinds besides Secrets the ExternalSecrets may write to.
+	TargetKinds []k8sschema.GroupKind
+
+	// indexReader lists the objects by the field indexes of the manager cache.
+	indexReader client.Reader
 }
 
 // Reconcile implements the main reconciliation loop
@@ -81,6 +146,8 @@ type Reconciler struct {
 // and updates/creates a Kubernetes secret based on them.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := r.Log.WithValues("ExternalSecret", req.NamespacedName)
+	ctx, span := startReconcile(ctx, req.NamespacedName)
+	defer span.End()
 
 	syncCallsMetricLabels := prometheus.Labels{"name": req.Name, "namespace": req.Namespace}
 
@@ -88,20 +155,25 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	err := r.Get(ctx, req.NamespacedName, &externalSecret)
 	if apierrors.IsNotFound(err) {
-		syncCallsTotal.With(syncCallsMetricLabels).Inc()
-		conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretDeleted, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretDeleted, "Secret was deleted")
-		SetExternalSecretCondition(&esv1alpha1.ExternalSecret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      req.Name,
-				Namespace: req.Namespace,
-			},
-		}, *conditionSynced)
+		deleteMetrics(req.NamespacedName)
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		log.Error(err, errGetES)
 		syncCallsError.With(syncCallsMetricLabels).Inc()
 		return ctrl.Result{}, nil
 	}
+	defer observeReconcileDuration(req.NamespacedName, time.Now())
+
+	// a deleted ExternalSecret only cleans up its target
+	if !externalSecret.DeletionTimestamp.IsZero() {
+		if err := r.finalize(ctx, &externalSecret); err != nil {
+			log.Error(err, errFinalize)
+			tracing.SetError(span, err)
+			r.Recorder.Event(&externalSecret, v1.EventTypeWarning, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		return ctrl.Result{}, nil
+	}
 
 	// patch status when done processing
 	p := client.MergeFrom(externalSecret.DeepCopy())
@@ -112,77 +184,163 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		}
 	}()
 
-	store, err := r.getStore(ctx, &externalSecret)
-	if err != nil {
-		log.Error(err, errStoreRef)
-		conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
-		SetExternalSecretCondition(&externalSecret, *conditionSynced)
-		syncCallsError.With(syncCallsMetricLabels).Inc()
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
-	}
+	// ExternalSecrets that only use generators don't need a store
+	var store esv1alpha1.GenericStore
+	var secretClient provider.SecretsClient
+	concurrency := 1
+	if externalSecret.Spec.UsesSecretStore() {
+		store, err = r.getStore(ctx, &externalSecret)
+		if err != nil {
+			log.Error(err, errStoreRef)
+			tracing.SetError(span, err)
+			r.Recorder.Event(&externalSecret, v1.EventTypeWarning, events.ReasonStoreNotReady, err.Error())
+			conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
+			SetExternalSecretCondition(&externalSecret, *conditionSynced)
+			syncCallsError.With(syncCallsMetricLabels).Inc()
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
 
-	log = log.WithValues("SecretStore", store.GetNamespacedName())
+		log = log.WithValues("SecretStore", store.GetNamespacedName())
 
-	// check if store should be handled by this controller instance
-	if !shouldProcessStore(store, r.ControllerClass) {
-		log.Info("skipping unmanaged store")
-		return ctrl.Result{}, nil
-	}
+		// check if store should be handled by this controller instance
+		if !shouldProcessStore(store, r.ControllerClass) {
+			log.Info("skipping unmanaged store")
+			r.Recorder.Eventf(&externalSecret, v1.EventTypeNormal, events.ReasonSkipped, msgSkipControllerClass, store.GetName(), store.GetSpec().Controller)
+			return ctrl.Result{}, nil
+		}
 
-	storeProvider, err := schema.GetProvider(store)
-	if err != nil {
-		log.Error(err, errStoreProvider)
-		syncCallsError.With(syncCallsMetricLabels).Inc()
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		storeProvider, err := schema.GetProvider(store)
+		if err != nil {
+			log.Error(err, errStoreProvider)
+			r.Recorder.Eventf(&externalSecret, v1.EventTypeWarning, events.ReasonStoreNotReady, "%s: %v", errStoreProvider, err)
+			syncCallsError.With(syncCallsMetricLabels).Inc()
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		concurrency = provider.Concurrency(storeProvider, r.ProviderConcurrency)
+
+		secretClient, err = r.newProviderClient(ctx, storeProvider, store, req.Namespace)
+		if err != nil {
+			log.Error(err, errStoreClient)
+			tracing.SetError(span, err)
+			r.Recorder.Eventf(&externalSecret, v1.EventTypeWarning, errorReason(err), "%s: %v", errStoreClient, err)
+			conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, errorReason(err), err.Error())
+			SetExternalSecretCondition(&externalSecret, *conditionSynced)
+			syncCallsError.With(syncCallsMetricLabels).Inc()
+			countProviderError(&externalSecret, err)
+			return ctrl.Result{RequeueAfter: errorRequeueAfter(err)}, nil
+		}
+
+		// retry transient provider errors within the reconcile as configured in the store
+		retryClient, err := retry.NewClient(store, secretClient)
+		if err != nil {
+			log.Error(err, errStoreClient)
+			if err := secretClient.Close(ctx); err != nil {
+				log.Error(err, errCloseStoreClient)
+			}
+			r.Recorder.Eventf(&externalSecret, v1.EventTypeWarning, events.ReasonStoreNotReady, "%s: %v", errStoreClient, err)
+			conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
+			SetExternalSecretCondition(&externalSecret, *conditionSynced)
+			syncCallsError.With(syncCallsMetricLabels).Inc()
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		secretClient = retryClient
+		if r.ResponseCache != nil {
+			secretClient = r.ResponseCache.NewClient(ctx, store, req.Namespace, secretClient)
+		}
+
+		defer func() {
+			err = secretClient.Close(ctx)
+			if err != nil {
+				log.Error(err, errCloseStoreClient)
+			}
+		}()
 	}
 
-	secretClient, err := storeProvider.NewClient(ctx, store, r.Client, req.Namespace)
-	if err != nil {
-		log.Error(err, errStoreClient)
+	// targets other than Secrets must be allowed in the controller, it has no permissions for others
+	if err := r.checkTarget(&externalSecret); err != nil {
+		log.Error(err, errInvalidTarget)
+		tracing.SetError(span, err)
+		r.Recorder.Event(&externalSecret, v1.EventTypeWarning, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
 		conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
 		SetExternalSecretCondition(&externalSecret, *conditionSynced)
 		syncCallsError.With(syncCallsMetricLabels).Inc()
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		return ctrl.Result{}, nil
 	}
 
-	defer func() {
-		err = secretClient.Close(ctx)
-		if err != nil {
-			log.Error(err, errCloseStoreClient)
-		}
-	}()
+	// the fields merged into the target are removed before the ExternalSecret is deleted
+	if err := r.updateFinalizer(ctx, &externalSecret); err != nil {
+		log.Error(err, errUpdateFinalizer)
+		tracing.SetError(span, err)
+		syncCallsError.With(syncCallsMetricLabels).Inc()
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
 
 	refreshInt := r.RequeueInterval
 	if externalSecret.Spec.RefreshInterval != nil {
 		refreshInt = externalSecret.Spec.RefreshInterval.Duration
 	}
+	// only Periodic refreshes are requeued, the others are triggered by changes
+	if externalSecret.Spec.RefreshPolicy == esv1alpha1.RefreshPolicyCreatedOnce ||
+		externalSecret.Spec.RefreshPolicy == esv1alpha1.RefreshPolicyOnChange {
+		refreshInt = 0
+	}
 
 	// Target Secret Name should default to the ExternalSecret name if not explicitly specified
-	secretName := externalSecret.Spec.Target.Name
-	if secretName == "" {
-		secretName = externalSecret.ObjectMeta.Name
-	}
+	secretName := targetName(&externalSecret)
 
 	// fetch external secret, we need to ensure that it exists, and it's hashmap corresponds
-	var existingSecret v1.Secret
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      secretName,
-		Namespace: externalSecret.Namespace,
-	}, &existingSecret)
+	existingSecret, err := r.getTarget(ctx, &externalSecret, secretName)
 	if err != nil && !apierrors.IsNotFound(err) {
 		log.Error(err, errGetExistingSecret)
 	}
 
+	// a failed lookup of the dependencies refreshes the secret
+	dependencies, err := r.getDependencyVersion(ctx, &externalSecret, store)
+	if err != nil {
+		log.Error(err, errGetDependencies)
+	}
+
+	// a secret with refreshPolicy=CreatedOnce is never touched after its creation
+	if isCreatedOnce(externalSecret, existingSecret) {
+		updateLastSync(&externalSecret)
+		log.V(1).Info("skipping refresh of created secret", "refreshPolicy", externalSecret.Spec.RefreshPolicy)
+		conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionTrue, esv1alpha1.ConditionReasonSecretSynced, msgCreatedOnce)
+		SetExternalSecretCondition(&externalSecret, *conditionSynced)
+		return ctrl.Result{}, nil
+	}
+
 	// refresh should be skipped if
-	// 1. resource generation hasn't changed
-	// 2. refresh interval is 0
+	// 1. resource generation and the dependencies haven't changed
+	// 2. refresh interval is 0 or the refresh policy is OnChange
 	// 3. if we're still within refresh-interval
-	if !shouldRefresh(externalSecret) && isSecretValid(existingSecret) {
-		log.V(1).Info("skipping refresh", "rv", getResourceVersion(externalSecret))
+	// 4. the secret was not changed since the last sync, or the drift is only reported
+	refresh := shouldRefresh(externalSecret, dependencies)
+	var drift []string
+	if !refresh {
+		drift = secretDrift(externalSecret, existingSecret)
+	}
+	if len(drift) > 0 {
+		r.reportDrift(&externalSecret, secretName, drift)
+		if externalSecret.Spec.Target.DriftPolicy == esv1alpha1.DriftPolicyReport {
+			updateLastSync(&externalSecret)
+			log.V(1).Info("reporting drift of secret", "drift", drift)
+			return ctrl.Result{RequeueAfter: refreshInt}, nil
+		}
+		log.Info("correcting drift of secret", "drift", drift)
+	} else if !refresh && isTargetValid(&externalSecret, existingSecret) {
+		resolveDrift(&externalSecret)
+		updateLastSync(&externalSecret)
+		log.V(1).Info("skipping refresh", "rv", getResourceVersion(externalSecret, dependencies))
 		return ctrl.Result{RequeueAfter: refreshInt}, nil
 	}
 	if !shouldReconcile(externalSecret) {
-		log.V(1).Info("stopping reconciling", "rv", getResourceVersion(externalSecret))
+		log.V(1).Info("stopping reconciling", "rv", getResourceVersion(externalSecret, dependencies))
+		// a forced sync of an immutable target is acknowledged, it can't be refreshed
+		if forceSyncPending(externalSecret) {
+			forceSync := externalSecret.Annotations[esv1alpha1.AnnotationForceSync]
+			r.Recorder.Eventf(&externalSecret, v1.EventTypeNormal, events.ReasonSkipped, msgImmutableForceSync, forceSync, targetKind(&externalSecret), secretName)
+			externalSecret.Status.ForceSync = forceSync
+		}
 		return ctrl.Result{
 			RequeueAfter: 0,
 			Requeue:      false,
@@ -198,18 +356,34 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		Data:      make(map[string][]byte),
 	}
 
+	var provenance []esv1alpha1.ExternalSecretKeyProvenance
 	mutationFunc := func() error {
-		if externalSecret.Spec.Target.CreationPolicy == esv1alpha1.Owner {
+		switch externalSecret.Spec.Target.CreationPolicy {
+		case esv1alpha1.Owner:
 			err = controllerutil.SetControllerReference(&externalSecret, &secret.ObjectMeta, r.Scheme)
 			if err != nil {
 				return fmt.Errorf(errSetCtrlReference, err)
 			}
+		case esv1alpha1.Orphan:
+			// releases a Secret the ExternalSecret owned before
+			removeOwnerRef(&secret.ObjectMeta, externalSecret.UID)
 		}
 
-		dataMap, err := r.getProviderSecretData(ctx, secretClient, &externalSecret)
+		fetchCtx, prov := newProvenanceRecorder(ctx, &externalSecret)
+		if forceSyncPending(externalSecret) {
+			// a forced sync reads the data from the provider, not from the response cache
+			fetchCtx = responsecache.WithRefresh(fetchCtx)
+		}
+		dataMap, err := r.getProviderSecretData(fetchCtx, secretClient, concurrency, &externalSecret, prov)
 		if err != nil {
 			return fmt.Errorf(errGetSecretData, err)
 		}
+		provenance = prov.list()
+
+		// abort the write, the secret is deleted below
+		if len(dataMap) == 0 && externalSecret.Spec.Target.DeletionPolicy == esv1alpha1.DeletionPolicyDelete {
+			return errSecretDataDeleted
+		}
 
 		err = r.applyTemplate(ctx, &externalSecret, secret, dataMap)
 		if err != nil {
@@ -219,30 +393,59 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return nil
 	}
 
+	// trace the writes of the target Secret
+	writer := secretWriter{r.Client}
 	// nolint
-	switch externalSecret.Spec.Target.CreationPolicy {
-	case esv1alpha1.Merge:
-		err = patchSecret(ctx, r.Client, r.Scheme, secret, mutationFunc)
-	case esv1alpha1.None:
+	var op controllerutil.OperationResult
+	switch {
+	case externalSecret.Spec.Target.CreationPolicy == esv1alpha1.None:
 		log.V(1).Info("secret creation skipped due to creationPolicy=None")
 		err = nil
+	case !isSecretTarget(&externalSecret):
+		op, err = applyTarget(ctx, writer, &externalSecret, secret, &existingSecret, mutationFunc)
+	case externalSecret.Spec.Target.CreationPolicy == esv1alpha1.Merge:
+		err = patchSecret(ctx, writer, r.Scheme, secret, mutationFunc)
 	default:
-		_, err = ctrl.CreateOrUpdate(ctx, r.Client, secret, mutationFunc)
+		op, err = ctrl.CreateOrUpdate(ctx, writer, secret, mutationFunc)
+	}
+
+	if errors.Is(err, errSecretDataDeleted) {
+		err = writer.Delete(ctx, targetObject(&externalSecret, secret))
+		if client.IgnoreNotFound(err) != nil {
+			err = fmt.Errorf(errDeleteSecret, secret.Name, err)
+		} else {
+			log.Info("deleted secret since its data was deleted from the provider")
+			r.Recorder.Eventf(&externalSecret, v1.EventTypeNormal, events.ReasonDeleted, msgDeleted, targetKind(&externalSecret), secret.Name)
+			conditionDeleted := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretDeleted, "Secret was deleted since its data was deleted from the provider")
+			SetExternalSecretCondition(&externalSecret, *conditionDeleted)
+			externalSecret.Status.ForceSync = externalSecret.Annotations[esv1alpha1.AnnotationForceSync]
+			syncCallsTotal.With(syncCallsMetricLabels).Inc()
+			return ctrl.Result{RequeueAfter: refreshInt}, nil
+		}
 	}
 
 	if err != nil {
 		log.Error(err, errReconcileES)
-		conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretSyncedError, err.Error())
+		tracing.SetError(span, err)
+		r.Recorder.Event(&externalSecret, v1.EventTypeWarning, errorReason(err), err.Error())
+		conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionFalse, errorReason(err), err.Error())
 		SetExternalSecretCondition(&externalSecret, *conditionSynced)
 		syncCallsError.With(syncCallsMetricLabels).Inc()
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		countProviderError(&externalSecret, err)
+		return ctrl.Result{RequeueAfter: errorRequeueAfter(err)}, nil
 	}
 
+	r.recordSyncEvent(&externalSecret, op, &existingSecret, secret)
+
 	conditionSynced := NewExternalSecretCondition(esv1alpha1.ExternalSecretReady, v1.ConditionTrue, esv1alpha1.ConditionReasonSecretSynced, "Secret was synced")
 	currCond := GetExternalSecretCondition(externalSecret.Status, esv1alpha1.ExternalSecretReady)
 	SetExternalSecretCondition(&externalSecret, *conditionSynced)
 	externalSecret.Status.RefreshTime = metav1.NewTime(time.Now())
-	externalSecret.Status.SyncedResourceVersion = getResourceVersion(externalSecret)
+	externalSecret.Status.SyncedResourceVersion = getResourceVersion(externalSecret, dependencies)
+	externalSecret.Status.ForceSync = externalSecret.Annotations[esv1alpha1.AnnotationForceSync]
+	externalSecret.Status.Provenance = provenance
+	resolveDrift(&externalSecret)
+	updateLastSync(&externalSecret)
 	syncCallsTotal.With(syncCallsMetricLabels).Inc()
 	if currCond == nil || currCond.Status != conditionSynced.Status {
 		log.Info("reconciled secret") // Log once if on success in any verbosity
@@ -255,6 +458,57 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}, nil
 }
 
+// recordSyncEvent emits an event if the sync created the target or changed its data.
+// Only the changed keys are named, never their values.
+func (r *Reconciler) recordSyncEvent(externalSecret *esv1alpha1.ExternalSecret, op controllerutil.OperationResult, existing, secret *v1.Secret) {
+	kind := targetKind(externalSecret)
+	switch externalSecret.Spec.Target.CreationPolicy {
+	case esv1alpha1.None:
+		return
+	case esv1alpha1.Merge:
+		// rendered manifests report the result of the apply
+		if !hasTargetData(externalSecret) {
+			break
+		}
+		// other fields of the Secret are not managed by the ExternalSecret
+		if keys := changedKeys(existing.Data, secret.Data, false); len(keys) > 0 {
+			r.Recorder.Eventf(externalSecret, v1.EventTypeNormal, events.ReasonUpdated, msgUpdatedKeys, kind, secret.Name, strings.Join(keys, ", "))
+		}
+		return
+	}
+	switch op {
+	case controllerutil.OperationResultCreated:
+		r.Recorder.Eventf(externalSecret, v1.EventTypeNormal, events.ReasonCreated, msgCreated, kind, secret.Name)
+	case controllerutil.OperationResultUpdated:
+		// the data of rendered manifests can't be compared
+		if keys := changedKeys(existing.Data, secret.Data, true); len(keys) > 0 && hasTargetData(externalSecret) {
+			r.Recorder.Eventf(externalSecret, v1.EventTypeNormal, events.ReasonUpdated, msgUpdatedKeys, kind, secret.Name, strings.Join(keys, ", "))
+		} else {
+			r.Recorder.Eventf(externalSecret, v1.EventTypeNormal, events.ReasonUpdated, msgUpdated, kind, secret.Name)
+		}
+	}
+}
+
+// changedKeys returns the sorted keys whose values differ between the old and new data.
+// Keys missing in the new data are only included if withRemoved is set.
+func changedKeys(old, new map[string][]byte, withRemoved bool) []string {
+	var keys []string
+	for k, v := range new {
+		if oldVal, ok := old[k]; !ok || !bytes.Equal(oldVal, v) {
+			keys = append(keys, k)
+		}
+	}
+	if withRemoved {
+		for k := range old {
+			if _, ok := new[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func patchSecret(ctx context.Context, c client.Client, scheme *runtime.Scheme, secret *v1.Secret, mutationFunc func() error) error {
 	err := c.Get(ctx, client.ObjectKeyFromObject(secret), secret.DeepCopy())
 	if apierrors.IsNotFound(err) {
@@ -282,13 +536,24 @@ func patchSecret(ctx context.Context, c client.Client, scheme *runtime.Scheme, s
 	// we might get into a conflict here if we are not the manager of that particular field
 	// we do not resolve the conflict and return an error instead
 	// see: https://kubernetes.io/docs/reference/using-api/server-side-apply/#conflicts
-	err = c.Patch(ctx, secret, client.Apply, client.FieldOwner("external-secrets"))
+	err = c.Patch(ctx, secret, client.Apply, client.FieldOwner(fieldManager))
 	if err != nil {
 		return fmt.Errorf(errPolicyMergePatch, secret.Name, err)
 	}
 	return nil
 }
 
+// newProviderClient returns a client of the store provider, shared with other
+// reconciles if the client cache is enabled. The client must be closed after use.
+func (r *Reconciler) newProviderClient(ctx context.Context, storeProvider provider.Provider, store esv1alpha1.GenericStore, namespace string) (provider.SecretsClient, error) {
+	// measure and trace the provider calls, shared clients keep measuring and tracing them
+	storeProvider = providertracing.NewProvider(providermetrics.NewProvider(storeProvider))
+	if r.ClientCache == nil {
+		return storeProvider.NewClient(ctx, store, r.Client, namespace)
+	}
+	return r.ClientCache.Get(ctx, storeProvider, store, namespace)
+}
+
 // shouldProcessStore returns true if the store should be processed.
 func shouldProcessStore(store esv1alpha1.GenericStore, class string) bool {
 	if store.GetSpec().Controller == "" || store.GetSpec().Controller == class {
@@ -297,28 +562,59 @@ func shouldProcessStore(store esv1alpha1.GenericStore, class string) bool {
 	return false
 }
 
-func getResourceVersion(es esv1alpha1.ExternalSecret) string {
-	return fmt.Sprintf("%d-%s", es.ObjectMeta.GetGeneration(), hashMeta(es.ObjectMeta))
+// getResourceVersion combines the generation and metadata of the ExternalSecret
+// with the version of its dependencies, see getDependencyVersion.
+func getResourceVersion(es esv1alpha1.ExternalSecret, dependencies string) string {
+	if dependencies == "" {
+		return fmt.Sprintf("%d-%s", es.ObjectMeta.GetGeneration(), hashMeta(es.ObjectMeta))
+	}
+	return fmt.Sprintf("%d-%s-%s", es.ObjectMeta.GetGeneration(), hashMeta(es.ObjectMeta), dependencies)
 }
 
+// hashMeta hashes the labels and annotations,
+// the force-sync annotation is handled by shouldRefresh.
 func hashMeta(m metav1.ObjectMeta) string {
 	type meta struct {
 		annotations map[string]string
 		labels      map[string]string
 	}
+	annotations := m.Annotations
+	if _, ok := annotations[esv1alpha1.AnnotationForceSync]; ok {
+		annotations = make(map[string]string, len(m.Annotations))
+		for k, v := range m.Annotations {
+			if k != esv1alpha1.AnnotationForceSync {
+				annotations[k] = v
+			}
+		}
+	}
 	return utils.ObjectHash(meta{
-		annotations: m.Annotations,
+		annotations: annotations,
 		labels:      m.Labels,
 	})
 }
 
-func shouldRefresh(es esv1alpha1.ExternalSecret) bool {
-	// refresh if resource version changed
-	if es.Status.SyncedResourceVersion != getResourceVersion(es) {
+// forceSyncPending returns true if the force-sync annotation
+// is set to a value that hasn't been handled yet.
+func forceSyncPending(es esv1alpha1.ExternalSecret) bool {
+	forceSync := es.Annotations[esv1alpha1.AnnotationForceSync]
+	return forceSync != "" && forceSync != es.Status.ForceSync
+}
+
+func shouldRefresh(es esv1alpha1.ExternalSecret, dependencies string) bool {
+	// refresh on a new value of the force-sync annotation, regardless of the refresh interval
+	if forceSyncPending(es) {
 		return true
 	}
 
-	// skip refresh if refresh interval is 0
+	// refresh if resource version or the dependencies changed
+	if es.Status.SyncedResourceVersion != getResourceVersion(es, dependencies) {
+		return true
+	}
+
+	// skip refresh if refresh interval is 0 or the refresh policy is OnChange
+	if es.Spec.RefreshPolicy == esv1alpha1.RefreshPolicyOnChange && es.Status.SyncedResourceVersion != "" {
+		return false
+	}
 	if es.Spec.RefreshInterval.Duration == 0 && es.Status.SyncedResourceVersion != "" {
 		return false
 	}
@@ -328,6 +624,16 @@ func shouldRefresh(es esv1alpha1.ExternalSecret) bool {
 	return !es.Status.RefreshTime.Add(es.Spec.RefreshInterval.Duration).After(time.Now())
 }
 
+// isCreatedOnce returns true if the ExternalSecret has refreshPolicy=CreatedOnce
+// and the secret was synced once and still exists. A pending force-sync
+// refreshes the secret nonetheless.
+func isCreatedOnce(es esv1alpha1.ExternalSecret, existingSecret v1.Secret) bool {
+	if es.Spec.RefreshPolicy != esv1alpha1.RefreshPolicyCreatedOnce || es.Status.RefreshTime.IsZero() || forceSyncPending(es) {
+		return false
+	}
+	return existingSecret.UID != "" || es.Spec.Target.CreationPolicy == esv1alpha1.None
+}
+
 func shouldReconcile(es esv1alpha1.ExternalSecret) bool {
 	if es.Spec.Target.Immutable && hasSyncedCondition(es) {
 		return false
@@ -359,14 +665,21 @@ func isSecretValid(existingSecret v1.Secret) bool {
 }
 
 // getStore returns the store with the provided ExternalSecret.
-func (r *Reconciler) getStore(ctx context.Context, externalSecret *esv1alpha1.ExternalSecret) (esv1alpha1.GenericStore, error) {
+func (r *Reconciler) getStore(ctx context.Context, externalSecret *esv1alpha1.ExternalSecret) (_ esv1alpha1.GenericStore, err error) {
+	ctx, span := tracing.Start(ctx, spanGetStore)
+	defer func() {
+		tracing.End(span, err)
+	}()
+	if externalSecret.Spec.SecretStoreRef == nil {
+		return nil, fmt.Errorf(errMissingStoreRef)
+	}
 	ref := types.NamespacedName{
 		Name: externalSecret.Spec.SecretStoreRef.Name,
 	}
 
 	if externalSecret.Spec.SecretStoreRef.Kind == esv1alpha1.ClusterSecretStoreKind {
 		var store esv1alpha1.ClusterSecretStore
-		err := r.Get(ctx, ref, &store)
+		err = r.Get(ctx, ref, &store)
 		if err != nil {
 			return nil, fmt.Errorf(errGetClusterSecretStore, ref.Name, err)
 		}
@@ -377,7 +690,7 @@ func (r *Reconciler) getStore(ctx context.Context, externalSecret *esv1alpha1.Ex
 	ref.Namespace = externalSecret.Namespace
 
 	var store esv1alpha1.SecretStore
-	err := r.Get(ctx, ref, &store)
+	err = r.Get(ctx, ref, &store)
 	if err != nil {
 		return nil, fmt.Errorf(errGetSecretStore, ref.Name, err)
 	}
@@ -385,35 +698,225 @@ func (r *Reconciler) getStore(ctx context.Context, externalSecret *esv1alpha1.Ex
 }
 
 // getProviderSecretData returns the provider's secret data with the provided ExternalSecret.
-func (r *Reconciler) getProviderSecretData(ctx context.Context, providerClient provider.SecretsClient, externalSecret *esv1alpha1.ExternalSecret) (map[string][]byte, error) {
+// The source of every key is recorded in prov, concurrency bounds the GetSecret calls of spec.data.
+func (r *Reconciler) getProviderSecretData(ctx context.Context, providerClient provider.SecretsClient, concurrency int, externalSecret *esv1alpha1.ExternalSecret, prov *provenanceRecorder) (map[string][]byte, error) {
 	providerData := make(map[string][]byte)
 
-	for _, remoteRef := range externalSecret.Spec.DataFrom {
-		secretMap, err := providerClient.GetSecretMap(ctx, remoteRef)
-		if err != nil {
-			return nil, fmt.Errorf(errGetSecretKey, remoteRef.Key, externalSecret.Name, err)
+	for i, remoteRef := range externalSecret.Spec.DataFrom {
+		var secretMap map[string][]byte
+		// names holds the provider secret of every key found with find
+		var names map[string]string
+		var err error
+		switch {
+		case remoteRef.GeneratorRef != nil:
+			secretMap, err = r.generateSecretData(ctx, i, externalSecret, *remoteRef.GeneratorRef)
+			if err != nil {
+				return nil, err
+			}
+		case remoteRef.Find != nil:
+			secretMap, names, err = findProviderSecrets(ctx, providerClient, *remoteRef.Find)
+			if err != nil {
+				return nil, fmt.Errorf(errFindSecrets, i, externalSecret.Name, err)
+			}
+		default:
+			secretMap, err = providerClient.GetSecretMap(ctx, remoteRef.GetRemoteRef())
+			if skipDeletedSecret(externalSecret, err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf(errGetSecretKey, remoteRef.Key, externalSecret.Name, err)
+			}
 		}
 
+		secretMap, err = utils.DecodeMap(remoteRef.DecodingStrategy, secretMap)
+		if err != nil {
+			return nil, fmt.Errorf(errDecodeDataFrom, i, externalSecret.Name, err)
+		}
+		secretMap, origins, err := utils.RewriteMapWithOrigins(remoteRef.Rewrite, secretMap)
+		if err != nil {
+			return nil, fmt.Errorf(errRewriteDataFrom, i, externalSecret.Name, err)
+		}
+		err = validateSecretKeys(secretMap)
+		if err != nil {
+			return nil, fmt.Errorf(errRewriteDataFrom, i, externalSecret.Name, err)
+		}
 		providerData = utils.MergeByteMap(providerData, secretMap)
+
+		for key, origin := range origins {
+			switch {
+			case remoteRef.GeneratorRef != nil:
+				prov.addGenerator(key, *remoteRef.GeneratorRef)
+			case remoteRef.Find != nil:
+				prov.addRemote(key, esv1alpha1.ExternalSecretDataRemoteRef{Key: names[origin]}, "")
+			default:
+				ref := remoteRef.GetRemoteRef()
+				prov.addRemote(key, ref, mapProperty(ref, origin))
+			}
+		}
 	}
 
-	for _, secretRef := range externalSecret.Spec.Data {
-		secretData, err := providerClient.GetSecret(ctx, secretRef.RemoteRef)
+	if len(externalSecret.Spec.Data) == 0 {
+		return providerData, nil
+	}
+	refs := make([]esv1alpha1.ExternalSecretDataRemoteRef, len(externalSecret.Spec.Data))
+	for i, secretRef := range externalSecret.Spec.Data {
+		refs[i] = secretRef.RemoteRef
+	}
+	results := provider.GetSecrets(ctx, providerClient, refs, concurrency)
+	for i, secretRef := range externalSecret.Spec.Data {
+		secretData, err := results[i].Value, results[i].Err
+		if skipDeletedSecret(externalSecret, err) {
+			continue
+		}
 		if err != nil {
 			return nil, fmt.Errorf(errGetSecretKey, secretRef.RemoteRef.Key, externalSecret.Name, err)
 		}
 
+		secretData, err = utils.Decode(secretRef.RemoteRef.DecodingStrategy, secretData)
+		if err != nil {
+			return nil, fmt.Errorf(errDecodeData, secretRef.SecretKey, externalSecret.Name, err)
+		}
 		providerData[secretRef.SecretKey] = secretData
+		prov.addRemote(secretRef.SecretKey, secretRef.RemoteRef, secretRef.RemoteRef.Property)
 	}
 
 	return providerData, nil
 }
 
+// skipDeletedSecret returns true if err reports a secret that was deleted from the provider
+// and the deletionPolicy of the target leaves such secrets out of the data.
+func skipDeletedSecret(externalSecret *esv1alpha1.ExternalSecret, err error) bool {
+	if !provider.IsNotFound(err) {
+		return false
+	}
+	policy := externalSecret.Spec.Target.DeletionPolicy
+	return policy == esv1alpha1.DeletionPolicyDelete || policy == esv1alpha1.DeletionPolicyMerge
+}
+
+// errorReason returns the condition reason for err.
+// Provider errors are reported with the reason of their category.
+func errorReason(err error) string {
+	var keysErr *invalidKeysError
+	if errors.As(err, &keysErr) {
+		return esv1alpha1.ConditionReasonInvalidKeys
+	}
+	switch provider.GetErrorCategory(err) {
+	case provider.ErrorCategoryNotFound:
+		return esv1alpha1.ConditionReasonSecretNotFound
+	case provider.ErrorCategoryPermissionDenied:
+		return esv1alpha1.ConditionReasonPermissionDenied
+	case provider.ErrorCategoryUnauthenticated:
+		return esv1alpha1.ConditionReasonUnauthenticated
+	case provider.ErrorCategoryRateLimited:
+		return esv1alpha1.ConditionReasonRateLimited
+	case provider.ErrorCategoryInvalidRef:
+		return esv1alpha1.ConditionReasonInvalidRef
+	case provider.ErrorCategoryTransient:
+		return esv1alpha1.ConditionReasonProviderUnavailable
+	}
+	return esv1alpha1.ConditionReasonSecretSyncedError
+}
+
+// errorRequeueAfter returns how long to wait before retrying after err.
+func errorRequeueAfter(err error) time.Duration {
+	switch provider.GetErrorCategory(err) {
+	case provider.ErrorCategoryTransient:
+		return requeueAfterTransient
+	case provider.ErrorCategoryRateLimited, provider.ErrorCategoryPermissionDenied,
+		provider.ErrorCategoryUnauthenticated, provider.ErrorCategoryInvalidRef:
+		return requeueAfterBackoff
+	}
+	return requeueAfter
+}
+
+// invalidKeysError is returned if the data contains keys that are not valid Secret keys.
+type invalidKeysError struct {
+	keys []string
+}
+
+func (e *invalidKeysError) Error() string {
+	return fmt.Sprintf(errInvalidKeys, strings.Join(e.keys, ", "))
+}
+
+// validateSecretKeys returns an invalidKeysError listing the invalid keys of the data.
+func validateSecretKeys(data map[string][]byte) error {
+	var invalid []string
+	for k := range data {
+		if msgs := validation.IsConfigMapKey(k); len(msgs) > 0 {
+			invalid = append(invalid, fmt.Sprintf("%q (%s)", k, strings.Join(msgs, ", ")))
+		}
+	}
+	if len(invalid) == 0 {
+		return nil
+	}
+	sort.Strings(invalid)
+	return &invalidKeysError{keys: invalid}
+}
+
+// generateSecretData returns the data produced by the generator the ref points to.
+// The generator resource is read from the namespace of the ExternalSecret.
+func (r *Reconciler) generateSecretData(ctx context.Context, i int, externalSecret *esv1alpha1.ExternalSecret, ref esv1alpha1.GeneratorRef) (map[string][]byte, error) {
+	gen, obj, err := generator.GetGenerator(ref.Kind)
+	if err != nil {
+		return nil, fmt.Errorf(errGetGenerator, i, externalSecret.Name, err)
+	}
+	err = r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: externalSecret.Namespace}, obj)
+	if err != nil {
+		return nil, fmt.Errorf(errGetGenerator, i, externalSecret.Name, err)
+	}
+	secretMap, err := gen.Generate(ctx, obj, r.Client, externalSecret.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errGenerate, i, externalSecret.Name, err)
+	}
+	return secretMap, nil
+}
+
+// findProviderSecrets returns the secrets of the provider that match the find criteria,
+// keyed by the Secret key their name is turned into, and the name of every key.
+// If several names result in the same key, the name that sorts last wins.
+func findProviderSecrets(ctx context.Context, providerClient provider.SecretsClient, find esv1alpha1.ExternalSecretFind) (map[string][]byte, map[string]string, error) {
+	secrets, err := providerClient.GetAllSecrets(ctx, find)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	secretMap := make(map[string][]byte, len(secrets))
+	keyNames := make(map[string]string, len(secrets))
+	for _, name := range names {
+		key := utils.FindKeyName(name, find.KeyNaming)
+		secretMap[key] = secrets[name]
+		keyNames[key] = name
+	}
+	return secretMap, keyNames, nil
+}
+
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager, opts controller.Options) error {
+	if err := setupIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	r.indexReader = mgr.GetCache()
+
+	// refresh the ExternalSecrets once their store or the Secrets and ConfigMaps
+	// they depend on or write to change, status updates of the stores are ignored
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(opts).
 		For(&esv1alpha1.ExternalSecret{}).
 		Owns(&v1.Secret{}).
+		Owns(&v1.ConfigMap{}).
+		Watches(&source.Kind{Type: &esv1alpha1.SecretStore{}},
+			handler.EnqueueRequestsFromMapFunc(r.findExternalSecretsForStore),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &esv1alpha1.ClusterSecretStore{}},
+			handler.EnqueueRequestsFromMapFunc(r.findExternalSecretsForStore),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.findExternalSecretsForSecret)).
+		Watches(&source.Kind{Type: &v1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.findExternalSecretsForConfigMap)).
 		Complete(r)
 }
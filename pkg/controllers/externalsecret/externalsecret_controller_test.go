@@ -15,25 +15,38 @@ package externalsecret
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/events"
 	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/fake"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	providertracing "github.com/external-secrets/external-secrets/pkg/provider/tracing"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 var (
@@ -208,7 +221,7 @@ var _ = Describe("ExternalSecret controller", func() {
 					Namespace: ExternalSecretNamespace,
 				},
 				Spec: esv1alpha1.ExternalSecretSpec{
-					SecretStoreRef: esv1alpha1.SecretStoreRef{
+					SecretStoreRef: &esv1alpha1.SecretStoreRef{
 						Name: ExternalSecretStore,
 					},
 					Target: esv1alpha1.ExternalSecretTarget{
@@ -276,6 +289,44 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// the reconcile is traced with the store lookup, the provider calls
+	// and the Secret write as children
+	checkTracing := func(tc *testCase) {
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Eventually(func() []string {
+				return tracedSpans(ExternalSecretName, ExternalSecretNamespace)
+			}, timeout, interval).Should(ContainElements(
+				spanGetStore,
+				providertracing.SpanNewClient,
+				"provider.GetSecret",
+				spanSecret+"Create",
+			))
+		}
+	}
+
+	// a synced ExternalSecret reports the reconcile duration and the time since
+	// the last sync, all of its series are removed once it is deleted
+	checkPrometheusCleanup := func(tc *testCase) {
+		const secretVal = "someValue"
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(externalSecretConditionShouldBe(ExternalSecretName, ExternalSecretNamespace, esv1alpha1.ExternalSecretReady, v1.ConditionTrue, 1.0)).To(BeTrue())
+			Eventually(func() int {
+				return seriesCount(lastSync, ExternalSecretName, ExternalSecretNamespace)
+			}, timeout, interval).Should(Equal(1))
+			Expect(seriesCount(reconcileDuration, ExternalSecretName, ExternalSecretNamespace)).To(Equal(1))
+
+			Expect(k8sClient.Delete(context.Background(), es)).To(Succeed())
+			Eventually(func() int {
+				return seriesCount(externalSecretCondition, ExternalSecretName, ExternalSecretNamespace) +
+					seriesCount(reconcileDuration, ExternalSecretName, ExternalSecretNamespace) +
+					seriesCount(lastSync, ExternalSecretName, ExternalSecretNamespace) +
+					seriesCount(syncCallsTotal, ExternalSecretName, ExternalSecretNamespace)
+			}, timeout, interval).Should(Equal(0))
+		}
+	}
+
 	// merge with existing secret using creationPolicy=Merge
 	// it should NOT have a ownerReference
 	// metadata.managedFields with the correct owner should be added to the secret
@@ -341,6 +392,193 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// deleting an ExternalSecret with creationPolicy=Merge removes the fields it merged
+	// into the secret and keeps the fields of other managers
+	mergeCleanupOnDelete := func(tc *testCase) {
+		const existingKey = "pre-existing-key"
+		const existingVal = "pre-existing-value"
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1alpha1.Merge
+
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string][]byte{
+				existingKey: []byte(existingVal),
+			},
+		}, client.FieldOwner(FakeManager))).To(Succeed())
+
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data).To(HaveKey(targetProp))
+			Expect(es.Finalizers).To(ContainElement(esv1alpha1.FinalizerMergedFields))
+
+			Expect(k8sClient.Delete(context.Background(), es)).To(Succeed())
+			secretKey := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			Eventually(func() bool {
+				var merged v1.Secret
+				Expect(k8sClient.Get(context.Background(), secretKey, &merged)).To(Succeed())
+				_, hasData := merged.Data[targetProp]
+				_, hasHash := merged.Annotations[esv1alpha1.AnnotationDataHash]
+				return !hasData && !hasHash
+			}, timeout, interval).Should(BeTrue())
+
+			var cleaned v1.Secret
+			Expect(k8sClient.Get(context.Background(), secretKey, &cleaned)).To(Succeed())
+			Expect(string(cleaned.Data[existingKey])).To(Equal(existingVal))
+			Expect(cleaned.ObjectMeta.ManagedFields).To(HaveLen(1))
+			Expect(hasFieldOwnership(cleaned.ObjectMeta, FakeManager, "{\"f:data\":{\".\":{},\"f:pre-existing-key\":{}},\"f:type\":{}}")).To(BeTrue())
+
+			esKey := types.NamespacedName{Name: ExternalSecretName, Namespace: ExternalSecretNamespace}
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(context.Background(), esKey, &esv1alpha1.ExternalSecret{}))
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// changing creationPolicy from Merge to Owner removes the fields merged before
+	// along with the finalizer
+	mergeToOwner := func(tc *testCase) {
+		const ownedKey = "owned-key"
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1alpha1.Merge
+
+		Expect(k8sClient.Create(context.Background(), &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ExternalSecretTargetSecretName,
+				Namespace: ExternalSecretNamespace,
+			},
+		}, client.FieldOwner(FakeManager))).To(Succeed())
+
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data).To(HaveKey(targetProp))
+			Expect(es.Finalizers).To(ContainElement(esv1alpha1.FinalizerMergedFields))
+
+			patch := client.MergeFrom(es.DeepCopy())
+			es.Spec.Target.CreationPolicy = esv1alpha1.Owner
+			es.Spec.Data[0].SecretKey = ownedKey
+			Expect(k8sClient.Patch(context.Background(), es, patch)).To(Succeed())
+
+			secretKey := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			Eventually(func() bool {
+				var owned v1.Secret
+				Expect(k8sClient.Get(context.Background(), secretKey, &owned)).To(Succeed())
+				_, hasMerged := owned.Data[targetProp]
+				_, hasOwned := owned.Data[ownedKey]
+				return !hasMerged && hasOwned
+			}, timeout, interval).Should(BeTrue())
+
+			esKey := types.NamespacedName{Name: ExternalSecretName, Namespace: ExternalSecretNamespace}
+			var updated esv1alpha1.ExternalSecret
+			Expect(k8sClient.Get(context.Background(), esKey, &updated)).To(Succeed())
+			Expect(updated.Finalizers).ToNot(ContainElement(esv1alpha1.FinalizerMergedFields))
+		}
+	}
+
+	// creationPolicy=Orphan creates the secret without an ownerReference,
+	// it is kept when the ExternalSecret is deleted
+	orphanSecret := func(tc *testCase) {
+		const secretVal = "someValue"
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1alpha1.Orphan
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+			Expect(secret.OwnerReferences).To(BeEmpty())
+			Expect(es.Finalizers).ToNot(ContainElement(esv1alpha1.FinalizerMergedFields))
+
+			Expect(k8sClient.Delete(context.Background(), es)).To(Succeed())
+			esKey := types.NamespacedName{Name: ExternalSecretName, Namespace: ExternalSecretNamespace}
+			Eventually(func() bool {
+				return apierrors.IsNotFound(k8sClient.Get(context.Background(), esKey, &esv1alpha1.ExternalSecret{}))
+			}, timeout, interval).Should(BeTrue())
+
+			secretKey := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			Consistently(func() error {
+				return k8sClient.Get(context.Background(), secretKey, &v1.Secret{})
+			}, time.Second, interval).Should(Succeed())
+		}
+	}
+
+	// changing creationPolicy from Owner to Orphan releases the secret
+	ownerToOrphan := func(tc *testCase) {
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(hasOwnerRef(secret.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeTrue())
+
+			patch := client.MergeFrom(es.DeepCopy())
+			es.Spec.Target.CreationPolicy = esv1alpha1.Orphan
+			Expect(k8sClient.Patch(context.Background(), es, patch)).To(Succeed())
+
+			secretKey := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			Eventually(func() bool {
+				var released v1.Secret
+				Expect(k8sClient.Get(context.Background(), secretKey, &released)).To(Succeed())
+				return hasOwnerRef(released.ObjectMeta, "ExternalSecret", ExternalSecretName)
+			}, timeout, interval).Should(BeFalse())
+		}
+	}
+
+	// the data is written to a ConfigMap instead of a Secret
+	syncToConfigMap := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "v1", Kind: "ConfigMap"}
+		tc.externalSecret.Labels = map[string]string{"team": "a"}
+		fakeProvider.WithGetSecret([]byte("value"), nil)
+		tc.checkExternalSecret = func(es *esv1alpha1.ExternalSecret) {
+			key := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			var cm v1.ConfigMap
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), key, &cm)
+			}, timeout, interval).Should(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue(targetProp, "value"))
+			Expect(cm.Labels).To(HaveKeyWithValue("team", "a"))
+			Expect(cm.Annotations).To(HaveKey(esv1alpha1.AnnotationDataHash))
+			Expect(hasOwnerRef(cm.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeTrue())
+			Expect(hasEvent(es, v1.EventTypeNormal, events.ReasonCreated, "Created ConfigMap "+ExternalSecretTargetSecretName)).To(BeTrue())
+
+			// no Secret is created
+			var secret v1.Secret
+			Expect(apierrors.IsNotFound(k8sClient.Get(context.Background(), key, &secret))).To(BeTrue())
+		}
+	}
+
+	// the data is rendered into a manifest of another kind
+	syncToManifest := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{
+			APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+			Kind:       genv1alpha1.PasswordKind,
+		}
+		tc.externalSecret.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{
+			Manifest: "spec:\n  length: {{ .targetProperty | toString }}\n  noUpper: true\n  allowRepeat: false",
+		}
+		fakeProvider.WithGetSecret([]byte("12"), nil)
+		tc.checkExternalSecret = func(es *esv1alpha1.ExternalSecret) {
+			key := types.NamespacedName{Name: ExternalSecretTargetSecretName, Namespace: ExternalSecretNamespace}
+			var password genv1alpha1.Password
+			Eventually(func() error {
+				return k8sClient.Get(context.Background(), key, &password)
+			}, timeout, interval).Should(Succeed())
+			Expect(password.Spec.Length).To(Equal(12))
+			Expect(password.Spec.NoUpper).To(BeTrue())
+			Expect(hasOwnerRef(password.ObjectMeta, "ExternalSecret", ExternalSecretName)).To(BeTrue())
+		}
+	}
+
+	// kinds that are not allowed in the controller are refused
+	rejectTargetKind := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{
+			APIVersion: genv1alpha1.SchemeGroupVersion.String(),
+			Kind:       genv1alpha1.SSHKeyKind,
+		}
+		tc.externalSecret.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{Manifest: "spec: {}"}
+		fakeProvider.WithGetSecret([]byte("value"), nil)
+		tc.checkCondition = func(es *esv1alpha1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionFalse &&
+				cond.Message == fmt.Sprintf(errTargetNotAllowed, genv1alpha1.SSHKeyGroupKind)
+		}
+	}
+
 	// controller should not force override but
 	// return an error on conflict
 	mergeWithConflict := func(tc *testCase) {
@@ -490,7 +728,7 @@ var _ = Describe("ExternalSecret controller", func() {
 				tplStaticKey: tplStaticVal,
 			},
 		}
-		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataRemoteRef{
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
 			{
 				Key: "datamap",
 			},
@@ -510,6 +748,317 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// a change of a templateFrom ConfigMap refreshes the secret within the refresh interval
+	refreshOnTemplateFromChange := func(tc *testCase) {
+		const tplFromCMName = "template-cm"
+		const tplFromKey = "tpl-from-key"
+		cm := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tplFromCMName,
+				Namespace: ExternalSecretNamespace,
+			},
+			Data: map[string]string{
+				tplFromKey: "before: {{ .targetProperty | toString }}",
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), cm)).To(Succeed())
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		tc.externalSecret.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{
+			TemplateFrom: []esv1alpha1.TemplateFrom{
+				{
+					ConfigMap: &esv1alpha1.TemplateRef{
+						Name:  tplFromCMName,
+						Items: []esv1alpha1.TemplateRefItem{{Key: tplFromKey}},
+					},
+				},
+			},
+		}
+		fakeProvider.WithGetSecret([]byte("someValue"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[tplFromKey])).To(Equal("before: someValue"))
+
+			cm.Data[tplFromKey] = "after: {{ .targetProperty | toString }}"
+			Expect(k8sClient.Update(context.Background(), cm)).To(Succeed())
+			Eventually(func() string {
+				var updated v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &updated)).To(Succeed())
+				return string(updated.Data[tplFromKey])
+			}, timeout, interval).Should(Equal("after: someValue"))
+		}
+	}
+
+	// a change of the spec of the store refreshes the secret within the refresh interval
+	refreshOnStoreChange := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			var store esv1alpha1.SecretStore
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{Name: ExternalSecretStore, Namespace: ExternalSecretNamespace}, &store)).To(Succeed())
+			store.Spec.Provider.AWS.Region = "eu-west-1"
+			Expect(k8sClient.Update(context.Background(), &store)).To(Succeed())
+			Eventually(func() string {
+				var updated v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &updated)).To(Succeed())
+				return string(updated.Data[targetProp])
+			}, timeout, interval).Should(Equal("after"))
+		}
+	}
+
+	// a new value of the force-sync annotation refreshes the secret
+	// within the refresh interval and is reported in the status
+	refreshOnForceSync := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+			Expect(es.Status.ForceSync).To(BeEmpty())
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			var updated esv1alpha1.ExternalSecret
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+			if updated.Annotations == nil {
+				updated.Annotations = make(map[string]string)
+			}
+			updated.Annotations[esv1alpha1.AnnotationForceSync] = "1666000000"
+			Expect(k8sClient.Update(context.Background(), &updated)).To(Succeed())
+			Eventually(func() string {
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+				return updated.Status.ForceSync
+			}, timeout, interval).Should(Equal("1666000000"))
+
+			var syncedSecret v1.Secret
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+			Expect(string(syncedSecret.Data[targetProp])).To(Equal("after"))
+		}
+	}
+
+	// forceSync sets the force-sync annotation of the ExternalSecret to value
+	// and waits until the value is reported in the status
+	forceSync := func(es *esv1alpha1.ExternalSecret, value string) {
+		var updated esv1alpha1.ExternalSecret
+		Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+		if updated.Annotations == nil {
+			updated.Annotations = make(map[string]string)
+		}
+		updated.Annotations[esv1alpha1.AnnotationForceSync] = value
+		Expect(k8sClient.Update(context.Background(), &updated)).To(Succeed())
+		Eventually(func() string {
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+			return updated.Status.ForceSync
+		}, timeout, interval).Should(Equal(value))
+	}
+
+	// a force-sync refreshes a secret with refreshPolicy=CreatedOnce
+	forceSyncCreatedOnce := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshPolicy = esv1alpha1.RefreshPolicyCreatedOnce
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			forceSync(es, "1666000000")
+
+			var syncedSecret v1.Secret
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+			Expect(string(syncedSecret.Data[targetProp])).To(Equal("after"))
+		}
+	}
+
+	// a force-sync of an immutable secret is reported in the status
+	// without changing the secret
+	forceSyncImmutable := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.Immutable = true
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			forceSync(es, "1666000000")
+
+			var syncedSecret v1.Secret
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+			Expect(string(syncedSecret.Data[targetProp])).To(Equal("before"))
+			Eventually(func() bool {
+				return hasEvent(es, v1.EventTypeNormal, events.ReasonSkipped, fmt.Sprintf(msgImmutableForceSync, "1666000000", "Secret", secret.Name))
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// with refreshPolicy=CreatedOnce the secret is never
+	// refreshed once it was created, not even on a spec change
+	refreshPolicyCreatedOnce := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Second}
+		tc.externalSecret.Spec.RefreshPolicy = esv1alpha1.RefreshPolicyCreatedOnce
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			var updated esv1alpha1.ExternalSecret
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+			refreshTime := updated.Status.RefreshTime
+			updated.Spec.Data[0].RemoteRef.Property = "changed"
+			Expect(k8sClient.Update(context.Background(), &updated)).To(Succeed())
+
+			Consistently(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return string(syncedSecret.Data[targetProp])
+			}, time.Second*5, time.Second).Should(Equal("before"))
+
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+			Expect(updated.Status.RefreshTime.Equal(&refreshTime)).To(BeTrue())
+			cond := GetExternalSecretCondition(updated.Status, esv1alpha1.ExternalSecretReady)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(v1.ConditionTrue))
+			Expect(cond.Message).To(Equal(msgCreatedOnce))
+		}
+	}
+
+	// with refreshPolicy=OnChange the refreshInterval is ignored,
+	// the secret is only refreshed when the ExternalSecret changes
+	refreshPolicyOnChange := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Second}
+		tc.externalSecret.Spec.RefreshPolicy = esv1alpha1.RefreshPolicyOnChange
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			Consistently(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return string(syncedSecret.Data[targetProp])
+			}, time.Second*5, time.Second).Should(Equal("before"))
+
+			var updated esv1alpha1.ExternalSecret
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+			refreshTime := updated.Status.RefreshTime
+			if updated.Labels == nil {
+				updated.Labels = make(map[string]string)
+			}
+			updated.Labels["changed"] = "true"
+			Expect(k8sClient.Update(context.Background(), &updated)).To(Succeed())
+
+			Eventually(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return string(syncedSecret.Data[targetProp])
+			}, timeout, interval).Should(Equal("after"))
+			Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+			Expect(updated.Status.RefreshTime.After(refreshTime.Time)).To(BeTrue())
+		}
+	}
+
+	// an explicit refreshPolicy=Periodic behaves like the default
+	refreshPolicyPeriodic := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Second}
+		tc.externalSecret.Spec.RefreshPolicy = esv1alpha1.RefreshPolicyPeriodic
+		fakeProvider.WithGetSecret([]byte("before"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("before"))
+
+			fakeProvider.WithGetSecret([]byte("after"), nil)
+			Eventually(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return string(syncedSecret.Data[targetProp])
+			}, timeout, interval).Should(Equal("after"))
+		}
+	}
+
+	// a manual change of the secret data is corrected right away
+	driftCorrect := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		fakeProvider.WithGetSecret([]byte("value"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("value"))
+
+			secret.Data[targetProp] = []byte("edited")
+			Expect(k8sClient.Update(context.Background(), secret)).To(Succeed())
+
+			Eventually(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return string(syncedSecret.Data[targetProp])
+			}, timeout, interval).Should(Equal("value"))
+			Eventually(func() bool {
+				return hasEvent(es, v1.EventTypeWarning, events.ReasonDrifted, fmt.Sprintf(msgDrifted, "Secret", ExternalSecretTargetSecretName, driftFieldData))
+			}, timeout, interval).Should(BeTrue())
+			Eventually(func() v1.ConditionStatus {
+				var updated esv1alpha1.ExternalSecret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+				cond := GetExternalSecretCondition(updated.Status, esv1alpha1.ExternalSecretDrifted)
+				if cond == nil {
+					return v1.ConditionUnknown
+				}
+				return cond.Status
+			}, timeout, interval).Should(Equal(v1.ConditionFalse))
+			Expect(testutil.ToFloat64(driftDetected.WithLabelValues(es.Name, es.Namespace))).To(Equal(1.0))
+		}
+	}
+
+	// a manual change of an orphaned secret, which has no owner reference
+	// to map it to the ExternalSecret, is corrected right away as well
+	driftCorrectOrphan := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		tc.externalSecret.Spec.Target.CreationPolicy = esv1alpha1.Orphan
+		fakeProvider.WithGetSecret([]byte("value"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal("value"))
+			Expect(secret.OwnerReferences).To(BeEmpty())
+
+			secret.Data[targetProp] = []byte("edited")
+			Expect(k8sClient.Update(context.Background(), secret)).To(Succeed())
+
+			Eventually(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return string(syncedSecret.Data[targetProp])
+			}, timeout, interval).Should(Equal("value"))
+			Eventually(func() bool {
+				return hasEvent(es, v1.EventTypeWarning, events.ReasonDrifted, fmt.Sprintf(msgDrifted, "Secret", ExternalSecretTargetSecretName, driftFieldData))
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// with driftPolicy=Report a manual change of the secret labels is kept and reported
+	driftReport := func(tc *testCase) {
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Hour}
+		tc.externalSecret.Spec.Target.DriftPolicy = esv1alpha1.DriftPolicyReport
+		tc.externalSecret.Labels = map[string]string{"team": "a"}
+		fakeProvider.WithGetSecret([]byte("value"), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Labels).To(HaveKeyWithValue("team", "a"))
+
+			secret.Labels["team"] = "b"
+			secret.Labels["unmanaged"] = "true"
+			Expect(k8sClient.Update(context.Background(), secret)).To(Succeed())
+
+			msg := fmt.Sprintf(msgDrifted, "Secret", ExternalSecretTargetSecretName, "label team")
+			Eventually(func() string {
+				var updated esv1alpha1.ExternalSecret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(es), &updated)).To(Succeed())
+				cond := GetExternalSecretCondition(updated.Status, esv1alpha1.ExternalSecretDrifted)
+				if cond == nil || cond.Status != v1.ConditionTrue {
+					return ""
+				}
+				return cond.Message
+			}, timeout, interval).Should(Equal(msg))
+			Expect(hasEvent(es, v1.EventTypeWarning, events.ReasonDrifted, msg)).To(BeTrue())
+			Expect(testutil.ToFloat64(driftDetected.WithLabelValues(es.Name, es.Namespace))).To(Equal(1.0))
+
+			Consistently(func() string {
+				var syncedSecret v1.Secret
+				Expect(k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &syncedSecret)).To(Succeed())
+				return syncedSecret.Labels["team"]
+			}, time.Second*3, time.Second).Should(Equal("b"))
+		}
+	}
+
 	refreshWithTemplate := func(tc *testCase) {
 		const secretVal = "someValue"
 		const tplStaticKey = "tplstatickey"
@@ -656,7 +1205,7 @@ var _ = Describe("ExternalSecret controller", func() {
 	// should be put into the secret
 	syncWithDataFrom := func(tc *testCase) {
 		tc.externalSecret.Spec.Data = nil
-		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataRemoteRef{
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
 			{
 				Key: remoteKey,
 			},
@@ -672,6 +1221,276 @@ var _ = Describe("ExternalSecret controller", func() {
 		}
 	}
 
+	// with dataFrom.find all matching secrets of the provider
+	// should be put into the secret using the key naming
+	syncWithDataFromFind := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				Find: &esv1alpha1.ExternalSecretFind{
+					Name:      &esv1alpha1.FindName{RegExp: "^app/"},
+					KeyNaming: esv1alpha1.FindKeyNamingBase,
+				},
+			},
+		}
+		fakeProvider.WithGetAllSecrets(map[string][]byte{
+			"app/foo":     []byte(FooValue),
+			"app/bar:baz": []byte(BarValue),
+		}, nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			// check values
+			Expect(string(secret.Data["foo"])).To(Equal(FooValue))
+			Expect(string(secret.Data["bar_baz"])).To(Equal(BarValue))
+		}
+	}
+
+	// values are decoded with the decodingStrategy of
+	// data and dataFrom before they are put into the secret
+	syncWithDecodingStrategy := func(tc *testCase) {
+		tc.externalSecret.Spec.Data[0].RemoteRef.DecodingStrategy = esv1alpha1.ExternalSecretDecodeBase64
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				Key:              remoteKey,
+				DecodingStrategy: esv1alpha1.ExternalSecretDecodeAuto,
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(base64.StdEncoding.EncodeToString([]byte(FooValue))), nil)
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"encoded": []byte(base64.URLEncoding.EncodeToString([]byte(BarValue))),
+			"plain":   []byte("not base64!"),
+		}, nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(FooValue))
+			Expect(string(secret.Data["encoded"])).To(Equal(BarValue))
+			Expect(string(secret.Data["plain"])).To(Equal("not base64!"))
+		}
+	}
+
+	// the keys of dataFrom are rewritten with
+	// the rules in the given order
+	syncWithRewrite := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				Key: remoteKey,
+				Rewrite: []esv1alpha1.ExternalSecretRewrite{
+					{Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: "[/.]", Target: "_"}},
+					{Prefix: "app_"},
+					{Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .value | upper }}"}},
+				},
+			},
+		}
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"db/password": []byte(FooValue),
+			"app.config":  []byte(BarValue),
+		}, nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data).To(HaveLen(2))
+			Expect(string(secret.Data["APP_DB_PASSWORD"])).To(Equal(FooValue))
+			Expect(string(secret.Data["APP_APP_CONFIG"])).To(Equal(BarValue))
+		}
+	}
+
+	// keys that are not valid Secret keys after rewriting
+	// must be reported with the InvalidKeys condition
+	invalidKeysCondition := func(tc *testCase) {
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				Key: remoteKey,
+				Rewrite: []esv1alpha1.ExternalSecretRewrite{
+					{Prefix: "db/"},
+				},
+			},
+		}
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"password": []byte(FooValue),
+		}, nil)
+		tc.checkCondition = func(es *esv1alpha1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1alpha1.ConditionReasonInvalidKeys {
+				return false
+			}
+			return strings.Contains(cond.Message, `"db/password"`)
+		}
+	}
+
+	// with deletionPolicy=Merge the keys of secrets
+	// that were deleted from the provider are left out
+	deletionPolicyMerge := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.DeletionPolicy = esv1alpha1.DeletionPolicyMerge
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				Key: remoteKey,
+			},
+		}
+		fakeProvider.WithGetSecret([]byte(FooValue), nil)
+		fakeProvider.WithGetSecretMap(nil, provider.NewNotFoundError(fmt.Errorf("secret gone")))
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data).To(HaveLen(1))
+			Expect(string(secret.Data[targetProp])).To(Equal(FooValue))
+		}
+	}
+
+	// with deletionPolicy=Delete the secret is deleted
+	// once all of its data was deleted from the provider
+	deletionPolicyDelete := func(tc *testCase) {
+		tc.externalSecret.Spec.Target.DeletionPolicy = esv1alpha1.DeletionPolicyDelete
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Second}
+		fakeProvider.WithGetSecret([]byte(FooValue), nil)
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(FooValue))
+
+			fakeProvider.WithGetSecret(nil, provider.NewNotFoundError(fmt.Errorf("secret gone")))
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &v1.Secret{})
+				return apierrors.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+
+			esKey := types.NamespacedName{Name: ExternalSecretName, Namespace: ExternalSecretNamespace}
+			Eventually(func() bool {
+				err := k8sClient.Get(context.Background(), esKey, es)
+				if err != nil {
+					return false
+				}
+				cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretReady)
+				return cond != nil && cond.Reason == esv1alpha1.ConditionReasonSecretDeleted
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// with the default deletionPolicy=Retain a secret that was
+	// deleted from the provider is reported as an error
+	deletionPolicyRetain := func(tc *testCase) {
+		fakeProvider.WithGetSecret(nil, provider.NewNotFoundError(fmt.Errorf("secret gone")))
+		tc.checkCondition = func(es *esv1alpha1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != esv1alpha1.ConditionReasonSecretNotFound {
+				return false
+			}
+			return strings.Contains(cond.Message, "secret gone")
+		}
+	}
+
+	// provider errors set the reason of their category
+	// and are counted by category
+	providerErrCategory := func(tc *testCase) {
+		fakeProvider.WithGetSecret(nil, provider.NewPermissionDeniedError(fmt.Errorf("access denied")))
+		tc.checkCondition = func(es *esv1alpha1.ExternalSecret) bool {
+			cond := GetExternalSecretCondition(es.Status, esv1alpha1.ExternalSecretReady)
+			return cond != nil && cond.Status == v1.ConditionFalse && cond.Reason == esv1alpha1.ConditionReasonPermissionDenied
+		}
+		tc.checkExternalSecret = func(es *esv1alpha1.ExternalSecret) {
+			Expect(providerErrors.WithLabelValues(ExternalSecretName, ExternalSecretNamespace, string(provider.ErrorCategoryPermissionDenied)).Write(&metric)).To(Succeed())
+			Expect(metric.GetCounter().GetValue()).To(BeNumerically(">=", 1.0))
+		}
+	}
+
+	// transient provider errors should be retried within the reconcile
+	// as configured by the retry settings of the store
+	retryTransientErr := func(tc *testCase) {
+		const secretVal = "someValue"
+		var calls int32
+		retries := int32(3)
+		interval := "10ms"
+		tc.secretStore.Spec.RetrySettings = &esv1alpha1.SecretStoreRetrySettings{
+			MaxRetries:    &retries,
+			RetryInterval: &interval,
+		}
+		fakeProvider.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return nil, provider.NewTransientError(fmt.Errorf("unavailable"))
+			}
+			return []byte(secretVal), nil
+		}
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(string(secret.Data[targetProp])).To(Equal(secretVal))
+			Expect(atomic.LoadInt32(&calls)).To(BeNumerically(">=", 2))
+		}
+	}
+
+	// syncing the secret emits an event for the created secret
+	// and names the changed keys once it is updated
+	emitSyncEvents := func(tc *testCase) {
+		const secretVal = "someValue"
+		fakeProvider.WithGetSecret([]byte(secretVal), nil)
+		tc.externalSecret.Spec.RefreshInterval = &metav1.Duration{Duration: time.Second}
+		tc.checkExternalSecret = func(es *esv1alpha1.ExternalSecret) {
+			Eventually(func() bool {
+				return hasEvent(es, v1.EventTypeNormal, events.ReasonCreated, "Created Secret "+ExternalSecretTargetSecretName)
+			}, timeout, interval).Should(BeTrue())
+			fakeProvider.WithGetSecret([]byte("newValue"), nil)
+			Eventually(func() bool {
+				return hasEvent(es, v1.EventTypeNormal, events.ReasonUpdated, fmt.Sprintf("Updated Secret %s, changed keys: %s", ExternalSecretTargetSecretName, targetProp))
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// with recordProvenance the source of every key and the
+	// version reported by the provider should be listed in the status
+	recordProvenance := func(tc *testCase) {
+		tc.externalSecret.Spec.RecordProvenance = true
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				Key:     remoteKey,
+				Rewrite: []esv1alpha1.ExternalSecretRewrite{{Prefix: "from_"}},
+			},
+		}
+		fakeProvider.GetSecretFn = func(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+			provider.RecordVersion(ctx, ref.Key, ref.Version, "5")
+			return []byte(FooValue), nil
+		}
+		fakeProvider.WithGetSecretMap(map[string][]byte{
+			"foo": []byte(FooValue),
+		}, nil)
+		tc.checkExternalSecret = func(es *esv1alpha1.ExternalSecret) {
+			storeRef := &esv1alpha1.SecretStoreRef{Name: ExternalSecretStore}
+			Expect(es.Status.Provenance).To(Equal([]esv1alpha1.ExternalSecretKeyProvenance{
+				{
+					SecretKey: "from_foo",
+					RemoteKey: remoteKey,
+					Property:  "foo",
+					StoreRef:  storeRef,
+				},
+				{
+					SecretKey: targetProp,
+					RemoteKey: remoteKey,
+					Property:  remoteProperty,
+					VersionID: "5",
+					StoreRef:  storeRef,
+				},
+			}))
+		}
+	}
+
+	// with dataFrom.generatorRef the generated data
+	// should be put into the secret without using the store
+	syncWithGenerator := func(tc *testCase) {
+		const passwordLength = 16
+		Expect(k8sClient.Create(context.Background(), &genv1alpha1.Password{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-password",
+				Namespace: ExternalSecretNamespace,
+			},
+			Spec: genv1alpha1.PasswordSpec{
+				Length: passwordLength,
+			},
+		})).To(Succeed())
+		tc.externalSecret.Spec.SecretStoreRef = nil
+		tc.externalSecret.Spec.Data = nil
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
+			{
+				GeneratorRef: &esv1alpha1.GeneratorRef{
+					Kind: genv1alpha1.PasswordKind,
+					Name: "test-password",
+				},
+			},
+		}
+		tc.checkSecret = func(es *esv1alpha1.ExternalSecret, secret *v1.Secret) {
+			Expect(secret.Data["password"]).To(HaveLen(passwordLength))
+		}
+	}
+
 	// with dataFrom and using a template
 	// should be put into the secret
 	syncWithDataFromTemplate := func(tc *testCase) {
@@ -683,7 +1502,7 @@ var _ = Describe("ExternalSecret controller", func() {
 			},
 		}
 
-		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataRemoteRef{
+		tc.externalSecret.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{
 			{
 				Key: remoteKey,
 			},
@@ -932,9 +1751,29 @@ var _ = Describe("ExternalSecret controller", func() {
 		Entry("should use external secret name if target secret name isn't defined", syncWithoutTargetName),
 		Entry("should set the condition eventually", syncLabelsAnnotations),
 		Entry("should set prometheus counters", checkPrometheusCounters),
+		Entry("should remove the prometheus series of a deleted ExternalSecret", checkPrometheusCleanup),
+		Entry("should trace the reconcile", checkTracing),
+		Entry("should refresh secret when a templateFrom ConfigMap changes", refreshOnTemplateFromChange),
+		Entry("should refresh secret when the store changes", refreshOnStoreChange),
+		Entry("should refresh secret on a new force-sync value", refreshOnForceSync),
+		Entry("should not refresh secret with refreshPolicy=CreatedOnce", refreshPolicyCreatedOnce),
+		Entry("should refresh secret with refreshPolicy=CreatedOnce on a new force-sync value", forceSyncCreatedOnce),
+		Entry("should report a new force-sync value of an immutable secret", forceSyncImmutable),
+		Entry("should refresh secret only on change with refreshPolicy=OnChange", refreshPolicyOnChange),
+		Entry("should refresh secret periodically with refreshPolicy=Periodic", refreshPolicyPeriodic),
+		Entry("should correct a manual change of the secret", driftCorrect),
+		Entry("should correct a manual change of an orphaned secret", driftCorrectOrphan),
+		Entry("should only report a manual change of the secret with driftPolicy=Report", driftReport),
 		Entry("should merge with existing secret using creationPolicy=Merge", mergeWithSecret),
 		Entry("should error if secret doesn't exist when using creationPolicy=Merge", mergeWithSecretErr),
 		Entry("should not resolve conflicts with creationPolicy=Merge", mergeWithConflict),
+		Entry("should remove the merged fields when deleting an ExternalSecret with creationPolicy=Merge", mergeCleanupOnDelete),
+		Entry("should remove the merged fields when changing creationPolicy from Merge to Owner", mergeToOwner),
+		Entry("should keep the secret of a deleted ExternalSecret with creationPolicy=Orphan", orphanSecret),
+		Entry("should release the secret when changing creationPolicy from Owner to Orphan", ownerToOrphan),
+		Entry("should write the data to a ConfigMap", syncToConfigMap),
+		Entry("should render the data into a manifest of another kind", syncToManifest),
+		Entry("should refuse a target kind that is not allowed", rejectTargetKind),
 		Entry("should sync with template", syncWithTemplate),
 		Entry("should sync template with correct value precedence", syncWithTemplatePrecedence),
 		Entry("should refresh secret from template", refreshWithTemplate),
@@ -943,7 +1782,19 @@ var _ = Describe("ExternalSecret controller", func() {
 		Entry("should not refresh secret value when provider secret changes but refreshInterval is zero", refreshintervalZero),
 		Entry("should fetch secret using dataFrom", syncWithDataFrom),
 		Entry("should fetch secret using dataFrom and a template", syncWithDataFromTemplate),
+		Entry("should fetch secrets using dataFrom.find", syncWithDataFromFind),
+		Entry("should generate secrets using dataFrom.generatorRef", syncWithGenerator),
+		Entry("should decode secrets using the decodingStrategy", syncWithDecodingStrategy),
+		Entry("should rewrite the keys of dataFrom", syncWithRewrite),
+		Entry("should set an InvalidKeys condition for invalid rewritten keys", invalidKeysCondition),
+		Entry("should leave out deleted provider secrets with deletionPolicy=Merge", deletionPolicyMerge),
+		Entry("should delete the secret when provider data is deleted with deletionPolicy=Delete", deletionPolicyDelete),
+		Entry("should set an error condition when provider data is deleted with deletionPolicy=Retain", deletionPolicyRetain),
 		Entry("should set error condition when provider errors", providerErrCondition),
+		Entry("should set the reason of the provider error category", providerErrCategory),
+		Entry("should retry transient provider errors with the retry settings of the store", retryTransientErr),
+		Entry("should record the provenance of the keys in the status", recordProvenance),
+		Entry("should emit events when the secret is created and updated", emitSyncEvents),
 		Entry("should set an error condition when store does not exist", storeMissingErrCondition),
 		Entry("should set an error condition when store provider constructor fails", storeConstructErrCondition),
 		Entry("should not process store with mismatching controller field", ignoreMismatchController),
@@ -957,7 +1808,85 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 				Status: esv1alpha1.ExternalSecretStatus{
 					SyncedResourceVersion: "some resource version",
 				},
-			})).To(BeTrue())
+			}, "")).To(BeTrue())
+		})
+		It("should refresh on a new value of the force-sync annotation", func() {
+			es := esv1alpha1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{esv1alpha1.AnnotationForceSync: "1"},
+				},
+				Spec: esv1alpha1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: 0},
+				},
+				Status: esv1alpha1.ExternalSecretStatus{
+					RefreshTime: metav1.Now(),
+				},
+			}
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
+			Expect(shouldRefresh(es, "")).To(BeTrue())
+
+			es.Status.ForceSync = "1"
+			Expect(shouldRefresh(es, "")).To(BeFalse())
+
+			// the annotation is not part of the resource version
+			es.Annotations[esv1alpha1.AnnotationForceSync] = "2"
+			Expect(getResourceVersion(es, "")).To(Equal(es.Status.SyncedResourceVersion))
+			Expect(shouldRefresh(es, "")).To(BeTrue())
+		})
+		It("should only refresh on change with refreshPolicy=OnChange", func() {
+			es := esv1alpha1.ExternalSecret{
+				Spec: esv1alpha1.ExternalSecretSpec{
+					RefreshPolicy:   esv1alpha1.RefreshPolicyOnChange,
+					RefreshInterval: &metav1.Duration{Duration: time.Second},
+				},
+				Status: esv1alpha1.ExternalSecretStatus{
+					RefreshTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+				},
+			}
+			Expect(shouldRefresh(es, "")).To(BeTrue())
+
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
+			Expect(shouldRefresh(es, "")).To(BeFalse())
+
+			es.Spec.RefreshInterval = &metav1.Duration{Duration: time.Minute}
+			Expect(shouldRefresh(es, "")).To(BeTrue())
+		})
+		It("should skip a created secret with refreshPolicy=CreatedOnce", func() {
+			es := esv1alpha1.ExternalSecret{
+				Spec: esv1alpha1.ExternalSecretSpec{
+					RefreshPolicy: esv1alpha1.RefreshPolicyCreatedOnce,
+				},
+			}
+			existing := v1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "1234"}}
+			Expect(isCreatedOnce(es, existing)).To(BeFalse())
+
+			es.Status.RefreshTime = metav1.Now()
+			Expect(isCreatedOnce(es, existing)).To(BeTrue())
+
+			// a deleted secret is created again
+			Expect(isCreatedOnce(es, v1.Secret{})).To(BeFalse())
+
+			// a force-sync refreshes the secret once
+			es.Annotations = map[string]string{esv1alpha1.AnnotationForceSync: "1"}
+			Expect(isCreatedOnce(es, existing)).To(BeFalse())
+			es.Status.ForceSync = "1"
+			Expect(isCreatedOnce(es, existing)).To(BeTrue())
+
+			es.Spec.RefreshPolicy = esv1alpha1.RefreshPolicyPeriodic
+			Expect(isCreatedOnce(es, existing)).To(BeFalse())
+		})
+		It("should refresh when the dependencies change", func() {
+			es := esv1alpha1.ExternalSecret{
+				Spec: esv1alpha1.ExternalSecretSpec{
+					RefreshInterval: &metav1.Duration{Duration: time.Minute},
+				},
+				Status: esv1alpha1.ExternalSecretStatus{
+					RefreshTime: metav1.Now(),
+				},
+			}
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "store-v1")
+			Expect(shouldRefresh(es, "store-v1")).To(BeFalse())
+			Expect(shouldRefresh(es, "store-v2")).To(BeTrue())
 		})
 		It("should refresh when labels change", func() {
 			es := esv1alpha1.ExternalSecret{
@@ -974,13 +1903,13 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 					RefreshTime: metav1.Now(),
 				},
 			}
-			es.Status.SyncedResourceVersion = getResourceVersion(es)
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
 			// this should not refresh, rv matches object
-			Expect(shouldRefresh(es)).To(BeFalse())
+			Expect(shouldRefresh(es, "")).To(BeFalse())
 
 			// change labels without changing the syncedResourceVersion and expect refresh
 			es.ObjectMeta.Labels["new"] = "w00t"
-			Expect(shouldRefresh(es)).To(BeTrue())
+			Expect(shouldRefresh(es, "")).To(BeTrue())
 		})
 
 		It("should refresh when annotations change", func() {
@@ -998,13 +1927,13 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 					RefreshTime: metav1.Now(),
 				},
 			}
-			es.Status.SyncedResourceVersion = getResourceVersion(es)
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
 			// this should not refresh, rv matches object
-			Expect(shouldRefresh(es)).To(BeFalse())
+			Expect(shouldRefresh(es, "")).To(BeFalse())
 
 			// change annotations without changing the syncedResourceVersion and expect refresh
 			es.ObjectMeta.Annotations["new"] = "w00t"
-			Expect(shouldRefresh(es)).To(BeTrue())
+			Expect(shouldRefresh(es, "")).To(BeTrue())
 		})
 
 		It("should refresh when generation has changed", func() {
@@ -1019,12 +1948,12 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 					RefreshTime: metav1.Now(),
 				},
 			}
-			es.Status.SyncedResourceVersion = getResourceVersion(es)
-			Expect(shouldRefresh(es)).To(BeFalse())
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
+			Expect(shouldRefresh(es, "")).To(BeFalse())
 
 			// update gen -> refresh
 			es.ObjectMeta.Generation = 2
-			Expect(shouldRefresh(es)).To(BeTrue())
+			Expect(shouldRefresh(es, "")).To(BeTrue())
 		})
 
 		It("should skip refresh when refreshInterval is 0", func() {
@@ -1038,8 +1967,8 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 				Status: esv1alpha1.ExternalSecretStatus{},
 			}
 			// resource version matches
-			es.Status.SyncedResourceVersion = getResourceVersion(es)
-			Expect(shouldRefresh(es)).To(BeFalse())
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
+			Expect(shouldRefresh(es, "")).To(BeFalse())
 		})
 
 		It("should refresh when refresh interval has passed", func() {
@@ -1055,8 +1984,8 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 				},
 			}
 			// resource version matches
-			es.Status.SyncedResourceVersion = getResourceVersion(es)
-			Expect(shouldRefresh(es)).To(BeTrue())
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
+			Expect(shouldRefresh(es, "")).To(BeTrue())
 		})
 
 		It("should refresh when no refresh time was set", func() {
@@ -1070,8 +1999,8 @@ var _ = Describe("ExternalSecret refresh logic", func() {
 				Status: esv1alpha1.ExternalSecretStatus{},
 			}
 			// resource version matches
-			es.Status.SyncedResourceVersion = getResourceVersion(es)
-			Expect(shouldRefresh(es)).To(BeTrue())
+			es.Status.SyncedResourceVersion = getResourceVersion(es, "")
+			Expect(shouldRefresh(es, "")).To(BeTrue())
 		})
 
 	})
@@ -1161,6 +2090,138 @@ var _ = Describe("Controller Reconcile logic", func() {
 			})).To(BeTrue())
 		})
 
+		It("should name the changed keys of the secret data", func() {
+			old := map[string][]byte{"same": []byte("a"), "changed": []byte("b"), "removed": []byte("c")}
+			updated := map[string][]byte{"same": []byte("a"), "changed": []byte("x"), "added": []byte("d")}
+			Expect(changedKeys(old, updated, true)).To(Equal([]string{"added", "changed", "removed"}))
+			Expect(changedKeys(old, updated, false)).To(Equal([]string{"added", "changed"}))
+		})
+
+		It("should index the targets that are checked for drift", func() {
+			es := &esv1alpha1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{Name: "es"},
+				Spec: esv1alpha1.ExternalSecretSpec{
+					Target: esv1alpha1.ExternalSecretTarget{CreationPolicy: esv1alpha1.Orphan},
+				},
+			}
+			Expect(driftTargetNames(es, secretGroupKind)).To(Equal([]string{"es"}))
+			Expect(driftTargetNames(es, configMapGroupKind)).To(BeEmpty())
+
+			es.Spec.Target.Name = "target"
+			es.Spec.Target.CreationPolicy = esv1alpha1.Owner
+			Expect(driftTargetNames(es, secretGroupKind)).To(Equal([]string{"target"}))
+
+			es.Spec.Target.CreationPolicy = esv1alpha1.Merge
+			Expect(driftTargetNames(es, secretGroupKind)).To(BeEmpty())
+
+			es.Spec.Target.CreationPolicy = esv1alpha1.Owner
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "v1", Kind: "ConfigMap"}
+			Expect(driftTargetNames(es, configMapGroupKind)).To(Equal([]string{"target"}))
+			Expect(driftTargetNames(es, secretGroupKind)).To(BeEmpty())
+		})
+		It("should detect the drift of an owned secret", func() {
+			es := esv1alpha1.ExternalSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"team": "a"},
+					Annotations: map[string]string{"note": "x"},
+				},
+				Status: esv1alpha1.ExternalSecretStatus{
+					SyncedResourceVersion: "1",
+				},
+			}
+			data := map[string][]byte{"key": []byte("value")}
+			secret := v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:         "1234",
+					Labels:      map[string]string{"team": "a", "unmanaged": "true"},
+					Annotations: map[string]string{"note": "x", esv1alpha1.AnnotationDataHash: utils.ObjectHash(data)},
+				},
+				Data: data,
+			}
+			Expect(secretDrift(es, secret)).To(BeEmpty())
+
+			drifted := secret.DeepCopy()
+			drifted.Data = map[string][]byte{"key": []byte("edited")}
+			drifted.Labels["team"] = "b"
+			delete(drifted.Annotations, "note")
+			Expect(secretDrift(es, *drifted)).To(Equal([]string{driftFieldData, "label team", "annotation note"}))
+
+			// merged secrets and secrets that were never synced are not checked
+			merged := es.DeepCopy()
+			merged.Spec.Target.CreationPolicy = esv1alpha1.Merge
+			Expect(secretDrift(*merged, *drifted)).To(BeEmpty())
+			es.Status.SyncedResourceVersion = ""
+			Expect(secretDrift(es, *drifted)).To(BeEmpty())
+		})
+
+		It("should render the target of the ExternalSecret", func() {
+			es := &esv1alpha1.ExternalSecret{
+				Spec: esv1alpha1.ExternalSecretSpec{
+					Target: esv1alpha1.ExternalSecretTarget{
+						Manifest: &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "v1", Kind: "ConfigMap"},
+					},
+				},
+			}
+			secret := &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+					Labels:    map[string]string{"team": "a"},
+				},
+				Data: map[string][]byte{"text": []byte("value"), "binary": {0xff, 0xfe}},
+			}
+			obj, err := renderTarget(es, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj.GetKind()).To(Equal("ConfigMap"))
+			Expect(obj.GetName()).To(Equal("foo"))
+			Expect(obj.GetLabels()).To(HaveKeyWithValue("team", "a"))
+			Expect(obj.Object["data"]).To(Equal(map[string]interface{}{"text": "value"}))
+			Expect(obj.Object["binaryData"]).To(Equal(map[string]interface{}{"binary": "//4="}))
+
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "argoproj.io/v1alpha1", Kind: "Application"}
+			es.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{
+				Manifest: "metadata:\n  labels:\n    app: b\nspec:\n  value: {{ .text | toString }}",
+			}
+			obj, err = renderTarget(es, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj.GetAPIVersion()).To(Equal("argoproj.io/v1alpha1"))
+			Expect(obj.GetLabels()).To(Equal(map[string]string{"app": "b", "team": "a"}))
+			Expect(obj.Object["spec"]).To(Equal(map[string]interface{}{"value": "value"}))
+
+			// the kind is defined by the ExternalSecret
+			es.Spec.Target.Template.Manifest = "kind: Other"
+			_, err = renderTarget(es, secret)
+			Expect(err).To(MatchError(fmt.Sprintf(errTargetManifestObj, "kind")))
+		})
+
+		It("should index the Secrets referenced by a store", func() {
+			ns := "auth"
+			auth := esv1alpha1.VaultAuth{
+				TokenSecretRef: &esmeta.SecretKeySelector{Name: "token"},
+				AppRole: &esv1alpha1.VaultAppRole{
+					SecretRef: esmeta.SecretKeySelector{Name: "approle", Namespace: &ns},
+				},
+			}
+			spec := esv1alpha1.SecretStoreSpec{
+				Provider: &esv1alpha1.SecretStoreProvider{
+					Vault: &esv1alpha1.VaultProvider{Auth: auth},
+				},
+			}
+			Expect(storeRefIndexValues(&esv1alpha1.SecretStore{Spec: spec}, "Secret")).To(Equal([]string{"token", "approle"}))
+			Expect(storeRefIndexValues(&esv1alpha1.ClusterSecretStore{Spec: spec}, "Secret")).To(Equal([]string{"token", "auth/approle"}))
+			Expect(storeRefIndexValues(&esv1alpha1.SecretStore{Spec: spec}, "ConfigMap")).To(BeEmpty())
+		})
+
+		It("should report the seconds since the last sync", func() {
+			c := newLastSyncCollector()
+			now := time.Now()
+			c.now = func() time.Time { return now }
+			c.set(types.NamespacedName{Name: "foo", Namespace: "bar"}, now.Add(-time.Minute))
+			Expect(testutil.ToFloat64(c)).To(Equal(60.0))
+			c.delete(types.NamespacedName{Name: "foo", Namespace: "bar"})
+			Expect(testutil.CollectAndCount(c)).To(Equal(0))
+		})
+
 		It("should not reconcile if secret is immutable and has synced condition", func() {
 			Expect(shouldReconcile(esv1alpha1.ExternalSecret{
 				Spec: esv1alpha1.ExternalSecretSpec{
@@ -1177,6 +2238,19 @@ var _ = Describe("Controller Reconcile logic", func() {
 	})
 })
 
+func hasEvent(es *esv1alpha1.ExternalSecret, eventType, reason, message string) bool {
+	var eventList v1.EventList
+	if err := k8sClient.List(context.Background(), &eventList, client.InNamespace(es.Namespace)); err != nil {
+		return false
+	}
+	for _, e := range eventList.Items {
+		if e.InvolvedObject.UID == es.UID && e.Type == eventType && e.Reason == reason && e.Message == message {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateNamespace creates a new namespace in the cluster.
 func CreateNamespace(baseName string, c client.Client) (string, error) {
 	genName := fmt.Sprintf("ctrl-test-%v", baseName)
@@ -1217,6 +2291,53 @@ func hasFieldOwnership(meta metav1.ObjectMeta, mgr, rawFields string) bool {
 	return false
 }
 
+// tracedSpans returns the names of the spans of the reconciles of the ExternalSecret.
+func tracedSpans(name, ns string) []string {
+	spans := spanExporter.GetSpans()
+	traces := make(map[trace.TraceID]bool)
+	for _, span := range spans {
+		if span.Name != spanReconcile {
+			continue
+		}
+		attrs := make(map[attribute.Key]string)
+		for _, attr := range span.Attributes {
+			attrs[attr.Key] = attr.Value.Emit()
+		}
+		if attrs["externalsecret.name"] == name && attrs["externalsecret.namespace"] == ns {
+			traces[span.SpanContext.TraceID()] = true
+		}
+	}
+	var names []string
+	for _, span := range spans {
+		if traces[span.SpanContext.TraceID()] {
+			names = append(names, span.Name)
+		}
+	}
+	return names
+}
+
+// seriesCount returns the number of series of the collector for the ExternalSecret.
+func seriesCount(c prometheus.Collector, name, ns string) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	count := 0
+	for m := range ch {
+		var d dto.Metric
+		Expect(m.Write(&d)).To(Succeed())
+		labels := make(map[string]string)
+		for _, l := range d.GetLabel() {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["name"] == name && labels["namespace"] == ns {
+			count++
+		}
+	}
+	return count
+}
+
 func externalSecretConditionShouldBe(name, ns string, ct esv1alpha1.ExternalSecretConditionType, cs v1.ConditionStatus, v float64) bool {
 	return Eventually(func() float64 {
 		Expect(externalSecretCondition.WithLabelValues(name, ns, string(ct), string(cs)).Write(&metric)).To(Succeed())
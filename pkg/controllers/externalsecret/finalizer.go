@@ -0,0 +1,116 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+const (
+	// fieldManager is the field manager of the server-side applied targets.
+	fieldManager = "external-secrets"
+
+	errUpdateFinalizer   = "could not update finalizer"
+	errFinalize          = "could not finalize ExternalSecret"
+	errRemoveMergeFields = "could not remove the merged fields of %s %s: %w"
+)
+
+// targetName returns the name of the target, which defaults to the name of the ExternalSecret.
+func targetName(es *esv1alpha1.ExternalSecret) string {
+	if es.Spec.Target.Name != "" {
+		return es.Spec.Target.Name
+	}
+	return es.Name
+}
+
+// updateFinalizer adds the finalizer to ExternalSecrets that merge into their target.
+// Once they don't merge anymore, the merged fields are removed along with the finalizer.
+func (r *Reconciler) updateFinalizer(ctx context.Context, es *esv1alpha1.ExternalSecret) error {
+	merge := es.Spec.Target.CreationPolicy == esv1alpha1.Merge
+	if merge == controllerutil.ContainsFinalizer(es, esv1alpha1.FinalizerMergedFields) {
+		return nil
+	}
+	if !merge {
+		return r.finalize(ctx, es)
+	}
+	patch := client.MergeFrom(es.DeepCopy())
+	controllerutil.AddFinalizer(es, esv1alpha1.FinalizerMergedFields)
+	return r.Patch(ctx, es, patch)
+}
+
+// finalize removes the fields the ExternalSecret merged into its target, then its finalizer.
+// The finalizer is only present if the ExternalSecret merged into the target, even if its
+// creation policy changed since.
+func (r *Reconciler) finalize(ctx context.Context, es *esv1alpha1.ExternalSecret) error {
+	if !controllerutil.ContainsFinalizer(es, esv1alpha1.FinalizerMergedFields) {
+		return nil
+	}
+	if err := r.removeMergedFields(ctx, es); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(es.DeepCopy())
+	controllerutil.RemoveFinalizer(es, esv1alpha1.FinalizerMergedFields)
+	return r.Patch(ctx, es, patch)
+}
+
+// removeMergedFields removes the fields owned by the field manager from the target.
+// Applying an object without fields makes the API server drop every field the manager
+// applied before, unless another manager owns it as well.
+func (r *Reconciler) removeMergedFields(ctx context.Context, es *esv1alpha1.ExternalSecret) error {
+	name := targetName(es)
+	kind := targetKind(es)
+	// applying to a missing target would create it
+	_, err := r.getTarget(ctx, es, name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errRemoveMergeFields, kind, name, err)
+	}
+	gvk, err := targetGVK(es)
+	if err != nil {
+		return fmt.Errorf(errRemoveMergeFields, kind, name, err)
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(es.Namespace)
+	err = secretWriter{r.Client}.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldManager))
+	if err != nil {
+		return fmt.Errorf(errRemoveMergeFields, kind, name, err)
+	}
+	return nil
+}
+
+// removeOwnerRef removes the owner reference of the owner with the uid.
+func removeOwnerRef(meta *metav1.ObjectMeta, uid types.UID) {
+	refs := meta.OwnerReferences[:0]
+	for _, ref := range meta.OwnerReferences {
+		if ref.UID != uid {
+			refs = append(refs, ref)
+		}
+	}
+	meta.OwnerReferences = refs
+}
@@ -15,16 +15,26 @@ limitations under the License.
 package externalsecret
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 )
 
 const (
 	ExternalSecretSubsystem          = "externalsecret"
 	SyncCallsKey                     = "sync_calls_total"
 	SyncCallsErrorKey                = "sync_calls_error"
+	ProviderErrorsKey                = "provider_errors_total"
+	ReconcileDurationKey             = "reconcile_duration_seconds"
+	SecondsSinceLastSyncKey          = "seconds_since_last_sync"
+	DriftDetectedKey                 = "drift_detected_total"
 	externalSecretStatusConditionKey = "status_condition"
 )
 
@@ -41,13 +51,124 @@ var (
 		Help:      "Total number of the External Secret sync errors",
 	}, []string{"name", "namespace"})
 
+	providerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      ProviderErrorsKey,
+		Help:      "Total number of the External Secret sync errors by the category of the provider error",
+	}, []string{"name", "namespace", "category"})
+
 	externalSecretCondition = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Subsystem: ExternalSecretSubsystem,
 		Name:      externalSecretStatusConditionKey,
 		Help:      "The status condition of a specific External Secret",
 	}, []string{"name", "namespace", "condition", "status"})
+
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      ReconcileDurationKey,
+		Help:      "Duration of the External Secret reconciles",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"name", "namespace"})
+
+	driftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      DriftDetectedKey,
+		Help:      "Total number of changes of the target Secret of the External Secret since its last sync",
+	}, []string{"name", "namespace"})
+
+	lastSync = newLastSyncCollector()
+
+	errorCategories = []provider.ErrorCategory{
+		provider.ErrorCategoryNotFound,
+		provider.ErrorCategoryPermissionDenied,
+		provider.ErrorCategoryUnauthenticated,
+		provider.ErrorCategoryRateLimited,
+		provider.ErrorCategoryInvalidRef,
+		provider.ErrorCategoryTransient,
+		provider.ErrorCategoryUnknown,
+	}
 )
 
+// lastSyncCollector reports the seconds since the last successful sync of
+// every External Secret, computed at the time of the scrape.
+type lastSyncCollector struct {
+	desc  *prometheus.Desc
+	now   func() time.Time
+	mu    sync.Mutex
+	syncs map[types.NamespacedName]time.Time
+}
+
+func newLastSyncCollector() *lastSyncCollector {
+	return &lastSyncCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName("", ExternalSecretSubsystem, SecondsSinceLastSyncKey),
+			"Seconds since the last successful sync of a specific External Secret",
+			[]string{"name", "namespace"}, nil),
+		now:   time.Now,
+		syncs: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *lastSyncCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector.
+func (c *lastSyncCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for key, t := range c.syncs {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, now.Sub(t).Seconds(), key.Name, key.Namespace)
+	}
+}
+
+// set records the time of the last successful sync of the External Secret.
+func (c *lastSyncCollector) set(key types.NamespacedName, t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncs[key] = t
+}
+
+func (c *lastSyncCollector) delete(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.syncs, key)
+}
+
+// updateLastSync records the refresh time of the ExternalSecret as its last successful sync.
+func updateLastSync(es *esv1alpha1.ExternalSecret) {
+	if es.Status.RefreshTime.IsZero() {
+		return
+	}
+	lastSync.set(types.NamespacedName{Name: es.Name, Namespace: es.Namespace}, es.Status.RefreshTime.Time)
+}
+
+// observeReconcileDuration records the duration of a reconcile that started at start.
+func observeReconcileDuration(key types.NamespacedName, start time.Time) {
+	reconcileDuration.WithLabelValues(key.Name, key.Namespace).Observe(time.Since(start).Seconds())
+}
+
+// deleteMetrics removes the series of a deleted ExternalSecret,
+// so they don't stay around for the lifetime of the controller.
+func deleteMetrics(key types.NamespacedName) {
+	labels := prometheus.Labels{"name": key.Name, "namespace": key.Namespace}
+	syncCallsTotal.Delete(labels)
+	syncCallsError.Delete(labels)
+	reconcileDuration.Delete(labels)
+	driftDetected.Delete(labels)
+	lastSync.delete(key)
+	for _, category := range errorCategories {
+		providerErrors.DeleteLabelValues(key.Name, key.Namespace, string(category))
+	}
+	for _, condition := range []esv1alpha1.ExternalSecretConditionType{esv1alpha1.ExternalSecretReady, esv1alpha1.ExternalSecretDeleted, esv1alpha1.ExternalSecretDrifted} {
+		for _, status := range []v1.ConditionStatus{v1.ConditionTrue, v1.ConditionFalse, v1.ConditionUnknown} {
+			externalSecretCondition.DeleteLabelValues(key.Name, key.Namespace, string(condition), string(status))
+		}
+	}
+}
+
 // updateExternalSecretCondition updates the ExternalSecret conditions.
 func updateExternalSecretCondition(es *esv1alpha1.ExternalSecret, condition *esv1alpha1.ExternalSecretStatusCondition, value float64) {
 	externalSecretCondition.With(prometheus.Labels{
@@ -58,6 +179,20 @@ func updateExternalSecretCondition(es *esv1alpha1.ExternalSecret, condition *esv
 	}).Set(value)
 }
 
+// countProviderError counts a sync error by the category of the provider error.
+func countProviderError(es *esv1alpha1.ExternalSecret, err error) {
+	providerErrors.With(prometheus.Labels{
+		"name":      es.Name,
+		"namespace": es.Namespace,
+		"category":  string(provider.GetErrorCategory(err)),
+	}).Inc()
+}
+
+// countDrift counts a detected drift of the target Secret.
+func countDrift(es *esv1alpha1.ExternalSecret) {
+	driftDetected.WithLabelValues(es.Name, es.Namespace).Inc()
+}
+
 func init() {
-	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, externalSecretCondition)
+	metrics.Registry.MustRegister(syncCallsTotal, syncCallsError, providerErrors, externalSecretCondition, reconcileDuration, driftDetected, lastSync)
 }
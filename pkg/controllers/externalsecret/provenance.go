@@ -0,0 +1,98 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"sort"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+)
+
+// provenanceRecorder collects the source of every key of the fetched data.
+// A nil recorder records nothing, so callers don't need to check if provenance is enabled.
+type provenanceRecorder struct {
+	storeRef *esv1alpha1.SecretStoreRef
+	versions *provider.VersionRecorder
+	keys     map[string]esv1alpha1.ExternalSecretKeyProvenance
+}
+
+// newProvenanceRecorder returns a recorder if the ExternalSecret records provenance,
+// and a context that collects the provider versions of the secrets read with it.
+func newProvenanceRecorder(ctx context.Context, externalSecret *esv1alpha1.ExternalSecret) (context.Context, *provenanceRecorder) {
+	if !externalSecret.Spec.RecordProvenance {
+		return ctx, nil
+	}
+	ctx, versions := provider.WithVersionRecorder(ctx)
+	return ctx, &provenanceRecorder{
+		storeRef: externalSecret.Spec.SecretStoreRef,
+		versions: versions,
+		keys:     make(map[string]esv1alpha1.ExternalSecretKeyProvenance),
+	}
+}
+
+// addRemote records that the value of secretKey was read from the property of the ref.
+func (p *provenanceRecorder) addRemote(secretKey string, ref esv1alpha1.ExternalSecretDataRemoteRef, property string) {
+	if p == nil {
+		return
+	}
+	var storeRef *esv1alpha1.SecretStoreRef
+	if p.storeRef != nil {
+		storeRef = p.storeRef.DeepCopy()
+	}
+	p.keys[secretKey] = esv1alpha1.ExternalSecretKeyProvenance{
+		SecretKey: secretKey,
+		RemoteKey: ref.Key,
+		Property:  property,
+		Version:   ref.Version,
+		VersionID: p.versions.Version(ref.Key, ref.Version),
+		StoreRef:  storeRef,
+	}
+}
+
+// addGenerator records that the value of secretKey was produced by the generator.
+func (p *provenanceRecorder) addGenerator(secretKey string, ref esv1alpha1.GeneratorRef) {
+	if p == nil {
+		return
+	}
+	p.keys[secretKey] = esv1alpha1.ExternalSecretKeyProvenance{
+		SecretKey:    secretKey,
+		GeneratorRef: ref.DeepCopy(),
+	}
+}
+
+// list returns the provenance of the keys sorted by key.
+func (p *provenanceRecorder) list() []esv1alpha1.ExternalSecretKeyProvenance {
+	if p == nil || len(p.keys) == 0 {
+		return nil
+	}
+	out := make([]esv1alpha1.ExternalSecretKeyProvenance, 0, len(p.keys))
+	for _, prov := range p.keys {
+		out = append(out, prov)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SecretKey < out[j].SecretKey
+	})
+	return out
+}
+
+// mapProperty returns the property of the Provider data a key of a fetched map was read from.
+func mapProperty(ref esv1alpha1.ExternalSecretDataRemoteRef, key string) string {
+	if ref.Property == "" {
+		return key
+	}
+	return ref.Property + "." + key
+}
@@ -0,0 +1,275 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/template"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errTargetAPIVersion  = "invalid apiVersion %q of the target: %w"
+	errTargetNotAllowed  = "target kind %s is not allowed in the controller"
+	errTargetNoManifest  = "target kind %s requires a template manifest"
+	errRenderManifest    = "could not render the target manifest: %w"
+	errApplyTarget       = "could not apply %s %s: %w"
+	errPolicyMergeTarget = "the desired %s %s was not found. creationPolicy=Merge requires that it exists"
+	errTargetManifestObj = "the target manifest must not set %s"
+)
+
+var (
+	secretGroupKind    = schema.GroupKind{Kind: "Secret"}
+	configMapGroupKind = schema.GroupKind{Kind: "ConfigMap"}
+)
+
+// ParseTargetKinds parses the kinds the ExternalSecrets may write to besides Secrets,
+// given as Kind for the core group or Kind.group, e.g. ConfigMap or Application.argoproj.io.
+func ParseTargetKinds(kinds []string) []schema.GroupKind {
+	var out []schema.GroupKind
+	for _, kind := range kinds {
+		kind = strings.TrimSpace(kind)
+		if kind == "" {
+			continue
+		}
+		out = append(out, schema.ParseGroupKind(kind))
+	}
+	return out
+}
+
+// targetGVK returns the group, version and kind of the resource the ExternalSecret writes to.
+func targetGVK(es *esv1alpha1.ExternalSecret) (schema.GroupVersionKind, error) {
+	manifest := es.Spec.Target.Manifest
+	if manifest == nil {
+		return v1.SchemeGroupVersion.WithKind(secretGroupKind.Kind), nil
+	}
+	gv, err := schema.ParseGroupVersion(manifest.APIVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf(errTargetAPIVersion, manifest.APIVersion, err)
+	}
+	return gv.WithKind(manifest.Kind), nil
+}
+
+// isSecretTarget returns true if the ExternalSecret writes to a Secret.
+func isSecretTarget(es *esv1alpha1.ExternalSecret) bool {
+	gvk, err := targetGVK(es)
+	return err == nil && gvk.GroupKind() == secretGroupKind
+}
+
+// hasTargetData returns true if the data of the target can be compared to the data hash,
+// which is the case for Secrets and ConfigMaps but not for rendered manifests.
+func hasTargetData(es *esv1alpha1.ExternalSecret) bool {
+	gvk, err := targetGVK(es)
+	return err == nil && (gvk.GroupKind() == secretGroupKind || gvk.GroupKind() == configMapGroupKind)
+}
+
+// targetKind returns the kind of the target for messages.
+func targetKind(es *esv1alpha1.ExternalSecret) string {
+	gvk, err := targetGVK(es)
+	if err != nil {
+		return secretGroupKind.Kind
+	}
+	return gvk.Kind
+}
+
+// checkTarget returns an error if the controller may not write to the target of the ExternalSecret.
+func (r *Reconciler) checkTarget(es *esv1alpha1.ExternalSecret) error {
+	gvk, err := targetGVK(es)
+	if err != nil {
+		return err
+	}
+	if gvk.GroupKind() == secretGroupKind {
+		return nil
+	}
+	allowed := false
+	for _, kind := range r.TargetKinds {
+		if kind == gvk.GroupKind() {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf(errTargetNotAllowed, gvk.GroupKind())
+	}
+	if gvk.GroupKind() != configMapGroupKind && (es.Spec.Target.Template == nil || es.Spec.Target.Template.Manifest == "") {
+		return fmt.Errorf(errTargetNoManifest, gvk.GroupKind())
+	}
+	return nil
+}
+
+// getTarget returns the existing target of the ExternalSecret as a Secret,
+// so the checks of the Secret apply to every kind of target.
+// Only the metadata of rendered manifests is returned, ConfigMaps include their data.
+func (r *Reconciler) getTarget(ctx context.Context, es *esv1alpha1.ExternalSecret, name string) (v1.Secret, error) {
+	key := types.NamespacedName{Name: name, Namespace: es.Namespace}
+	var existing v1.Secret
+	if isSecretTarget(es) {
+		err := r.Get(ctx, key, &existing)
+		return existing, err
+	}
+	gvk, err := targetGVK(es)
+	if err != nil {
+		return existing, err
+	}
+	if gvk.GroupKind() == configMapGroupKind {
+		var cm v1.ConfigMap
+		if err := r.Get(ctx, key, &cm); err != nil {
+			return existing, err
+		}
+		existing.ObjectMeta = cm.ObjectMeta
+		existing.Data = make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+		for k, v := range cm.Data {
+			existing.Data[k] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			existing.Data[k] = v
+		}
+		return existing, nil
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, key, obj); err != nil {
+		return existing, err
+	}
+	existing.Name = obj.GetName()
+	existing.Namespace = obj.GetNamespace()
+	existing.UID = obj.GetUID()
+	existing.ResourceVersion = obj.GetResourceVersion()
+	existing.Labels = obj.GetLabels()
+	existing.Annotations = obj.GetAnnotations()
+	existing.OwnerReferences = obj.GetOwnerReferences()
+	return existing, nil
+}
+
+// isTargetValid checks if the target exists and, if its data can be compared, is consistent with the hash.
+func isTargetValid(es *esv1alpha1.ExternalSecret, existing v1.Secret) bool {
+	if hasTargetData(es) {
+		return isSecretValid(existing)
+	}
+	return existing.UID != ""
+}
+
+// renderTarget converts the Secret built by the sync into the target resource.
+// ConfigMaps get its data, other kinds are rendered from the template manifest with it.
+// The name, namespace, labels, annotations and owner of the Secret are set on the target.
+func renderTarget(es *esv1alpha1.ExternalSecret, secret *v1.Secret) (*unstructured.Unstructured, error) {
+	gvk, err := targetGVK(es)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: make(map[string]interface{})}
+	if gvk.GroupKind() == configMapGroupKind {
+		data := make(map[string]interface{})
+		binaryData := make(map[string]interface{})
+		for k, v := range secret.Data {
+			if utf8.Valid(v) {
+				data[k] = string(v)
+			} else {
+				binaryData[k] = base64.StdEncoding.EncodeToString(v)
+			}
+		}
+		obj.Object["data"] = data
+		if len(binaryData) > 0 {
+			obj.Object["binaryData"] = binaryData
+		}
+		if es.Spec.Target.Immutable {
+			obj.Object["immutable"] = true
+		}
+	} else {
+		obj.Object, err = template.ExecuteManifest(es.Spec.Target.Template.Manifest, secret.Data)
+		if err != nil {
+			return nil, fmt.Errorf(errRenderManifest, err)
+		}
+		// the identity of the target is defined by the ExternalSecret
+		for _, field := range []string{"apiVersion", "kind", "status"} {
+			if _, ok := obj.Object[field]; ok {
+				return nil, fmt.Errorf(errTargetManifestObj, field)
+			}
+		}
+	}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(secret.Name)
+	obj.SetNamespace(secret.Namespace)
+	obj.SetLabels(mergedMap(obj.GetLabels(), secret.Labels))
+	obj.SetAnnotations(mergedMap(obj.GetAnnotations(), secret.Annotations))
+	obj.SetOwnerReferences(secret.OwnerReferences)
+	return obj, nil
+}
+
+func mergedMap(dst, src map[string]string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string, len(src))
+	}
+	utils.MergeStringMap(dst, src)
+	return dst
+}
+
+// applyTarget builds the target with the mutation func and applies it with server-side apply.
+// Targets owned by the ExternalSecret take over the fields set by others, merged targets
+// return a conflict like merged Secrets do.
+func applyTarget(ctx context.Context, c client.Client, es *esv1alpha1.ExternalSecret, secret *v1.Secret, existing *v1.Secret, mutationFunc func() error) (controllerutil.OperationResult, error) {
+	kind := targetKind(es)
+	if es.Spec.Target.CreationPolicy == esv1alpha1.Merge && existing.UID == "" {
+		return controllerutil.OperationResultNone, fmt.Errorf(errPolicyMergeTarget, kind, secret.Name)
+	}
+	if err := mutationFunc(); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	obj, err := renderTarget(es, secret)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if es.Spec.Target.CreationPolicy != esv1alpha1.Merge {
+		opts = append(opts, client.ForceOwnership)
+	}
+	err = c.Patch(ctx, obj, client.Apply, opts...)
+	if err != nil {
+		return controllerutil.OperationResultNone, fmt.Errorf(errApplyTarget, kind, secret.Name, err)
+	}
+	switch {
+	case existing.UID == "":
+		return controllerutil.OperationResultCreated, nil
+	case obj.GetResourceVersion() == existing.ResourceVersion:
+		return controllerutil.OperationResultNone, nil
+	}
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// targetObject returns the object to delete the target of the ExternalSecret with.
+func targetObject(es *esv1alpha1.ExternalSecret, secret *v1.Secret) client.Object {
+	gvk, err := targetGVK(es)
+	if err != nil || gvk.GroupKind() == secretGroupKind {
+		return secret
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(secret.Name)
+	obj.SetNamespace(secret.Namespace)
+	return obj
+}
@@ -0,0 +1,88 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/external-secrets/external-secrets/pkg/tracing"
+)
+
+const (
+	spanReconcile = "ExternalSecret.Reconcile"
+	spanGetStore  = "ExternalSecret.GetStore"
+	spanSecret    = "Secret."
+)
+
+// startReconcile starts the span of the reconcile of the ExternalSecret,
+// the spans of the store lookup, the provider calls and the Secret write are its children.
+func startReconcile(ctx context.Context, key client.ObjectKey) (context.Context, trace.Span) {
+	return tracing.Start(ctx, spanReconcile,
+		attribute.String("externalsecret.name", key.Name),
+		attribute.String("externalsecret.namespace", key.Namespace))
+}
+
+// secretWriter traces the writes of the target Secret or resource.
+type secretWriter struct {
+	client.Client
+}
+
+// Create implements client.Writer.
+func (w secretWriter) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := startWrite(ctx, "Create", obj)
+	err := w.Client.Create(ctx, obj, opts...)
+	tracing.End(span, err)
+	return err
+}
+
+// Update implements client.Writer.
+func (w secretWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := startWrite(ctx, "Update", obj)
+	err := w.Client.Update(ctx, obj, opts...)
+	tracing.End(span, err)
+	return err
+}
+
+// Patch implements client.Writer.
+func (w secretWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := startWrite(ctx, "Patch", obj)
+	err := w.Client.Patch(ctx, obj, patch, opts...)
+	tracing.End(span, err)
+	return err
+}
+
+// Delete implements client.Writer.
+func (w secretWriter) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := startWrite(ctx, "Delete", obj)
+	err := w.Client.Delete(ctx, obj, opts...)
+	tracing.End(span, err)
+	return err
+}
+
+func startWrite(ctx context.Context, operation string, obj client.Object) (context.Context, trace.Span) {
+	name := spanSecret + operation
+	// targets other than Secrets are written as unstructured objects
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		name = u.GetKind() + "." + operation
+	}
+	return tracing.Start(ctx, name,
+		attribute.String("secret.name", obj.GetName()),
+		attribute.String("secret.namespace", obj.GetNamespace()))
+}
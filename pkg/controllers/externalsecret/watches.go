@@ -0,0 +1,304 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalsecret
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+// Field indexes that map the stores, Secrets and ConfigMaps back to the
+// ExternalSecrets that depend on them.
+const (
+	// indexStoreRef indexes ExternalSecrets by the kind and name of their store.
+	indexStoreRef = "spec.secretStoreRef"
+	// indexTemplateSecret and indexTemplateConfigMap index ExternalSecrets
+	// by the names of the Secrets and ConfigMaps of their templateFrom.
+	indexTemplateSecret    = "spec.target.template.templateFrom.secret"
+	indexTemplateConfigMap = "spec.target.template.templateFrom.configMap"
+	// indexStoreSecret and indexStoreConfigMap index stores by the Secrets and
+	// ConfigMaps referenced by their provider, see storeRefIndexValues.
+	indexStoreSecret    = "spec.provider.secretRef"
+	indexStoreConfigMap = "spec.provider.configMapRef"
+	// indexTargetSecret and indexTargetConfigMap index ExternalSecrets by the name of
+	// their target, the owner reference of an orphaned target doesn't map it back.
+	indexTargetSecret    = "spec.target.name.secret"
+	indexTargetConfigMap = "spec.target.name.configMap"
+
+	errListExternalSecrets = "could not list ExternalSecrets for %s"
+)
+
+// setupIndexes adds the field indexes used to map the watched objects to ExternalSecrets.
+func setupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	err := indexer.IndexField(ctx, &esv1alpha1.ExternalSecret{}, indexStoreRef, func(obj client.Object) []string {
+		es := obj.(*esv1alpha1.ExternalSecret)
+		if es.Spec.SecretStoreRef == nil {
+			return nil
+		}
+		return []string{storeRefIndexValue(storeKind(es.Spec.SecretStoreRef), es.Spec.SecretStoreRef.Name)}
+	})
+	if err != nil {
+		return err
+	}
+	err = indexer.IndexField(ctx, &esv1alpha1.ExternalSecret{}, indexTemplateSecret, func(obj client.Object) []string {
+		return templateFromNames(obj.(*esv1alpha1.ExternalSecret), utils.KindSecret)
+	})
+	if err != nil {
+		return err
+	}
+	err = indexer.IndexField(ctx, &esv1alpha1.ExternalSecret{}, indexTemplateConfigMap, func(obj client.Object) []string {
+		return templateFromNames(obj.(*esv1alpha1.ExternalSecret), utils.KindConfigMap)
+	})
+	if err != nil {
+		return err
+	}
+	err = indexer.IndexField(ctx, &esv1alpha1.ExternalSecret{}, indexTargetSecret, func(obj client.Object) []string {
+		return driftTargetNames(obj.(*esv1alpha1.ExternalSecret), secretGroupKind)
+	})
+	if err != nil {
+		return err
+	}
+	err = indexer.IndexField(ctx, &esv1alpha1.ExternalSecret{}, indexTargetConfigMap, func(obj client.Object) []string {
+		return driftTargetNames(obj.(*esv1alpha1.ExternalSecret), configMapGroupKind)
+	})
+	if err != nil {
+		return err
+	}
+	for _, store := range []client.Object{&esv1alpha1.SecretStore{}, &esv1alpha1.ClusterSecretStore{}} {
+		err = indexer.IndexField(ctx, store, indexStoreSecret, func(obj client.Object) []string {
+			return storeRefIndexValues(obj.(esv1alpha1.GenericStore), utils.KindSecret)
+		})
+		if err != nil {
+			return err
+		}
+		err = indexer.IndexField(ctx, store, indexStoreConfigMap, func(obj client.Object) []string {
+			return storeRefIndexValues(obj.(esv1alpha1.GenericStore), utils.KindConfigMap)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func storeKind(ref *esv1alpha1.SecretStoreRef) string {
+	if ref.Kind == esv1alpha1.ClusterSecretStoreKind {
+		return esv1alpha1.ClusterSecretStoreKind
+	}
+	return esv1alpha1.SecretStoreKind
+}
+
+func storeRefIndexValue(kind, name string) string {
+	return kind + "/" + name
+}
+
+// templateFromNames returns the names of the Secrets or ConfigMaps of the templateFrom of the ExternalSecret.
+func templateFromNames(es *esv1alpha1.ExternalSecret, kind string) []string {
+	if es.Spec.Target.Template == nil {
+		return nil
+	}
+	var names []string
+	for _, tpl := range es.Spec.Target.Template.TemplateFrom {
+		switch {
+		case kind == utils.KindSecret && tpl.Secret != nil:
+			names = append(names, tpl.Secret.Name)
+		case kind == utils.KindConfigMap && tpl.ConfigMap != nil:
+			names = append(names, tpl.ConfigMap.Name)
+		}
+	}
+	return names
+}
+
+// driftTargetNames returns the name of the target of the ExternalSecret if it is of
+// the kind and checked for drift, merged targets are changed by other writers.
+func driftTargetNames(es *esv1alpha1.ExternalSecret, kind schema.GroupKind) []string {
+	gvk, err := targetGVK(es)
+	if err != nil || gvk.GroupKind() != kind {
+		return nil
+	}
+	if es.Spec.Target.CreationPolicy == esv1alpha1.Merge || es.Spec.Target.CreationPolicy == esv1alpha1.None {
+		return nil
+	}
+	return []string{targetName(es)}
+}
+
+// storeRefIndexValues returns the index values of the Secrets or ConfigMaps referenced by the store.
+// References of a SecretStore are always in its namespace and indexed by name.
+// References of a ClusterSecretStore are indexed as namespace/name if they name a namespace,
+// otherwise by name as they point to the namespace of the ExternalSecret.
+func storeRefIndexValues(store esv1alpha1.GenericStore, kind string) []string {
+	_, cluster := store.(*esv1alpha1.ClusterSecretStore)
+	var values []string
+	for _, ref := range utils.StoreRefs(store) {
+		if ref.Kind != kind {
+			continue
+		}
+		if cluster && ref.Namespace != nil {
+			values = append(values, *ref.Namespace+"/"+ref.Name)
+			continue
+		}
+		values = append(values, ref.Name)
+	}
+	return values
+}
+
+// findExternalSecretsForStore returns a request for every ExternalSecret that uses the store.
+func (r *Reconciler) findExternalSecretsForStore(obj client.Object) []reconcile.Request {
+	kind := esv1alpha1.SecretStoreKind
+	if _, ok := obj.(*esv1alpha1.ClusterSecretStore); ok {
+		kind = esv1alpha1.ClusterSecretStoreKind
+	}
+	requests := make(map[types.NamespacedName]bool)
+	r.addExternalSecretsForStore(requests, kind, obj.GetName(), obj.GetNamespace())
+	return toRequests(requests)
+}
+
+// findExternalSecretsForSecret returns a request for every ExternalSecret that uses
+// the Secret in its templateFrom, whose store references it or that writes to it.
+func (r *Reconciler) findExternalSecretsForSecret(obj client.Object) []reconcile.Request {
+	return r.findExternalSecretsForObject(obj, indexTemplateSecret, indexStoreSecret, indexTargetSecret)
+}
+
+// findExternalSecretsForConfigMap returns a request for every ExternalSecret that uses
+// the ConfigMap in its templateFrom, whose store references it or that writes to it.
+func (r *Reconciler) findExternalSecretsForConfigMap(obj client.Object) []reconcile.Request {
+	return r.findExternalSecretsForObject(obj, indexTemplateConfigMap, indexStoreConfigMap, indexTargetConfigMap)
+}
+
+func (r *Reconciler) findExternalSecretsForObject(obj client.Object, templateIndex, storeIndex, targetIndex string) []reconcile.Request {
+	ctx := context.Background()
+	key := client.ObjectKeyFromObject(obj)
+	requests := make(map[types.NamespacedName]bool)
+	r.addExternalSecrets(requests, key, client.InNamespace(key.Namespace), client.MatchingFields{templateIndex: key.Name})
+	r.addExternalSecrets(requests, key, client.InNamespace(key.Namespace), client.MatchingFields{targetIndex: key.Name})
+
+	var stores esv1alpha1.SecretStoreList
+	if err := r.indexReader.List(ctx, &stores, client.InNamespace(key.Namespace), client.MatchingFields{storeIndex: key.Name}); err != nil {
+		r.Log.Error(err, "could not list SecretStores", "object", key)
+	}
+	for i := range stores.Items {
+		r.addExternalSecretsForStore(requests, esv1alpha1.SecretStoreKind, stores.Items[i].Name, key.Namespace)
+	}
+
+	// a ClusterSecretStore that names the namespace of the reference is used by
+	// ExternalSecrets in any namespace, otherwise only by those in the namespace of obj
+	for _, ref := range []struct {
+		value     string
+		namespace string
+	}{
+		{value: key.Namespace + "/" + key.Name},
+		{value: key.Name, namespace: key.Namespace},
+	} {
+		var clusterStores esv1alpha1.ClusterSecretStoreList
+		if err := r.indexReader.List(ctx, &clusterStores, client.MatchingFields{storeIndex: ref.value}); err != nil {
+			r.Log.Error(err, "could not list ClusterSecretStores", "object", key)
+		}
+		for i := range clusterStores.Items {
+			r.addExternalSecretsForStore(requests, esv1alpha1.ClusterSecretStoreKind, clusterStores.Items[i].Name, ref.namespace)
+		}
+	}
+	return toRequests(requests)
+}
+
+// addExternalSecretsForStore adds the ExternalSecrets in the namespace that use the store,
+// an empty namespace matches all namespaces.
+func (r *Reconciler) addExternalSecretsForStore(requests map[types.NamespacedName]bool, kind, name, namespace string) {
+	r.addExternalSecrets(requests, types.NamespacedName{Name: name, Namespace: namespace},
+		client.InNamespace(namespace), client.MatchingFields{indexStoreRef: storeRefIndexValue(kind, name)})
+}
+
+func (r *Reconciler) addExternalSecrets(requests map[types.NamespacedName]bool, key types.NamespacedName, opts ...client.ListOption) {
+	var list esv1alpha1.ExternalSecretList
+	if err := r.indexReader.List(context.Background(), &list, opts...); err != nil {
+		r.Log.Error(err, fmt.Sprintf(errListExternalSecrets, key))
+		return
+	}
+	for i := range list.Items {
+		requests[client.ObjectKeyFromObject(&list.Items[i])] = true
+	}
+}
+
+func toRequests(keys map[types.NamespacedName]bool) []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(keys))
+	for key := range keys {
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return requests
+}
+
+// getDependencyVersion identifies the versions of the store and of the Secrets and
+// ConfigMaps the ExternalSecret depends on, a change of any of them triggers a refresh.
+// It returns an empty string if the ExternalSecret has no dependencies.
+func (r *Reconciler) getDependencyVersion(ctx context.Context, es *esv1alpha1.ExternalSecret, store esv1alpha1.GenericStore) (string, error) {
+	var versions []string
+	addVersion := func(obj client.Object, kind, name, namespace string) error {
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		// a missing object has no version, creating it is a change as well
+		if apierrors.IsNotFound(err) {
+			obj.SetResourceVersion("")
+		}
+		versions = append(versions, fmt.Sprintf("%s/%s/%s=%s", kind, namespace, name, obj.GetResourceVersion()))
+		return nil
+	}
+
+	if store != nil {
+		// the generation ignores status updates of the store
+		versions = append(versions, fmt.Sprintf("%s/%s=%d", storeKind(es.Spec.SecretStoreRef), store.GetName(), store.GetGeneration()))
+		_, cluster := store.(*esv1alpha1.ClusterSecretStore)
+		for _, ref := range utils.StoreRefs(store) {
+			namespace := es.Namespace
+			if cluster && ref.Namespace != nil {
+				namespace = *ref.Namespace
+			}
+			if err := addVersion(newObject(ref.Kind), ref.Kind, ref.Name, namespace); err != nil {
+				return "", err
+			}
+		}
+	}
+	for _, kind := range []string{utils.KindSecret, utils.KindConfigMap} {
+		for _, name := range templateFromNames(es, kind) {
+			if err := addVersion(newObject(kind), kind, name, es.Namespace); err != nil {
+				return "", err
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return "", nil
+	}
+	sort.Strings(versions)
+	return utils.ObjectHash(strings.Join(versions, ",")), nil
+}
+
+func newObject(kind string) client.Object {
+	if kind == utils.KindConfigMap {
+		return &v1.ConfigMap{}
+	}
+	return &v1.Secret{}
+}
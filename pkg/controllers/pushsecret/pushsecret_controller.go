@@ -0,0 +1,310 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+
+	// Loading registered providers.
+	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+)
+
+const (
+	requeueAfter = time.Second * 30
+
+	// indexSourceSecretName indexes PushSecrets by the name of their source Secret.
+	indexSourceSecretName = ".spec.selector.secret.name"
+
+	errGetPS                 = "could not get PushSecret"
+	errPatchStatus           = "unable to patch status"
+	errGetSecretStore        = "could not get SecretStore %q, %w"
+	errGetClusterSecretStore = "could not get ClusterSecretStore %q, %w"
+	errStoreRef              = "could not get store reference"
+	errStoreProvider         = "could not get store provider"
+	errStoreClient           = "could not get provider client"
+	errCloseStoreClient      = "could not close provider client"
+	errGetSourceSecret       = "could not get source secret %q: %w"
+	errPushNotSupported      = "provider of store %q does not support pushing secrets"
+	errMissingSourceKey      = "key %q not found in source secret %q"
+	errPushSecret            = "could not push key %q to %q: %w"
+)
+
+// Reconciler reconciles a PushSecret object.
+type Reconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	ControllerClass string
+	RequeueInterval time.Duration
+}
+
+// Reconcile implements the main reconciliation loop
+// for watched objects (PushSecret and their source Secret),
+// and writes the selected keys of the source Secret to the provider.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("PushSecret", req.NamespacedName)
+
+	var ps esv1alpha1.PushSecret
+	err := r.Get(ctx, req.NamespacedName, &ps)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, errGetPS)
+		return ctrl.Result{}, nil
+	}
+
+	// patch status when done processing
+	p := client.MergeFrom(ps.DeepCopy())
+	defer func() {
+		err = r.Status().Patch(ctx, &ps, p)
+		if err != nil {
+			log.Error(err, errPatchStatus)
+		}
+	}()
+
+	refreshInt := r.RequeueInterval
+	if ps.Spec.RefreshInterval != nil {
+		refreshInt = ps.Spec.RefreshInterval.Duration
+	}
+
+	var sourceSecret v1.Secret
+	err = r.Get(ctx, types.NamespacedName{
+		Name:      ps.Spec.Selector.Secret.Name,
+		Namespace: ps.Namespace,
+	}, &sourceSecret)
+	if err != nil {
+		err = fmt.Errorf(errGetSourceSecret, ps.Spec.Selector.Secret.Name, err)
+		log.Error(err, errGetSourceSecret)
+		r.markAsFailed(&ps, err)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// push should be skipped if
+	// 1. neither the PushSecret nor the source Secret changed
+	// 2. refresh interval is 0 or we're still within refresh-interval
+	if !shouldRefresh(ps, sourceSecret) {
+		log.V(1).Info("skipping push", "rv", getResourceVersion(ps, sourceSecret))
+		if refreshInt == 0 {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{RequeueAfter: refreshInt}, nil
+	}
+
+	store, err := r.getStore(ctx, &ps)
+	if err != nil {
+		log.Error(err, errStoreRef)
+		r.markAsFailed(&ps, err)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	log = log.WithValues("SecretStore", store.GetNamespacedName())
+
+	// check if store should be handled by this controller instance
+	if !shouldProcessStore(store, r.ControllerClass) {
+		log.Info("skipping unmanaged store")
+		return ctrl.Result{}, nil
+	}
+
+	storeProvider, err := schema.GetProvider(store)
+	if err != nil {
+		log.Error(err, errStoreProvider)
+		r.markAsFailed(&ps, err)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	secretClient, err := storeProvider.NewClient(ctx, store, r.Client, req.Namespace)
+	if err != nil {
+		log.Error(err, errStoreClient)
+		r.markAsFailed(&ps, err)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	defer func() {
+		err = secretClient.Close(ctx)
+		if err != nil {
+			log.Error(err, errCloseStoreClient)
+		}
+	}()
+
+	writer, ok := secretClient.(provider.SecretsWriter)
+	if !ok {
+		err = fmt.Errorf(errPushNotSupported, store.GetName())
+		log.Error(err, errPushNotSupported)
+		r.markAsFailed(&ps, err)
+		// retrying does not help until the store changes
+		return ctrl.Result{}, nil
+	}
+
+	err = pushSecretData(ctx, writer, &ps, &sourceSecret)
+	if err != nil {
+		log.Error(err, "could not push secret")
+		r.markAsFailed(&ps, err)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	conditionPushed := NewPushSecretCondition(esv1alpha1.PushSecretReady, v1.ConditionTrue, esv1alpha1.ConditionReasonSecretPushed, "Secret was pushed")
+	currCond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+	SetPushSecretCondition(&ps, *conditionPushed)
+	ps.Status.RefreshTime = metav1.NewTime(time.Now())
+	ps.Status.SyncedResourceVersion = getResourceVersion(ps, sourceSecret)
+	if currCond == nil || currCond.Status != conditionPushed.Status {
+		log.Info("pushed secret") // Log once if on success in any verbosity
+	} else {
+		log.V(1).Info("pushed secret") // Log all reconciliation cycles if higher verbosity applied
+	}
+
+	if refreshInt == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: refreshInt}, nil
+}
+
+func (r *Reconciler) markAsFailed(ps *esv1alpha1.PushSecret, err error) {
+	cond := NewPushSecretCondition(esv1alpha1.PushSecretReady, v1.ConditionFalse, esv1alpha1.ConditionReasonSecretPushedError, err.Error())
+	SetPushSecretCondition(ps, *cond)
+}
+
+// pushSecretData writes every key selected by the PushSecret to the provider.
+func pushSecretData(ctx context.Context, writer provider.SecretsWriter, ps *esv1alpha1.PushSecret, source *v1.Secret) error {
+	for _, data := range ps.Spec.Data {
+		value, ok := source.Data[data.SecretKey]
+		if !ok {
+			return fmt.Errorf(errMissingSourceKey, data.SecretKey, source.Name)
+		}
+		err := writer.PushSecret(ctx, value, data.RemoteRef)
+		if err != nil {
+			return fmt.Errorf(errPushSecret, data.SecretKey, data.RemoteRef.RemoteKey, err)
+		}
+	}
+	return nil
+}
+
+// shouldProcessStore returns true if the store should be processed.
+func shouldProcessStore(store esv1alpha1.GenericStore, class string) bool {
+	if store.GetSpec().Controller == "" || store.GetSpec().Controller == class {
+		return true
+	}
+	return false
+}
+
+// getResourceVersion combines the generation of the PushSecret
+// with the resource version of its source Secret.
+func getResourceVersion(ps esv1alpha1.PushSecret, source v1.Secret) string {
+	return fmt.Sprintf("%d-%s", ps.GetGeneration(), source.GetResourceVersion())
+}
+
+func shouldRefresh(ps esv1alpha1.PushSecret, source v1.Secret) bool {
+	// refresh if the PushSecret or the source Secret changed
+	if ps.Status.SyncedResourceVersion != getResourceVersion(ps, source) {
+		return true
+	}
+	// refresh if the last push failed
+	cond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+	if cond == nil || cond.Status != v1.ConditionTrue {
+		return true
+	}
+
+	// skip refresh if refresh interval is 0
+	if ps.Spec.RefreshInterval == nil || ps.Spec.RefreshInterval.Duration == 0 {
+		return false
+	}
+	if ps.Status.RefreshTime.IsZero() {
+		return true
+	}
+	return !ps.Status.RefreshTime.Add(ps.Spec.RefreshInterval.Duration).After(time.Now())
+}
+
+// getStore returns the store referenced by the PushSecret.
+func (r *Reconciler) getStore(ctx context.Context, ps *esv1alpha1.PushSecret) (esv1alpha1.GenericStore, error) {
+	ref := types.NamespacedName{
+		Name: ps.Spec.SecretStoreRef.Name,
+	}
+
+	if ps.Spec.SecretStoreRef.Kind == esv1alpha1.ClusterSecretStoreKind {
+		var store esv1alpha1.ClusterSecretStore
+		err := r.Get(ctx, ref, &store)
+		if err != nil {
+			return nil, fmt.Errorf(errGetClusterSecretStore, ref.Name, err)
+		}
+
+		return &store, nil
+	}
+
+	ref.Namespace = ps.Namespace
+
+	var store esv1alpha1.SecretStore
+	err := r.Get(ctx, ref, &store)
+	if err != nil {
+		return nil, fmt.Errorf(errGetSecretStore, ref.Name, err)
+	}
+	return &store, nil
+}
+
+// findPushSecretsForSecret returns a request for every PushSecret
+// that uses the given Secret as source.
+func (r *Reconciler) findPushSecretsForSecret(obj client.Object) []reconcile.Request {
+	var list esv1alpha1.PushSecretList
+	err := r.List(context.Background(), &list,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{indexSourceSecretName: obj.GetName()})
+	if err != nil {
+		r.Log.Error(err, "could not list PushSecrets for secret", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+		})
+	}
+	return requests
+}
+
+// SetupWithManager returns a new controller builder that will be started by the provided Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &esv1alpha1.PushSecret{}, indexSourceSecretName, func(obj client.Object) []string {
+		ps, ok := obj.(*esv1alpha1.PushSecret)
+		if !ok || ps.Spec.Selector.Secret.Name == "" {
+			return nil
+		}
+		return []string{ps.Spec.Selector.Secret.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&esv1alpha1.PushSecret{}).
+		Watches(&source.Kind{Type: &v1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.findPushSecretsForSecret)).
+		Complete(r)
+}
@@ -0,0 +1,321 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pushsecret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+)
+
+var (
+	fakeProvider *fake.Client
+	timeout      = time.Second * 10
+	interval     = time.Millisecond * 250
+)
+
+// pushed records the values written to the fake provider by remote key.
+type pushed struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (p *pushed) record(_ context.Context, value []byte, ref esv1alpha1.PushSecretRemoteRef) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.data[ref.RemoteKey] = string(value)
+	return nil
+}
+
+func (p *pushed) get(key string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.data[key]
+}
+
+type testCase struct {
+	secretStore  *esv1alpha1.SecretStore
+	sourceSecret *v1.Secret
+	pushSecret   *esv1alpha1.PushSecret
+
+	// checkCondition should return true if the PushSecret
+	// has the expected condition
+	checkCondition func(*esv1alpha1.PushSecret) bool
+
+	// checkPushSecret is called after the condition has been verified
+	// use this to verify the PushSecret and the pushed data
+	checkPushSecret func(*esv1alpha1.PushSecret)
+}
+
+type testTweaks func(*testCase)
+
+var _ = Describe("PushSecret controller", func() {
+	const (
+		PushSecretName   = "test-ps"
+		PushSecretStore  = "test-store"
+		SourceSecretName = "test-source"
+		secretKey        = "password"
+		secretValue      = "s3cr3t"
+		remoteKey        = "db/password"
+	)
+
+	var (
+		PushSecretNamespace string
+		store               *pushed
+	)
+
+	BeforeEach(func() {
+		var err error
+		PushSecretNamespace, err = CreateNamespace("test-ns", k8sClient)
+		Expect(err).ToNot(HaveOccurred())
+		store = &pushed{data: make(map[string]string)}
+		fakeProvider.PushSecretFn = store.record
+		fakeProvider.WithNew(func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+			return fakeProvider, nil
+		})
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: PushSecretNamespace,
+			},
+		}, client.PropagationPolicy(metav1.DeletePropagationBackground)), client.GracePeriodSeconds(0)).To(Succeed())
+	})
+
+	makeDefaultTestcase := func() *testCase {
+		return &testCase{
+			// default condition: ps should be ready
+			checkCondition: func(ps *esv1alpha1.PushSecret) bool {
+				cond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+				if cond == nil || cond.Status != v1.ConditionTrue {
+					return false
+				}
+				return true
+			},
+			checkPushSecret: func(ps *esv1alpha1.PushSecret) {},
+			secretStore: &esv1alpha1.SecretStore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      PushSecretStore,
+					Namespace: PushSecretNamespace,
+				},
+				Spec: esv1alpha1.SecretStoreSpec{
+					Provider: &esv1alpha1.SecretStoreProvider{
+						AWS: &esv1alpha1.AWSProvider{
+							Service: esv1alpha1.AWSServiceSecretsManager,
+						},
+					},
+				},
+			},
+			sourceSecret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      SourceSecretName,
+					Namespace: PushSecretNamespace,
+				},
+				Data: map[string][]byte{
+					secretKey: []byte(secretValue),
+				},
+			},
+			pushSecret: &esv1alpha1.PushSecret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      PushSecretName,
+					Namespace: PushSecretNamespace,
+				},
+				Spec: esv1alpha1.PushSecretSpec{
+					SecretStoreRef: esv1alpha1.SecretStoreRef{
+						Name: PushSecretStore,
+					},
+					Selector: esv1alpha1.PushSecretSelector{
+						Secret: esv1alpha1.PushSecretSecretRef{
+							Name: SourceSecretName,
+						},
+					},
+					Data: []esv1alpha1.PushSecretData{
+						{
+							SecretKey: secretKey,
+							RemoteRef: esv1alpha1.PushSecretRemoteRef{
+								RemoteKey: remoteKey,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	hasReadyReason := func(reason string) func(*esv1alpha1.PushSecret) bool {
+		return func(ps *esv1alpha1.PushSecret) bool {
+			cond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+			if cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != reason {
+				return false
+			}
+			return true
+		}
+	}
+
+	// the selected keys of the source secret are pushed to the provider
+	pushKeys := func(tc *testCase) {
+		tc.checkPushSecret = func(ps *esv1alpha1.PushSecret) {
+			Expect(store.get(remoteKey)).To(Equal(secretValue))
+			Expect(ps.Status.SyncedResourceVersion).ToNot(BeEmpty())
+			Expect(ps.Status.RefreshTime.IsZero()).To(BeFalse())
+		}
+	}
+
+	// a change of the source secret is pushed again
+	pushOnSourceChange := func(tc *testCase) {
+		const newValue = "n3w-s3cr3t"
+		tc.checkPushSecret = func(ps *esv1alpha1.PushSecret) {
+			Expect(store.get(remoteKey)).To(Equal(secretValue))
+
+			var source v1.Secret
+			Expect(k8sClient.Get(context.Background(), types.NamespacedName{
+				Name:      SourceSecretName,
+				Namespace: PushSecretNamespace,
+			}, &source)).To(Succeed())
+			source.Data[secretKey] = []byte(newValue)
+			Expect(k8sClient.Update(context.Background(), &source)).To(Succeed())
+
+			Eventually(func() string {
+				return store.get(remoteKey)
+			}, timeout, interval).Should(Equal(newValue))
+		}
+	}
+
+	// a missing source secret is reported as error
+	missingSourceSecret := func(tc *testCase) {
+		tc.sourceSecret = nil
+		tc.checkCondition = hasReadyReason(esv1alpha1.ConditionReasonSecretPushedError)
+		tc.checkPushSecret = func(ps *esv1alpha1.PushSecret) {
+			Expect(store.get(remoteKey)).To(BeEmpty())
+		}
+	}
+
+	// a key that is not part of the source secret is reported as error
+	missingSourceKey := func(tc *testCase) {
+		tc.pushSecret.Spec.Data[0].SecretKey = "does-not-exist"
+		tc.checkCondition = hasReadyReason(esv1alpha1.ConditionReasonSecretPushedError)
+		tc.checkPushSecret = func(ps *esv1alpha1.PushSecret) {
+			cond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+			Expect(cond.Message).To(Equal(fmt.Sprintf(errMissingSourceKey, "does-not-exist", SourceSecretName)))
+		}
+	}
+
+	// a provider error is reported as error
+	providerError := func(tc *testCase) {
+		fakeProvider.WithPushSecret(errors.New("boom"))
+		tc.checkCondition = hasReadyReason(esv1alpha1.ConditionReasonSecretPushedError)
+		tc.checkPushSecret = func(ps *esv1alpha1.PushSecret) {
+			cond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+			Expect(cond.Message).To(ContainSubstring("boom"))
+		}
+	}
+
+	// a provider that can not write secrets is reported as error
+	pushNotSupported := func(tc *testCase) {
+		fakeProvider.WithNew(func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+			// only expose the read methods of the fake provider
+			return struct{ provider.SecretsClient }{fakeProvider}, nil
+		})
+		tc.checkCondition = hasReadyReason(esv1alpha1.ConditionReasonSecretPushedError)
+		tc.checkPushSecret = func(ps *esv1alpha1.PushSecret) {
+			cond := GetPushSecretCondition(ps.Status, esv1alpha1.PushSecretReady)
+			Expect(cond.Message).To(Equal(fmt.Sprintf(errPushNotSupported, PushSecretStore)))
+		}
+	}
+
+	DescribeTable("When reconciling a PushSecret",
+		func(tweaks ...testTweaks) {
+			tc := makeDefaultTestcase()
+			for _, tweak := range tweaks {
+				tweak(tc)
+			}
+			ctx := context.Background()
+			By("creating a secret store, source secret and push secret")
+			if tc.secretStore != nil {
+				Expect(k8sClient.Create(ctx, tc.secretStore)).To(Succeed())
+			}
+			if tc.sourceSecret != nil {
+				Expect(k8sClient.Create(ctx, tc.sourceSecret)).To(Succeed())
+			}
+			Expect(k8sClient.Create(ctx, tc.pushSecret)).Should(Succeed())
+
+			psKey := types.NamespacedName{Name: PushSecretName, Namespace: PushSecretNamespace}
+			createdPS := &esv1alpha1.PushSecret{}
+			By("checking the ps condition")
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, psKey, createdPS)
+				if err != nil {
+					return false
+				}
+				return tc.checkCondition(createdPS)
+			}, timeout, interval).Should(BeTrue())
+			tc.checkPushSecret(createdPS)
+		},
+		Entry("should push the selected keys", pushKeys),
+		Entry("should push again if the source secret changes", pushOnSourceChange),
+		Entry("should error if the source secret does not exist", missingSourceSecret),
+		Entry("should error if a key is missing in the source secret", missingSourceKey),
+		Entry("should error if the provider fails", providerError),
+		Entry("should error if the provider does not support pushing", pushNotSupported),
+	)
+})
+
+// CreateNamespace creates a new namespace in the cluster.
+func CreateNamespace(baseName string, c client.Client) (string, error) {
+	genName := fmt.Sprintf("ctrl-test-%v", baseName)
+	ns := &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: genName,
+		},
+	}
+	var err error
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		err = c.Create(context.Background(), ns)
+		if err != nil {
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return ns.Name, nil
+}
+
+func init() {
+	fakeProvider = fake.New()
+	schema.ForceRegister(fakeProvider, &esv1alpha1.SecretStoreProvider{
+		AWS: &esv1alpha1.AWSProvider{
+			Service: esv1alpha1.AWSServiceSecretsManager,
+		},
+	})
+}
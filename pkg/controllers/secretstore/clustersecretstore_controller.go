@@ -0,0 +1,66 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+const (
+	errGetClusterSecretStore = "unable to get ClusterSecretStore"
+)
+
+// ClusterStoreReconciler reconciles a ClusterSecretStore object.
+type ClusterStoreReconciler struct {
+	client.Client
+	Log             logr.Logger
+	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
+	ControllerClass string
+	RequeueInterval time.Duration
+}
+
+// Reconcile validates the ClusterSecretStore and updates its Ready condition.
+func (r *ClusterStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("clustersecretstore", req.NamespacedName)
+
+	var css esv1alpha1.ClusterSecretStore
+	err := r.Get(ctx, req.NamespacedName, &css)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, errGetClusterSecretStore)
+		return ctrl.Result{}, err
+	}
+
+	return reconcile(ctx, req, &css, r.Client, r.Recorder, log, r.ControllerClass, r.RequeueInterval)
+}
+
+// SetupWithManager returns a new controller builder that will be started by the provided Manager.
+func (r *ClusterStoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&esv1alpha1.ClusterSecretStore{}).
+		Complete(r)
+}
@@ -0,0 +1,153 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/events"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+
+	// Loading registered providers.
+	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	defaultRequeueInterval = time.Minute * 5
+
+	errStoreProvider    = "could not get store provider: %w"
+	errStoreClient      = "could not get provider client: %w"
+	errValidationFailed = "could not validate provider: %w"
+	errPatchStatus      = "unable to patch status: %w"
+	errCloseStoreClient = "could not close provider client"
+
+	msgStoreValidated = "store validated"
+	msgStoreUnknown   = "store configured, the connection to the provider could not be checked"
+
+	msgSkipControllerClass = "Skipped, the store is managed by controller class %q"
+)
+
+// reconcile validates the given store and reports the result in the Ready condition.
+// It is shared by the SecretStore and ClusterSecretStore reconcilers.
+func reconcile(ctx context.Context, req ctrl.Request, ss esv1alpha1.GenericStore, cl client.Client, recorder record.EventRecorder,
+	log logr.Logger, controllerClass string, requeueInterval time.Duration) (result ctrl.Result, err error) {
+	if !shouldProcessStore(ss, controllerClass) {
+		log.V(1).Info("skip store")
+		recorder.Eventf(ss, v1.EventTypeNormal, events.ReasonSkipped, msgSkipControllerClass, ss.GetSpec().Controller)
+		return ctrl.Result{}, nil
+	}
+
+	if requeueInterval == 0 {
+		requeueInterval = defaultRequeueInterval
+	}
+
+	p := client.MergeFrom(ss.Copy())
+	defer func() {
+		patchErr := cl.Status().Patch(ctx, ss, p)
+		if patchErr != nil && err == nil {
+			err = fmt.Errorf(errPatchStatus, patchErr)
+		}
+	}()
+
+	reason, validateErr := validateStore(ctx, req.Namespace, ss, cl)
+	if validateErr != nil {
+		log.Error(validateErr, "unable to validate store")
+		recorder.Event(ss, v1.EventTypeWarning, reason, validateErr.Error())
+		cond := NewSecretStoreCondition(esv1alpha1.SecretStoreReady, v1.ConditionFalse, reason, validateErr.Error())
+		SetSecretStoreCondition(ss, *cond)
+		return ctrl.Result{RequeueAfter: requeueInterval}, nil
+	}
+
+	msg := msgStoreValidated
+	if reason == "" {
+		msg = msgStoreUnknown
+	}
+	// the store is validated on every requeue, only report when it becomes ready
+	if prev := GetSecretStoreCondition(ss.GetStatus(), esv1alpha1.SecretStoreReady); prev == nil || prev.Status != v1.ConditionTrue {
+		recorder.Event(ss, v1.EventTypeNormal, esv1alpha1.ReasonStoreValid, msg)
+	}
+	cond := NewSecretStoreCondition(esv1alpha1.SecretStoreReady, v1.ConditionTrue, esv1alpha1.ReasonStoreValid, msg)
+	SetSecretStoreCondition(ss, *cond)
+
+	return ctrl.Result{RequeueAfter: requeueInterval}, nil
+}
+
+// validateStore constructs a provider client for the store and checks connectivity.
+// On failure it returns the condition reason along with the error.
+// An empty reason without error means the provider could not verify connectivity.
+func validateStore(ctx context.Context, namespace string, store esv1alpha1.GenericStore, cl client.Client) (string, error) {
+	if errs := schema.ValidateStore(store); len(errs) > 0 {
+		return esv1alpha1.ReasonInvalidStore, errs.ToAggregate()
+	}
+
+	storeProvider, err := schema.GetProvider(store)
+	if err != nil {
+		return esv1alpha1.ReasonInvalidStore, fmt.Errorf(errStoreProvider, err)
+	}
+	// refs without a namespace are resolved in the namespace of the ExternalSecret,
+	// there is no client that could be checked for the ClusterSecretStore itself.
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok && hasRefWithoutNamespace(store) {
+		return "", nil
+	}
+
+	secretClient, err := storeProvider.NewClient(ctx, store, cl, namespace)
+	if err != nil {
+		return esv1alpha1.ReasonInvalidProviderConfig, fmt.Errorf(errStoreClient, err)
+	}
+	defer func() {
+		if err := secretClient.Close(ctx); err != nil {
+			ctrl.Log.Error(err, errCloseStoreClient)
+		}
+	}()
+
+	res, err := secretClient.Validate(ctx)
+	if err != nil {
+		return esv1alpha1.ReasonValidationFailed, fmt.Errorf(errValidationFailed, err)
+	}
+	if res == provider.ValidationResultUnknown {
+		return "", nil
+	}
+	return esv1alpha1.ReasonStoreValid, nil
+}
+
+// shouldProcessStore returns true if the store should be processed by this controller class.
+func shouldProcessStore(store esv1alpha1.GenericStore, class string) bool {
+	if store.GetSpec().Controller == "" || store.GetSpec().Controller == class {
+		return true
+	}
+	return false
+}
+
+// hasRefWithoutNamespace returns true if one of the secret or configmap
+// references of the store does not name a namespace.
+func hasRefWithoutNamespace(store esv1alpha1.GenericStore) bool {
+	for _, ref := range utils.StoreRefs(store) {
+		if ref.Namespace == nil {
+			return true
+		}
+	}
+	return false
+}
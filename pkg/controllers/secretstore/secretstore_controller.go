@@ -16,30 +16,46 @@ package secretstore
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 )
 
+const (
+	errGetSecretStore = "unable to get SecretStore"
+)
+
 // Reconciler reconciles a SecretStore object.
 type Reconciler struct {
 	client.Client
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
 	ControllerClass string
+	RequeueInterval time.Duration
 }
 
+// Reconcile validates the SecretStore and updates its Ready condition.
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = context.Background()
-	_ = r.Log.WithValues("secretstore", req.NamespacedName)
+	log := r.Log.WithValues("secretstore", req.NamespacedName)
 
-	// your logic here
+	var ss esv1alpha1.SecretStore
+	err := r.Get(ctx, req.NamespacedName, &ss)
+	if apierrors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		log.Error(err, errGetSecretStore)
+		return ctrl.Result{}, err
+	}
 
-	return ctrl.Result{}, nil
+	return reconcile(ctx, req, &ss, r.Client, r.Recorder, log, r.ControllerClass, r.RequeueInterval)
 }
 
 // SetupWithManager returns a new controller builder that will be started by the provided Manager.
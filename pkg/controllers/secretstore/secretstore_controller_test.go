@@ -0,0 +1,280 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+)
+
+const (
+	defaultControllerClass = "default"
+)
+
+var (
+	fakeProvider *fake.Client
+	timeout      = time.Second * 10
+	interval     = time.Millisecond * 250
+)
+
+type testCase struct {
+	store esv1alpha1.GenericStore
+
+	// prepare is called before the store is created
+	// use this to configure the fake provider
+	prepare func()
+
+	// assert is called once the store has been created
+	assert func(esv1alpha1.GenericStore)
+}
+
+type testTweaks func(*testCase)
+
+var _ = Describe("SecretStore reconcile", func() {
+	var test *testCase
+
+	BeforeEach(func() {
+		test = makeDefaultTestcase()
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.Delete(context.Background(), test.store)).To(Succeed())
+	})
+
+	// a store with a working provider is marked as Ready
+	validStore := func(tc *testCase) {
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Eventually(func() bool {
+				return hasReadyCondition(store, v1.ConditionTrue, esv1alpha1.ReasonStoreValid)
+			}, timeout, interval).Should(BeTrue())
+			Eventually(func() bool {
+				return hasEvent(store, v1.EventTypeNormal, esv1alpha1.ReasonStoreValid)
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// a provider that can not verify connectivity is still Ready
+	unknownValidationResult := func(tc *testCase) {
+		tc.prepare = func() {
+			fakeProvider.WithValidate(provider.ValidationResultUnknown, nil)
+		}
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Eventually(func() bool {
+				if !hasReadyCondition(store, v1.ConditionTrue, esv1alpha1.ReasonStoreValid) {
+					return false
+				}
+				cond := GetSecretStoreCondition(store.GetStatus(), esv1alpha1.SecretStoreReady)
+				return cond.Message == msgStoreUnknown
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// a failing Validate call sets Ready=False and emits a warning
+	invalidProvider := func(tc *testCase) {
+		tc.prepare = func() {
+			fakeProvider.WithValidate(provider.ValidationResultError, errors.New("access denied"))
+		}
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Eventually(func() bool {
+				return hasReadyCondition(store, v1.ConditionFalse, esv1alpha1.ReasonValidationFailed)
+			}, timeout, interval).Should(BeTrue())
+			Eventually(func() bool {
+				return hasEvent(store, v1.EventTypeWarning, esv1alpha1.ReasonValidationFailed)
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// a provider client that can not be constructed sets Ready=False
+	invalidProviderConfig := func(tc *testCase) {
+		tc.prepare = func() {
+			fakeProvider.WithNew(func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+				return nil, errors.New("invalid auth")
+			})
+		}
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Eventually(func() bool {
+				return hasReadyCondition(store, v1.ConditionFalse, esv1alpha1.ReasonInvalidProviderConfig)
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// a store spec that does not pass the static validation sets Ready=False
+	invalidStoreSpec := func(tc *testCase) {
+		tc.prepare = func() {
+			fakeProvider.WithValidateStore(field.ErrorList{
+				field.Required(field.NewPath("spec", "provider", "aws", "region"), ""),
+			})
+		}
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Eventually(func() bool {
+				return hasReadyCondition(store, v1.ConditionFalse, esv1alpha1.ReasonInvalidStore)
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	// stores that belong to a different controller class are ignored
+	ignoredControllerClass := func(tc *testCase) {
+		tc.store.GetSpec().Controller = "some-other-controller"
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Consistently(func() int {
+				Expect(k8sClient.Get(context.Background(), storeKey(store), store)).To(Succeed())
+				return len(store.GetStatus().Conditions)
+			}, time.Second*3, interval).Should(Equal(0))
+		}
+	}
+
+	// ClusterSecretStores are reconciled as well
+	clusterStore := func(tc *testCase) {
+		spec := tc.store.GetSpec()
+		tc.store = &esv1alpha1.ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: tc.store.GetName(),
+			},
+			Spec: *spec,
+		}
+	}
+
+	// auth refs of a ClusterSecretStore without a namespace can't be resolved
+	// without an ExternalSecret, the client is never constructed
+	namespacelessAuthRef := func(tc *testCase) {
+		tc.store.GetSpec().Provider.AWS.Auth = esv1alpha1.AWSAuth{
+			SecretRef: &esv1alpha1.AWSAuthSecretRef{
+				AccessKeyID: esmeta.SecretKeySelector{
+					Name: "aws-creds",
+					Key:  "access-key",
+				},
+				SecretAccessKey: esmeta.SecretKeySelector{
+					Name: "aws-creds",
+					Key:  "secret-key",
+				},
+			},
+		}
+		tc.prepare = func() {
+			fakeProvider.WithNew(func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+				return nil, errors.New("secret aws-creds not found")
+			})
+		}
+		tc.assert = func(store esv1alpha1.GenericStore) {
+			Eventually(func() bool {
+				if !hasReadyCondition(store, v1.ConditionTrue, esv1alpha1.ReasonStoreValid) {
+					return false
+				}
+				cond := GetSecretStoreCondition(store.GetStatus(), esv1alpha1.SecretStoreReady)
+				return cond.Message == msgStoreUnknown
+			}, timeout, interval).Should(BeTrue())
+		}
+	}
+
+	DescribeTable("When reconciling a SecretStore", func(tweaks ...testTweaks) {
+		for _, tweak := range tweaks {
+			tweak(test)
+		}
+		if test.prepare != nil {
+			test.prepare()
+		}
+		Expect(k8sClient.Create(context.Background(), test.store)).To(Succeed())
+		test.assert(test.store)
+	},
+		Entry("should mark a valid store as ready", validStore),
+		Entry("should mark a store as ready if connectivity can not be verified", unknownValidationResult),
+		Entry("should report a validation error", invalidProvider),
+		Entry("should report an invalid provider config", invalidProviderConfig),
+		Entry("should report an invalid store spec", invalidStoreSpec),
+		Entry("should ignore stores of a different controller class", ignoredControllerClass),
+		Entry("should mark a valid ClusterSecretStore as ready", validStore, clusterStore),
+		Entry("should report a ClusterSecretStore validation error", invalidProvider, clusterStore),
+		Entry("should not probe a ClusterSecretStore with auth refs without a namespace", namespacelessAuthRef, clusterStore),
+	)
+})
+
+func makeDefaultTestcase() *testCase {
+	fakeProvider.WithValidate(provider.ValidationResultReady, nil)
+	fakeProvider.WithValidateStore(nil)
+	fakeProvider.WithNew(func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+		return fakeProvider, nil
+	})
+	return &testCase{
+		store: &esv1alpha1.SecretStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-store-%d", time.Now().UnixNano()),
+				Namespace: "default",
+			},
+			Spec: esv1alpha1.SecretStoreSpec{
+				Provider: &esv1alpha1.SecretStoreProvider{
+					AWS: &esv1alpha1.AWSProvider{
+						Service: esv1alpha1.AWSServiceSecretsManager,
+					},
+				},
+			},
+		},
+	}
+}
+
+func storeKey(store esv1alpha1.GenericStore) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      store.GetName(),
+		Namespace: store.GetNamespace(),
+	}
+}
+
+func hasReadyCondition(store esv1alpha1.GenericStore, status v1.ConditionStatus, reason string) bool {
+	if err := k8sClient.Get(context.Background(), storeKey(store), store); err != nil {
+		return false
+	}
+	cond := GetSecretStoreCondition(store.GetStatus(), esv1alpha1.SecretStoreReady)
+	if cond == nil {
+		return false
+	}
+	return cond.Status == status && cond.Reason == reason
+}
+
+func hasEvent(store esv1alpha1.GenericStore, eventType, reason string) bool {
+	var events v1.EventList
+	if err := k8sClient.List(context.Background(), &events); err != nil {
+		return false
+	}
+	for _, e := range events.Items {
+		if e.InvolvedObject.UID == store.GetUID() && e.Type == eventType && e.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	fakeProvider = fake.New()
+	schema.ForceRegister(fakeProvider, &esv1alpha1.SecretStoreProvider{
+		AWS: &esv1alpha1.AWSProvider{
+			Service: esv1alpha1.AWSServiceSecretsManager,
+		},
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretstore
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// NewSecretStoreCondition a set of default options for creating a SecretStore Condition.
+func NewSecretStoreCondition(condType esv1alpha1.SecretStoreConditionType, status v1.ConditionStatus, reason, message string) *esv1alpha1.SecretStoreStatusCondition {
+	return &esv1alpha1.SecretStoreStatusCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// GetSecretStoreCondition returns the condition with the provided type.
+func GetSecretStoreCondition(status esv1alpha1.SecretStoreStatus, condType esv1alpha1.SecretStoreConditionType) *esv1alpha1.SecretStoreStatusCondition {
+	for i := range status.Conditions {
+		c := status.Conditions[i]
+		if c.Type == condType {
+			return &c
+		}
+	}
+	return nil
+}
+
+// SetSecretStoreCondition updates the secret store to include the provided
+// condition.
+func SetSecretStoreCondition(store esv1alpha1.GenericStore, condition esv1alpha1.SecretStoreStatusCondition) {
+	status := store.GetStatus()
+	currentCond := GetSecretStoreCondition(status, condition.Type)
+
+	if currentCond != nil && currentCond.Status == condition.Status &&
+		currentCond.Reason == condition.Reason && currentCond.Message == condition.Message {
+		return
+	}
+
+	// Do not update lastTransitionTime if the status of the condition doesn't change.
+	if currentCond != nil && currentCond.Status == condition.Status {
+		condition.LastTransitionTime = currentCond.LastTransitionTime
+	}
+
+	status.Conditions = append(filterOutCondition(status.Conditions, condition.Type), condition)
+	store.SetStatus(status)
+}
+
+// filterOutCondition returns an empty set of conditions with the provided type.
+func filterOutCondition(conditions []esv1alpha1.SecretStoreStatusCondition, condType esv1alpha1.SecretStoreConditionType) []esv1alpha1.SecretStoreStatusCondition {
+	newConditions := make([]esv1alpha1.SecretStoreStatusCondition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Type == condType {
+			continue
+		}
+		newConditions = append(newConditions, c)
+	}
+	return newConditions
+}
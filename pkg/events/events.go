@@ -0,0 +1,104 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events emits Kubernetes Events for the resources of the controllers.
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events emitted by the controllers.
+// Provider errors and store validation failures use the reason of the condition they set.
+const (
+	ReasonCreated       = "Created"
+	ReasonUpdated       = "Updated"
+	ReasonDeleted       = "Deleted"
+	ReasonStoreNotReady = "StoreNotReady"
+	ReasonSkipped       = "Skipped"
+	ReasonDrifted       = "Drifted"
+)
+
+// DefaultInterval is the interval identical events of an object are dropped for.
+const DefaultInterval = 5 * time.Minute
+
+// maxTrackedEvents bounds the number of events remembered for rate limiting,
+// the least recently emitted ones are forgotten first.
+const maxTrackedEvents = 4096
+
+// NewRateLimitedRecorder returns an EventRecorder that drops an event if an identical
+// event was emitted for the same object within the interval, so a reconcile
+// that keeps failing doesn't emit an event on every retry.
+// An interval of zero returns the recorder unchanged.
+func NewRateLimitedRecorder(recorder record.EventRecorder, interval time.Duration) record.EventRecorder {
+	if interval <= 0 {
+		return recorder
+	}
+	return &rateLimitedRecorder{
+		recorder: recorder,
+		interval: interval,
+		seen:     cache.NewLRUExpireCache(maxTrackedEvents),
+	}
+}
+
+type rateLimitedRecorder struct {
+	recorder record.EventRecorder
+	interval time.Duration
+	mu       sync.Mutex
+	seen     *cache.LRUExpireCache
+}
+
+// Event implements record.EventRecorder.
+func (r *rateLimitedRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.allow(object, eventtype, reason, message) {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// Eventf implements record.EventRecorder.
+func (r *rateLimitedRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf implements record.EventRecorder.
+func (r *rateLimitedRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.allow(object, eventtype, reason, message) {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// allow returns true if the event wasn't emitted for the object within the interval
+// and remembers it.
+func (r *rateLimitedRecorder) allow(object runtime.Object, eventtype, reason, message string) bool {
+	obj, err := meta.Accessor(object)
+	if err != nil {
+		return true
+	}
+	key := fmt.Sprintf("%s/%s/%s/%s/%s/%s", obj.GetUID(), obj.GetNamespace(), obj.GetName(), eventtype, reason, message)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.seen.Get(key); found {
+		return false
+	}
+	r.seen.Add(key, struct{}{}, r.interval)
+	return true
+}
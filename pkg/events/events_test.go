@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRateLimitedRecorder(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	recorder := NewRateLimitedRecorder(fake, time.Hour)
+	foo := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", UID: types.UID("foo")}}
+	bar := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "default", UID: types.UID("bar")}}
+
+	recorder.Eventf(foo, v1.EventTypeWarning, "PermissionDenied", "access denied to %s", "key")
+	recorder.Eventf(foo, v1.EventTypeWarning, "PermissionDenied", "access denied to %s", "key")
+	recorder.Eventf(foo, v1.EventTypeWarning, "PermissionDenied", "access denied to %s", "other")
+	recorder.Event(bar, v1.EventTypeWarning, "PermissionDenied", "access denied to key")
+	recorder.Event(foo, v1.EventTypeNormal, ReasonUpdated, "access denied to key")
+	close(fake.Events)
+
+	var got []string
+	for e := range fake.Events {
+		got = append(got, e)
+	}
+	want := []string{
+		"Warning PermissionDenied access denied to key",
+		"Warning PermissionDenied access denied to other",
+		"Warning PermissionDenied access denied to key",
+		"Normal Updated access denied to key",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected events: %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRateLimitedRecorderExpires(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	recorder := NewRateLimitedRecorder(fake, 10*time.Millisecond)
+	foo := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+	recorder.Event(foo, v1.EventTypeNormal, ReasonCreated, "created")
+	time.Sleep(20 * time.Millisecond)
+	recorder.Event(foo, v1.EventTypeNormal, ReasonCreated, "created")
+	if len(fake.Events) != 2 {
+		t.Errorf("expected the event to be emitted again after the interval, got %d events", len(fake.Events))
+	}
+}
+
+func TestZeroIntervalDisablesRateLimit(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	if recorder := NewRateLimitedRecorder(fake, 0); recorder != fake {
+		t.Errorf("expected the recorder to be returned unchanged")
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ecr implements a generator for AWS ECR authorization tokens.
+package ecr
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/generator"
+	awsauth "github.com/external-secrets/external-secrets/pkg/provider/aws/auth"
+	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
+)
+
+const (
+	errUnexpectedType = "expected %T, got %T"
+	errCreateSession  = "unable to create aws session: %w"
+	errGetToken       = "unable to get authorization token: %w"
+	errNoToken        = "no authorization data returned"
+	errInvalidToken   = "unexpected authorization token format"
+)
+
+// ECRInterface is a subset of the ecriface api.
+// see: https://docs.aws.amazon.com/sdk-for-go/api/service/ecr/ecriface/
+type ECRInterface interface {
+	GetAuthorizationToken(*ecr.GetAuthorizationTokenInput) (*ecr.GetAuthorizationTokenOutput, error)
+}
+
+// Generator requests ECR authorization tokens.
+type Generator struct {
+	newClient func(*session.Session) ECRInterface
+}
+
+// New returns an ECR authorization token generator.
+func New() *Generator {
+	return &Generator{
+		newClient: func(sess *session.Session) ECRInterface {
+			return ecr.New(sess)
+		},
+	}
+}
+
+func init() {
+	generator.Register(New(), &genv1alpha1.ECRAuthorizationToken{})
+}
+
+// Generate returns the keys "username", "password", "proxy_endpoint" and "expires_at".
+func (g *Generator) Generate(ctx context.Context, obj client.Object, kube client.Client, namespace string) (map[string][]byte, error) {
+	res, ok := obj.(*genv1alpha1.ECRAuthorizationToken)
+	if !ok {
+		return nil, fmt.Errorf(errUnexpectedType, &genv1alpha1.ECRAuthorizationToken{}, obj)
+	}
+	// the aws auth works on a store, the generator resource is namespaced
+	// so it behaves like a SecretStore in the namespace of the ExternalSecret.
+	store := &esv1alpha1.SecretStore{
+		TypeMeta: metav1.TypeMeta{
+			Kind: esv1alpha1.SecretStoreKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      res.Name,
+			Namespace: namespace,
+		},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				AWS: &esv1alpha1.AWSProvider{
+					Auth:   res.Spec.Auth,
+					Role:   res.Spec.Role,
+					Region: res.Spec.Region,
+				},
+			},
+		},
+	}
+	sess, err := awsauth.New(ctx, store, kube, namespace, awsauth.DefaultSTSProvider, awsauth.DefaultJWTProvider)
+	if err != nil {
+		return nil, fmt.Errorf(errCreateSession, err)
+	}
+	out, err := g.newClient(sess).GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return nil, fmt.Errorf(errGetToken, util.SanitizeErr(err))
+	}
+	if len(out.AuthorizationData) == 0 {
+		return nil, errors.New(errNoToken)
+	}
+	data := out.AuthorizationData[0]
+	decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(data.AuthorizationToken))
+	if err != nil {
+		return nil, fmt.Errorf(errGetToken, err)
+	}
+	// the token has the format user:password
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New(errInvalidToken)
+	}
+	return map[string][]byte{
+		"username":       []byte(parts[0]),
+		"password":       []byte(parts[1]),
+		"proxy_endpoint": []byte(aws.StringValue(data.ProxyEndpoint)),
+		"expires_at":     []byte(strconv.FormatInt(aws.TimeValue(data.ExpiresAt).Unix(), 10)),
+	}, nil
+}
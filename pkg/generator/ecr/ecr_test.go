@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ecr
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/google/go-cmp/cmp"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type fakeECR struct {
+	out *ecr.GetAuthorizationTokenOutput
+	err error
+}
+
+func (f *fakeECR) GetAuthorizationToken(*ecr.GetAuthorizationTokenInput) (*ecr.GetAuthorizationTokenOutput, error) {
+	return f.out, f.err
+}
+
+func TestGenerate(t *testing.T) {
+	expiry := time.Unix(1234, 0)
+	tbl := []struct {
+		name        string
+		client      *fakeECR
+		expectData  map[string][]byte
+		expectError string
+	}{
+		{
+			name: "returns the decoded token",
+			client: &fakeECR{
+				out: &ecr.GetAuthorizationTokenOutput{
+					AuthorizationData: []*ecr.AuthorizationData{
+						{
+							AuthorizationToken: aws.String(base64.StdEncoding.EncodeToString([]byte("AWS:secret:with:colons"))),
+							ProxyEndpoint:      aws.String("https://123456789012.dkr.ecr.eu-west-1.amazonaws.com"),
+							ExpiresAt:          &expiry,
+						},
+					},
+				},
+			},
+			expectData: map[string][]byte{
+				"username":       []byte("AWS"),
+				"password":       []byte("secret:with:colons"),
+				"proxy_endpoint": []byte("https://123456789012.dkr.ecr.eu-west-1.amazonaws.com"),
+				"expires_at":     []byte("1234"),
+			},
+		},
+		{
+			name:        "api error",
+			client:      &fakeECR{err: errors.New("boom")},
+			expectError: "unable to get authorization token: boom",
+		},
+		{
+			name:        "no authorization data",
+			client:      &fakeECR{out: &ecr.GetAuthorizationTokenOutput{}},
+			expectError: errNoToken,
+		},
+		{
+			name: "token without password",
+			client: &fakeECR{
+				out: &ecr.GetAuthorizationTokenOutput{
+					AuthorizationData: []*ecr.AuthorizationData{
+						{AuthorizationToken: aws.String(base64.StdEncoding.EncodeToString([]byte("AWS")))},
+					},
+				},
+			},
+			expectError: errInvalidToken,
+		},
+	}
+
+	for _, c := range tbl {
+		g := &Generator{
+			newClient: func(*session.Session) ECRInterface {
+				return c.client
+			},
+		}
+		data, err := g.Generate(context.Background(), &genv1alpha1.ECRAuthorizationToken{
+			Spec: genv1alpha1.ECRAuthorizationTokenSpec{
+				Region: "eu-west-1",
+			},
+		}, nil, "default")
+		if c.expectError != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expectError) {
+				t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", c.name, err)
+			continue
+		}
+		if diff := cmp.Diff(c.expectData, data); diff != "" {
+			t.Errorf("[%s] unexpected data: %s", c.name, diff)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcr implements a generator for GCP container registry access tokens.
+package gcr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/generator"
+	"github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager"
+)
+
+const (
+	// the registry accepts access tokens with this fixed user name.
+	tokenUsername = "oauth2accesstoken"
+
+	errUnexpectedType = "expected %T, got %T"
+	errTokenSource    = "unable to create token source: %w"
+	errGetToken       = "unable to get access token: %w"
+)
+
+type tokenSourceFunc func(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (oauth2.TokenSource, error)
+
+// Generator requests OAuth2 access tokens for the container registry.
+type Generator struct {
+	tokenSource tokenSourceFunc
+}
+
+// New returns a GCR access token generator.
+func New() *Generator {
+	return &Generator{tokenSource: secretmanager.NewTokenSource}
+}
+
+func init() {
+	generator.Register(New(), &genv1alpha1.GCRAccessToken{})
+}
+
+// Generate returns the keys "username", "password" and "expiry".
+func (g *Generator) Generate(ctx context.Context, obj client.Object, kube client.Client, namespace string) (map[string][]byte, error) {
+	res, ok := obj.(*genv1alpha1.GCRAccessToken)
+	if !ok {
+		return nil, fmt.Errorf(errUnexpectedType, &genv1alpha1.GCRAccessToken{}, obj)
+	}
+	// the gcp auth works on a store, the generator resource is namespaced
+	// so it behaves like a SecretStore in the namespace of the ExternalSecret.
+	store := &esv1alpha1.SecretStore{
+		TypeMeta: metav1.TypeMeta{
+			Kind: esv1alpha1.SecretStoreKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      res.Name,
+			Namespace: namespace,
+		},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				GCPSM: &esv1alpha1.GCPSMProvider{
+					Auth:      res.Spec.Auth,
+					ProjectID: res.Spec.ProjectID,
+				},
+			},
+		},
+	}
+	ts, err := g.tokenSource(ctx, store, kube, namespace)
+	if err != nil {
+		return nil, fmt.Errorf(errTokenSource, err)
+	}
+	token, err := ts.Token()
+	if err != nil {
+		return nil, fmt.Errorf(errGetToken, err)
+	}
+	return map[string][]byte{
+		"username": []byte(tokenUsername),
+		"password": []byte(token.AccessToken),
+		"expiry":   []byte(strconv.FormatInt(token.Expiry.Unix(), 10)),
+	}, nil
+}
@@ -0,0 +1,99 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcr
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+type errTokenSourceFake struct{}
+
+func (errTokenSourceFake) Token() (*oauth2.Token, error) {
+	return nil, errors.New("expired")
+}
+
+func TestGenerate(t *testing.T) {
+	tbl := []struct {
+		name        string
+		tokenSource tokenSourceFunc
+		expectData  map[string][]byte
+		expectError string
+	}{
+		{
+			name: "returns the access token",
+			tokenSource: func(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (oauth2.TokenSource, error) {
+				if store.GetSpec().Provider.GCPSM.ProjectID != "my-project" || namespace != "default" {
+					return nil, errors.New("unexpected store")
+				}
+				return oauth2.StaticTokenSource(&oauth2.Token{
+					AccessToken: "ya29.token",
+					Expiry:      time.Unix(1234, 0),
+				}), nil
+			},
+			expectData: map[string][]byte{
+				"username": []byte("oauth2accesstoken"),
+				"password": []byte("ya29.token"),
+				"expiry":   []byte("1234"),
+			},
+		},
+		{
+			name: "token source error",
+			tokenSource: func(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (oauth2.TokenSource, error) {
+				return nil, errors.New("missing credentials")
+			},
+			expectError: "unable to create token source: missing credentials",
+		},
+		{
+			name: "token error",
+			tokenSource: func(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (oauth2.TokenSource, error) {
+				return errTokenSourceFake{}, nil
+			},
+			expectError: "unable to get access token: expired",
+		},
+	}
+
+	for _, c := range tbl {
+		g := &Generator{tokenSource: c.tokenSource}
+		data, err := g.Generate(context.Background(), &genv1alpha1.GCRAccessToken{
+			Spec: genv1alpha1.GCRAccessTokenSpec{
+				ProjectID: "my-project",
+			},
+		}, nil, "default")
+		if c.expectError != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expectError) {
+				t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", c.name, err)
+			continue
+		}
+		if diff := cmp.Diff(c.expectData, data); diff != "" {
+			t.Errorf("[%s] unexpected data: %s", c.name, diff)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package generator defines the interface of the secret generators
+// and the registry they are looked up from by the kind of their resource.
+package generator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Generator produces secret data instead of fetching it from a provider.
+type Generator interface {
+	// Generate returns new secret data for the given generator resource.
+	Generate(ctx context.Context, obj client.Object, kube client.Client, namespace string) (map[string][]byte, error)
+}
+
+type registration struct {
+	generator Generator
+	object    client.Object
+}
+
+var builder map[string]registration
+var buildlock sync.RWMutex
+
+func init() {
+	builder = make(map[string]registration)
+}
+
+// Register a generator for the kind of the given resource. Register panics if a
+// generator for the same kind is already registered.
+func Register(g Generator, obj client.Object) {
+	kind := kindOf(obj)
+
+	buildlock.Lock()
+	defer buildlock.Unlock()
+	if _, exists := builder[kind]; exists {
+		panic(fmt.Sprintf("generator %q already registered", kind))
+	}
+	builder[kind] = registration{generator: g, object: obj}
+}
+
+// ForceRegister adds a generator for the kind of the given resource,
+// overwriting a generator if already registered. Should only be used for testing.
+func ForceRegister(g Generator, obj client.Object) {
+	buildlock.Lock()
+	builder[kindOf(obj)] = registration{generator: g, object: obj}
+	buildlock.Unlock()
+}
+
+// GetGenerator returns the generator registered for the kind
+// and an empty resource of that kind to read the generator spec into.
+func GetGenerator(kind string) (Generator, client.Object, error) {
+	buildlock.RLock()
+	r, ok := builder[kind]
+	buildlock.RUnlock()
+	if !ok {
+		return nil, nil, fmt.Errorf("no generator registered for kind %q", kind)
+	}
+	obj, ok := r.object.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, nil, fmt.Errorf("generator resource of kind %q is not a client.Object", kind)
+	}
+	return r.generator, obj, nil
+}
+
+func kindOf(obj client.Object) string {
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
@@ -0,0 +1,169 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package password implements a generator for random passwords.
+package password
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/generator"
+)
+
+const (
+	lowerLetters   = "abcdefghijklmnopqrstuvwxyz"
+	upperLetters   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars     = "0123456789"
+	defaultSymbols = "~!@#$%^&*()_+`-={}|[]\\:\"<>?,./"
+
+	errUnexpectedType = "expected %T, got %T"
+	errTooShort       = "length %d is less than the %d digits and %d symbols"
+	errNotEnoughChars = "cannot pick %d distinct characters out of %q without allowRepeat"
+	errNoSymbolChars  = "symbolCharacters must not be empty if symbols are requested"
+)
+
+// Generator generates passwords from the characters of its random source.
+type Generator struct {
+	rand io.Reader
+}
+
+// New returns a password generator that uses crypto/rand.
+func New() *Generator {
+	return &Generator{rand: rand.Reader}
+}
+
+// NewWithRand returns a password generator that reads from the given random source.
+// Passwords are deterministic for a deterministic source.
+func NewWithRand(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+func init() {
+	generator.Register(New(), &genv1alpha1.Password{})
+}
+
+// Generate returns a new password with the key "password".
+func (g *Generator) Generate(ctx context.Context, obj client.Object, kube client.Client, namespace string) (map[string][]byte, error) {
+	res, ok := obj.(*genv1alpha1.Password)
+	if !ok {
+		return nil, fmt.Errorf(errUnexpectedType, &genv1alpha1.Password{}, obj)
+	}
+	pass, err := g.generate(res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		"password": []byte(pass),
+	}, nil
+}
+
+func (g *Generator) generate(spec genv1alpha1.PasswordSpec) (string, error) {
+	length := spec.Length
+	digits := length / 4
+	if spec.Digits != nil {
+		digits = *spec.Digits
+	}
+	symbols := length / 4
+	if spec.Symbols != nil {
+		symbols = *spec.Symbols
+	}
+	if digits+symbols > length {
+		return "", fmt.Errorf(errTooShort, length, digits, symbols)
+	}
+	symbolChars := defaultSymbols
+	if spec.SymbolCharacters != nil {
+		symbolChars = *spec.SymbolCharacters
+	}
+	if symbols > 0 && symbolChars == "" {
+		return "", errors.New(errNoSymbolChars)
+	}
+	letterChars := lowerLetters
+	if !spec.NoUpper {
+		letterChars += upperLetters
+	}
+
+	// picked characters must be distinct across all character sets
+	picked := make([]rune, 0, length)
+	seen := make(map[rune]bool, length)
+	for _, set := range []struct {
+		chars string
+		count int
+	}{
+		{letterChars, length - digits - symbols},
+		{digitChars, digits},
+		{symbolChars, symbols},
+	} {
+		chars, err := g.pick(set.chars, set.count, spec.AllowRepeat, seen)
+		if err != nil {
+			return "", err
+		}
+		picked = append(picked, chars...)
+	}
+
+	// shuffle, otherwise the password would start with all letters
+	for i := len(picked) - 1; i > 0; i-- {
+		j, err := g.randInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		picked[i], picked[j] = picked[j], picked[i]
+	}
+	return string(picked), nil
+}
+
+func (g *Generator) pick(chars string, count int, allowRepeat bool, seen map[rune]bool) ([]rune, error) {
+	candidates := []rune(chars)
+	picked := make([]rune, 0, count)
+	for len(picked) < count {
+		if !allowRepeat {
+			candidates = unseen(candidates, seen)
+			if len(candidates) == 0 {
+				return nil, fmt.Errorf(errNotEnoughChars, count, chars)
+			}
+		}
+		i, err := g.randInt(len(candidates))
+		if err != nil {
+			return nil, err
+		}
+		picked = append(picked, candidates[i])
+		seen[candidates[i]] = true
+	}
+	return picked, nil
+}
+
+func (g *Generator) randInt(max int) (int, error) {
+	n, err := rand.Int(g.rand, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, err
+	}
+	return int(n.Int64()), nil
+}
+
+func unseen(chars []rune, seen map[rune]bool) []rune {
+	res := chars[:0]
+	for _, c := range chars {
+		if !seen[c] {
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package password
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestGenerate(t *testing.T) {
+	tbl := []struct {
+		name        string
+		spec        genv1alpha1.PasswordSpec
+		expectPass  string
+		expectError string
+	}{
+		{
+			name:       "default composition",
+			spec:       genv1alpha1.PasswordSpec{Length: 24},
+			expectPass: "7c5`SL.]P2M80s$/*6trhGAJ",
+		},
+		{
+			name: "only digits",
+			spec: genv1alpha1.PasswordSpec{
+				Length:  8,
+				Digits:  intPtr(8),
+				Symbols: intPtr(0),
+			},
+			expectPass: "38942651",
+		},
+		{
+			name: "custom symbols without upper case",
+			spec: genv1alpha1.PasswordSpec{
+				Length:           8,
+				Digits:           intPtr(0),
+				Symbols:          intPtr(4),
+				SymbolCharacters: strPtr("-_"),
+				NoUpper:          true,
+				AllowRepeat:      true,
+			},
+			expectPass: "sc_h_-b_",
+		},
+		{
+			name: "not enough distinct characters",
+			spec: genv1alpha1.PasswordSpec{
+				Length:  11,
+				Digits:  intPtr(11),
+				Symbols: intPtr(0),
+			},
+			expectError: "cannot pick 11 distinct characters",
+		},
+		{
+			name: "digits and symbols exceed length",
+			spec: genv1alpha1.PasswordSpec{
+				Length:  4,
+				Digits:  intPtr(3),
+				Symbols: intPtr(3),
+			},
+			expectError: "length 4 is less than the 3 digits and 3 symbols",
+		},
+	}
+
+	for _, c := range tbl {
+		g := NewWithRand(rand.New(rand.NewSource(1)))
+		data, err := g.Generate(context.Background(), &genv1alpha1.Password{Spec: c.spec}, nil, "")
+		if c.expectError != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expectError) {
+				t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("[%s] unexpected error: %v", c.name, err)
+			continue
+		}
+		if got := string(data["password"]); got != c.expectPass {
+			t.Errorf("[%s] unexpected password: expected %q, got %q", c.name, c.expectPass, got)
+		}
+	}
+}
+
+func TestGenerateComposition(t *testing.T) {
+	pass, err := New().generate(genv1alpha1.PasswordSpec{
+		Length:  32,
+		Digits:  intPtr(5),
+		Symbols: intPtr(7),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var digits, symbols int
+	seen := make(map[rune]bool)
+	for _, c := range pass {
+		switch {
+		case strings.ContainsRune(digitChars, c):
+			digits++
+		case strings.ContainsRune(defaultSymbols, c):
+			symbols++
+		}
+		if seen[c] {
+			t.Errorf("unexpected repeated character %q in %q", c, pass)
+		}
+		seen[c] = true
+	}
+	if len(pass) != 32 || digits != 5 || symbols != 7 {
+		t.Errorf("unexpected composition of %q: %d characters, %d digits, %d symbols", pass, len(pass), digits, symbols)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sshkey implements a generator for SSH key pairs.
+package sshkey
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/ssh"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/generator"
+)
+
+const (
+	defaultRSAKeySize = 4096
+
+	errUnexpectedType = "expected %T, got %T"
+	errUnknownKeyType = "unknown key type %q"
+	errGenerateKey    = "unable to generate %s key: %w"
+	errEncodeKey      = "unable to encode key: %w"
+)
+
+// Generator generates SSH key pairs from its random source.
+type Generator struct {
+	rand io.Reader
+}
+
+// New returns a SSH key generator that uses crypto/rand.
+func New() *Generator {
+	return &Generator{rand: rand.Reader}
+}
+
+// NewWithRand returns a SSH key generator that reads from the given random source.
+// Note that RSA keys are not deterministic even for a deterministic source.
+func NewWithRand(r io.Reader) *Generator {
+	return &Generator{rand: r}
+}
+
+func init() {
+	generator.Register(New(), &genv1alpha1.SSHKey{})
+}
+
+// Generate returns a new key pair with the keys "privateKey" and "publicKey".
+func (g *Generator) Generate(ctx context.Context, obj client.Object, kube client.Client, namespace string) (map[string][]byte, error) {
+	res, ok := obj.(*genv1alpha1.SSHKey)
+	if !ok {
+		return nil, fmt.Errorf(errUnexpectedType, &genv1alpha1.SSHKey{}, obj)
+	}
+	key, err := g.generateKey(res.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return g.encode(key, res.Spec.Comment)
+}
+
+func (g *Generator) generateKey(spec genv1alpha1.SSHKeySpec) (crypto.Signer, error) {
+	switch spec.KeyType {
+	case genv1alpha1.SSHKeyTypeRSA, "":
+		size := defaultRSAKeySize
+		if spec.KeySize != nil {
+			size = *spec.KeySize
+		}
+		key, err := rsa.GenerateKey(g.rand, size)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, genv1alpha1.SSHKeyTypeRSA, err)
+		}
+		return key, nil
+	case genv1alpha1.SSHKeyTypeED25519:
+		_, key, err := ed25519.GenerateKey(g.rand)
+		if err != nil {
+			return nil, fmt.Errorf(errGenerateKey, genv1alpha1.SSHKeyTypeED25519, err)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf(errUnknownKeyType, spec.KeyType)
+}
+
+func (g *Generator) encode(key crypto.Signer, comment string) (map[string][]byte, error) {
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf(errEncodeKey, err)
+	}
+	priv, err := g.marshalPrivateKey(key, pub, comment)
+	if err != nil {
+		return nil, fmt.Errorf(errEncodeKey, err)
+	}
+	authorized := ssh.MarshalAuthorizedKey(pub)
+	if comment != "" {
+		// MarshalAuthorizedKey terminates the line with a newline
+		authorized = append(authorized[:len(authorized)-1], []byte(" "+comment+"\n")...)
+	}
+	return map[string][]byte{
+		"privateKey": priv,
+		"publicKey":  authorized,
+	}, nil
+}
+
+// marshalPrivateKey encodes the key in the unencrypted openssh-key-v1 format,
+// see https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.key.
+func (g *Generator) marshalPrivateKey(key crypto.Signer, pub ssh.PublicKey, comment string) ([]byte, error) {
+	var keyData []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		keyData = ssh.Marshal(struct {
+			N       *big.Int
+			E       *big.Int
+			D       *big.Int
+			Iqmp    *big.Int
+			P       *big.Int
+			Q       *big.Int
+			Comment string
+		}{
+			N:       k.N,
+			E:       big.NewInt(int64(k.E)),
+			D:       k.D,
+			Iqmp:    k.Precomputed.Qinv,
+			P:       k.Primes[0],
+			Q:       k.Primes[1],
+			Comment: comment,
+		})
+	case ed25519.PrivateKey:
+		keyData = ssh.Marshal(struct {
+			Pub     []byte
+			Priv    []byte
+			Comment string
+		}{
+			Pub:     k.Public().(ed25519.PublicKey),
+			Priv:    k,
+			Comment: comment,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+
+	// the check ints allow to detect a wrong passphrase when decrypting,
+	// they must be equal but are otherwise arbitrary
+	var check [4]byte
+	if _, err := io.ReadFull(g.rand, check[:]); err != nil {
+		return nil, err
+	}
+	checkInt := binary.BigEndian.Uint32(check[:])
+	privBlock := ssh.Marshal(struct {
+		Check1  uint32
+		Check2  uint32
+		KeyType string
+		Rest    []byte `ssh:"rest"`
+	}{
+		Check1:  checkInt,
+		Check2:  checkInt,
+		KeyType: pub.Type(),
+		Rest:    keyData,
+	})
+	// pad to the block size of the "none" cipher
+	for i := 1; len(privBlock)%8 != 0; i++ {
+		privBlock = append(privBlock, byte(i))
+	}
+
+	body := ssh.Marshal(struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{
+		CipherName:   "none",
+		KdfName:      "none",
+		NumKeys:      1,
+		PubKey:       pub.Marshal(),
+		PrivKeyBlock: privBlock,
+	})
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte("openssh-key-v1\x00"), body...),
+	}), nil
+}
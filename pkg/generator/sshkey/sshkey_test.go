@@ -0,0 +1,112 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshkey
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	genv1alpha1 "github.com/external-secrets/external-secrets/apis/generators/v1alpha1"
+)
+
+func TestGenerateED25519(t *testing.T) {
+	spec := &genv1alpha1.SSHKey{
+		Spec: genv1alpha1.SSHKeySpec{
+			KeyType: genv1alpha1.SSHKeyTypeED25519,
+			Comment: "test@example",
+		},
+	}
+	first, err := NewWithRand(rand.New(rand.NewSource(1))).Generate(context.Background(), spec, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := NewWithRand(rand.New(rand.NewSource(1))).Generate(context.Background(), spec, nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(first["privateKey"], second["privateKey"]) || !bytes.Equal(first["publicKey"], second["publicKey"]) {
+		t.Errorf("expected the same key pair for the same random source")
+	}
+
+	raw, err := ssh.ParseRawPrivateKey(first["privateKey"])
+	if err != nil {
+		t.Fatalf("unable to parse private key: %v", err)
+	}
+	priv, ok := raw.(*ed25519.PrivateKey)
+	if !ok {
+		t.Fatalf("unexpected private key type %T", raw)
+	}
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey(first["publicKey"])
+	if err != nil {
+		t.Fatalf("unable to parse public key: %v", err)
+	}
+	if comment != "test@example" {
+		t.Errorf("unexpected comment %q", comment)
+	}
+	if !bytes.Equal(pub.(ssh.CryptoPublicKey).CryptoPublicKey().(ed25519.PublicKey), priv.Public().(ed25519.PublicKey)) {
+		t.Errorf("public key doesn't match private key")
+	}
+}
+
+func TestEncodeRSA(t *testing.T) {
+	// RSA key generation isn't deterministic, encode a fixed key instead
+	key, err := rsa.GenerateKey(rand.New(rand.NewSource(1)), 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := NewWithRand(rand.New(rand.NewSource(1))).encode(key, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	raw, err := ssh.ParseRawPrivateKey(data["privateKey"])
+	if err != nil {
+		t.Fatalf("unable to parse private key: %v", err)
+	}
+	priv, ok := raw.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("unexpected private key type %T", raw)
+	}
+	if !priv.Equal(key) {
+		t.Errorf("parsed private key doesn't match generated key")
+	}
+	if !strings.HasPrefix(string(data["publicKey"]), "ssh-rsa ") {
+		t.Errorf("unexpected public key %q", data["publicKey"])
+	}
+}
+
+func TestGenerateRSAKeySize(t *testing.T) {
+	size := 2048
+	key, err := New().generateKey(genv1alpha1.SSHKeySpec{KeySize: &size})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := key.(*rsa.PrivateKey).N.BitLen(); got != size {
+		t.Errorf("unexpected key size %d, expected %d", got, size)
+	}
+}
+
+func TestUnknownKeyType(t *testing.T) {
+	_, err := New().generateKey(genv1alpha1.SSHKeySpec{KeyType: "dsa"})
+	if err == nil || !strings.Contains(err.Error(), "unknown key type") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
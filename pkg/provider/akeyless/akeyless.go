@@ -21,6 +21,7 @@ import (
 	"strconv"
 
 	"github.com/akeylesslabs/akeyless-go/v2"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -66,6 +67,28 @@ func (p *Provider) NewClient(ctx context.Context, store esv1alpha1.GenericStore,
 	return newClient(ctx, store, kube, namespace)
 }
 
+// ValidateStore checks the Akeyless specific part of the store spec.
+func (p *Provider) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "akeyless")
+	spec, err := GetAKeylessProvider(store)
+	if err != nil {
+		return field.ErrorList{field.Required(fldPath, err.Error())}
+	}
+	authPath := fldPath.Child("authSecretRef")
+	if spec.Auth == nil {
+		return field.ErrorList{field.Required(authPath, "")}
+	}
+	refPath := authPath.Child("secretRef")
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.AccessID, refPath.Child("accessID"))...)
+	allErrs = append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.AccessType, refPath.Child("accessType"))...)
+	// accessTypeParam is not needed by every access type
+	if spec.Auth.SecretRef.AccessTypeParam.Name != "" {
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.AccessTypeParam, refPath.Child("accessTypeParam"))...)
+	}
+	return allErrs
+}
+
 func newClient(_ context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (provider.SecretsClient, error) {
 	akl := &akeylessBase{
 		kube:      kube,
@@ -99,6 +122,19 @@ func newClient(_ context.Context, store esv1alpha1.GenericStore, kube client.Cli
 	return &Akeyless{Client: akl}, nil
 }
 
+// Validate authenticates against the gateway with the access id of the
+// store. Akeyless grants access per item, so the token doesn't tell whether
+// the secrets of the ExternalSecrets can be read.
+func (a *Akeyless) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	if utils.IsNil(a.Client) {
+		return provider.ValidationResultError, fmt.Errorf(errUninitalizedAkeylessProvider)
+	}
+	if _, err := a.Client.TokenFromSecretRef(ctx); err != nil {
+		return provider.ValidationResultError, err
+	}
+	return provider.ValidationResultReady, nil
+}
+
 func (a *Akeyless) Close(ctx context.Context) error {
 	return nil
 }
@@ -153,3 +189,8 @@ func (a *Akeyless) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecr
 	}
 	return secretData, nil
 }
+
+// GetAllSecrets is not supported by the Akeyless provider.
+func (a *Akeyless) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
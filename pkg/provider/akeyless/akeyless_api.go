@@ -28,6 +28,8 @@ import (
 	azure_cloud_id "github.com/akeylesslabs/akeyless-go-cloud-id/cloudprovider/azure"
 	gcp_cloud_id "github.com/akeylesslabs/akeyless-go-cloud-id/cloudprovider/gcp"
 	"github.com/akeylesslabs/akeyless-go/v2"
+
+	"github.com/external-secrets/external-secrets/pkg/provider"
 )
 
 var apiErr akeyless.GenericOpenAPIError
@@ -100,10 +102,14 @@ func (a *akeylessBase) DescribeItem(itemName, token string) (*akeyless.Item, err
 	} else {
 		body.Token = &token
 	}
-	gsvOut, _, err := a.RestAPI.DescribeItem(ctx).Body(body).Execute()
+	gsvOut, res, err := a.RestAPI.DescribeItem(ctx).Body(body).Execute()
 	if err != nil {
 		if errors.As(err, &apiErr) {
-			return nil, fmt.Errorf("can't describe item: %v", string(apiErr.Body()))
+			err = fmt.Errorf("can't describe item: %v", string(apiErr.Body()))
+			if res != nil {
+				return nil, provider.FromHTTPStatus(res.StatusCode, err)
+			}
+			return nil, err
 		}
 		return nil, fmt.Errorf("can't describe item: %w", err)
 	}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakeakeyless "github.com/external-secrets/external-secrets/pkg/provider/akeyless/fake"
 )
 
@@ -166,3 +167,18 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestValidate(t *testing.T) {
+	mockClient := &fakeakeyless.AkeylessMockClient{}
+	sm := Akeyless{Client: mockClient}
+	res, err := sm.Validate(context.Background())
+	if res != provider.ValidationResultReady || err != nil {
+		t.Errorf("expected the store to be ready, got %v: %v", res, err)
+	}
+
+	mockClient.WithTokenErr(fmt.Errorf("authentication failed: access denied"))
+	res, err = sm.Validate(context.Background())
+	if res != provider.ValidationResultError || err == nil {
+		t.Errorf("expected the failed authentication to be an error, got %v: %v", res, err)
+	}
+}
@@ -19,12 +19,23 @@ import (
 
 type AkeylessMockClient struct {
 	getSecret func(secretName, token string, version int32) (string, error)
+	tokenErr  error
 }
 
 func (mc *AkeylessMockClient) TokenFromSecretRef(ctx context.Context) (string, error) {
+	if mc.tokenErr != nil {
+		return "", mc.tokenErr
+	}
 	return "newToken", nil
 }
 
+// WithTokenErr makes the authentication fail with err.
+func (mc *AkeylessMockClient) WithTokenErr(err error) {
+	if mc != nil {
+		mc.tokenErr = err
+	}
+}
+
 func (mc *AkeylessMockClient) GetSecretByType(secretName, token string, version int32) (string, error) {
 	return mc.getSecret(secretName, token, version)
 }
@@ -20,6 +20,7 @@ import (
 
 type AlibabaMockClient struct {
 	getSecretValue func(request *kmssdk.GetSecretValueRequest) (response *kmssdk.GetSecretValueResponse, err error)
+	listSecretsErr error
 }
 
 func (mc *AlibabaMockClient) GetSecretValue(*kmssdk.GetSecretValueRequest) (result *kmssdk.GetSecretValueResponse, err error) {
@@ -33,3 +34,17 @@ func (mc *AlibabaMockClient) WithValue(in *kmssdk.GetSecretValueRequest, val *km
 		}
 	}
 }
+
+func (mc *AlibabaMockClient) ListSecrets(*kmssdk.ListSecretsRequest) (result *kmssdk.ListSecretsResponse, err error) {
+	if mc.listSecretsErr != nil {
+		return nil, mc.listSecretsErr
+	}
+	return kmssdk.CreateListSecretsResponse(), nil
+}
+
+// WithListSecretsErr makes ListSecrets fail with err.
+func (mc *AlibabaMockClient) WithListSecretsErr(err error) {
+	if mc != nil {
+		mc.listSecretsErr = err
+	}
+}
@@ -17,12 +17,16 @@ package alibaba
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
+	sdkerrors "github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	kmssdk "github.com/aliyun/alibaba-cloud-sdk-go/services/kms"
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -59,6 +63,7 @@ type KeyManagementService struct {
 
 type SMInterface interface {
 	GetSecretValue(request *kmssdk.GetSecretValueRequest) (response *kmssdk.GetSecretValueResponse, err error)
+	ListSecrets(request *kmssdk.ListSecretsRequest) (response *kmssdk.ListSecretsResponse, err error)
 }
 
 // setAuth creates a new Alibaba session based on a store.
@@ -120,6 +125,10 @@ func (kms *KeyManagementService) GetSecret(ctx context.Context, ref esv1alpha1.E
 	kmsRequest.SecretName = ref.Key
 	kmsRequest.SetScheme("https")
 	secretOut, err := kms.Client.GetSecretValue(kmsRequest)
+	var serverErr *sdkerrors.ServerError
+	if errors.As(err, &serverErr) {
+		return nil, provider.FromHTTPStatus(serverErr.HttpStatus(), util.SanitizeErr(err))
+	}
 	if err != nil {
 		return nil, util.SanitizeErr(err)
 	}
@@ -135,7 +144,7 @@ func (kms *KeyManagementService) GetSecret(ctx context.Context, ref esv1alpha1.E
 	}
 	val := gjson.Get(payload, ref.Property)
 	if !val.Exists() {
-		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+		return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 	}
 	return []byte(val.String()), nil
 }
@@ -158,6 +167,11 @@ func (kms *KeyManagementService) GetSecretMap(ctx context.Context, ref esv1alpha
 	return secretData, nil
 }
 
+// GetAllSecrets is not supported by the Alibaba provider.
+func (kms *KeyManagementService) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (kms *KeyManagementService) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube kclient.Client, namespace string) (provider.SecretsClient, error) {
 	storeSpec := store.GetSpec()
@@ -178,8 +192,48 @@ func (kms *KeyManagementService) NewClient(ctx context.Context, store esv1alpha1
 	if err != nil {
 		return nil, fmt.Errorf(errAlibabaClient, err)
 	}
-	kms.Client = keyManagementService
-	return kms, nil
+	return &KeyManagementService{Client: keyManagementService}, nil
+}
+
+// ValidateStore checks the Alibaba specific part of the store spec.
+func (kms *KeyManagementService) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "alibaba")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Alibaba == nil {
+		return field.ErrorList{field.Required(fldPath, "missing Alibaba provider")}
+	}
+	spec := storeSpec.Provider.Alibaba
+	allErrs := field.ErrorList{}
+	if spec.RegionID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("regionID"), ""))
+	}
+	authPath := fldPath.Child("auth")
+	if spec.Auth == nil {
+		return append(allErrs, field.Required(authPath, ""))
+	}
+	refPath := authPath.Child("secretRef")
+	allErrs = append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.AccessKeyID, refPath.Child("accessKeyIDSecretRef"))...)
+	return append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.AccessKeySecret, refPath.Child("accessKeySecretSecretRef"))...)
+}
+
+// Validate lists a single secret of the region, KMS returns the secret
+// metadata only, so the AccessKey needs kms:ListSecrets but no read access.
+func (kms *KeyManagementService) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	if utils.IsNil(kms.Client) {
+		return provider.ValidationResultError, fmt.Errorf(errUninitalizedAlibabaProvider)
+	}
+	kmsRequest := kmssdk.CreateListSecretsRequest()
+	kmsRequest.PageSize = requests.NewInteger(1)
+	kmsRequest.SetScheme("https")
+	_, err := kms.Client.ListSecrets(kmsRequest)
+	var serverErr *sdkerrors.ServerError
+	if errors.As(err, &serverErr) {
+		return provider.ProbeResult(provider.FromHTTPStatus(serverErr.HttpStatus(), util.SanitizeErr(err)))
+	}
+	if err != nil {
+		return provider.ValidationResultError, util.SanitizeErr(err)
+	}
+	return provider.ValidationResultReady, nil
 }
 
 func (kms *KeyManagementService) Close(ctx context.Context) error {
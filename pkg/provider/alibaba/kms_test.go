@@ -17,14 +17,17 @@ package alibaba
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
 
+	sdkerrors "github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
 	kmssdk "github.com/aliyun/alibaba-cloud-sdk-go/services/kms"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakesm "github.com/external-secrets/external-secrets/pkg/provider/alibaba/fake"
 )
 
@@ -111,6 +114,18 @@ var setNilMockClient = func(kmstc *keyManagementServiceTestCase) {
 	kmstc.expectError = errUninitalizedAlibabaProvider
 }
 
+func TestAlibabaKMSGetSecretNotFound(t *testing.T) {
+	kmstc := makeValidKMSTestCase()
+	kmstc.apiErr = sdkerrors.NewServerError(http.StatusNotFound, `{"Code":"Forbidden.ResourceNotFound","Message":"The resource cannot be found."}`, "")
+	kmstc.mockClient.WithValue(kmstc.apiInput, kmstc.apiOutput, kmstc.apiErr)
+
+	sm := KeyManagementService{Client: kmstc.mockClient}
+	_, err := sm.GetSecret(context.Background(), *kmstc.ref)
+	if !provider.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
 func TestAlibabaKMSGetSecret(t *testing.T) {
 	secretData := make(map[string]interface{})
 	secretValue := "changedvalue"
@@ -195,3 +210,28 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestValidate(t *testing.T) {
+	for _, c := range []struct {
+		statusCode int
+		expectRes  provider.ValidationResult
+		expectErr  bool
+	}{
+		{statusCode: http.StatusOK, expectRes: provider.ValidationResultReady},
+		{statusCode: http.StatusForbidden, expectRes: provider.ValidationResultUnknown},
+		{statusCode: http.StatusUnauthorized, expectRes: provider.ValidationResultError, expectErr: true},
+	} {
+		mockClient := &fakesm.AlibabaMockClient{}
+		if c.statusCode != http.StatusOK {
+			mockClient.WithListSecretsErr(sdkerrors.NewServerError(c.statusCode, `{"Code":"Forbidden.NoPermission"}`, ""))
+		}
+		kms := KeyManagementService{Client: mockClient}
+		res, err := kms.Validate(context.Background())
+		if res != c.expectRes {
+			t.Errorf("[%d] unexpected result: expected %v, got %v", c.statusCode, c.expectRes, res)
+		}
+		if (err != nil) != c.expectErr {
+			t.Errorf("[%d] unexpected error: %v", c.statusCode, err)
+		}
+	}
+}
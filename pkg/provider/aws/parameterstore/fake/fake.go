@@ -22,7 +22,11 @@ import (
 
 // Client implements the aws parameterstore interface.
 type Client struct {
-	valFn func(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	valFn      func(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	valuesFn   func(*ssm.GetParametersInput) (*ssm.GetParametersOutput, error)
+	putFn      func(*ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
+	byPathFn   func(*ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+	describeFn func(*ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error)
 }
 
 func (sm *Client) GetParameter(in *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
@@ -37,3 +41,51 @@ func (sm *Client) WithValue(in *ssm.GetParameterInput, val *ssm.GetParameterOutp
 		return val, err
 	}
 }
+
+func (sm *Client) GetParameters(in *ssm.GetParametersInput) (*ssm.GetParametersOutput, error) {
+	if sm.valuesFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.valuesFn(in)
+}
+
+// WithGetParameters sets the function called when multiple parameters are fetched.
+func (sm *Client) WithGetParameters(fn func(*ssm.GetParametersInput) (*ssm.GetParametersOutput, error)) {
+	sm.valuesFn = fn
+}
+
+func (sm *Client) PutParameter(in *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+	if sm.putFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.putFn(in)
+}
+
+// WithPutParameter sets the function called when a parameter is written.
+func (sm *Client) WithPutParameter(fn func(*ssm.PutParameterInput) (*ssm.PutParameterOutput, error)) {
+	sm.putFn = fn
+}
+
+func (sm *Client) GetParametersByPath(in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+	if sm.byPathFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.byPathFn(in)
+}
+
+// WithGetParametersByPath sets the function called when parameters are fetched by path.
+func (sm *Client) WithGetParametersByPath(fn func(*ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)) {
+	sm.byPathFn = fn
+}
+
+func (sm *Client) DescribeParameters(in *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
+	if sm.describeFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.describeFn(in)
+}
+
+// WithDescribeParameters sets the function called when parameters are described.
+func (sm *Client) WithDescribeParameters(fn func(*ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error)) {
+	sm.describeFn = fn
+}
@@ -16,20 +16,26 @@ package parameterstore
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/tidwall/gjson"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 // ParameterStore is a provider for AWS ParameterStore.
 type ParameterStore struct {
+	sess   client.ConfigProvider
 	client PMInterface
 }
 
@@ -37,13 +43,24 @@ type ParameterStore struct {
 // see: https://docs.aws.amazon.com/sdk-for-go/api/service/ssm/ssmiface/
 type PMInterface interface {
 	GetParameter(*ssm.GetParameterInput) (*ssm.GetParameterOutput, error)
+	GetParameters(*ssm.GetParametersInput) (*ssm.GetParametersOutput, error)
+	PutParameter(*ssm.PutParameterInput) (*ssm.PutParameterOutput, error)
+	GetParametersByPath(*ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error)
+	DescribeParameters(*ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error)
 }
 
+// maxParametersPerRequest is the maximum number of names GetParameters accepts.
+const maxParametersPerRequest = 10
+
+var _ provider.SecretsWriter = &ParameterStore{}
+var _ provider.SecretsBatchReader = &ParameterStore{}
+
 var log = ctrl.Log.WithName("provider").WithName("aws").WithName("parameterstore")
 
 // New constructs a ParameterStore Provider that is specific to a store.
 func New(sess client.ConfigProvider) (*ParameterStore, error) {
 	return &ParameterStore{
+		sess:   sess,
 		client: ssm.New(sess),
 	}, nil
 }
@@ -56,17 +73,87 @@ func (pm *ParameterStore) GetSecret(ctx context.Context, ref esv1alpha1.External
 		WithDecryption: aws.Bool(true),
 	})
 	if err != nil {
-		return nil, util.SanitizeErr(err)
+		return nil, util.CategorizeErr(err)
+	}
+	recordVersion(ctx, ref, out.Parameter)
+	return getProperty(ref, out.Parameter.Value)
+}
+
+// GetSecrets reads the parameters of the refs with GetParameters, ten at a time.
+// Every parameter is read once, no matter how many refs read properties of it.
+func (pm *ParameterStore) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	var names []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		if !seen[ref.Key] {
+			seen[ref.Key] = true
+			names = append(names, ref.Key)
+		}
+	}
+	log.Info("fetching secret values", "keys", names)
+
+	params := make(map[string]*ssm.Parameter)
+	errs := make(map[string]error)
+	for start := 0; start < len(names); start += maxParametersPerRequest {
+		end := start + maxParametersPerRequest
+		if end > len(names) {
+			end = len(names)
+		}
+		out, err := pm.client.GetParameters(&ssm.GetParametersInput{
+			Names:          aws.StringSlice(names[start:end]),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			for _, name := range names[start:end] {
+				errs[name] = util.CategorizeErr(err)
+			}
+			continue
+		}
+		for _, param := range out.Parameters {
+			params[aws.StringValue(param.Name)] = param
+			if param.ARN != nil {
+				params[*param.ARN] = param
+			}
+		}
+	}
+
+	results := make([]provider.SecretResult, len(refs))
+	for i, ref := range refs {
+		if err, ok := errs[ref.Key]; ok {
+			results[i].Err = err
+			continue
+		}
+		param, ok := params[ref.Key]
+		if !ok {
+			results[i].Err = provider.NewNotFoundError(fmt.Errorf("parameter %s not found", ref.Key))
+			continue
+		}
+		recordVersion(ctx, ref, param)
+		results[i].Value, results[i].Err = getProperty(ref, param.Value)
+	}
+	return results
+}
+
+// recordVersion reports the version number of the parameter the ref was resolved to.
+func recordVersion(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef, param *ssm.Parameter) {
+	if param == nil || param.Version == nil {
+		return
 	}
+	provider.RecordVersion(ctx, ref.Key, ref.Version, strconv.FormatInt(*param.Version, 10))
+}
+
+// getProperty returns the value of the parameter or, if the ref has a property,
+// the value of the property of the JSON object stored in the parameter.
+func getProperty(ref esv1alpha1.ExternalSecretDataRemoteRef, value *string) ([]byte, error) {
 	if ref.Property == "" {
-		if out.Parameter.Value != nil {
-			return []byte(*out.Parameter.Value), nil
+		if value != nil {
+			return []byte(*value), nil
 		}
 		return nil, fmt.Errorf("invalid secret received. parameter value is nil for key: %s", ref.Key)
 	}
-	val := gjson.Get(*out.Parameter.Value, ref.Property)
+	val := gjson.Get(aws.StringValue(value), ref.Property)
 	if !val.Exists() {
-		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+		return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 	}
 	return []byte(val.String()), nil
 }
@@ -90,6 +177,137 @@ func (pm *ParameterStore) GetSecretMap(ctx context.Context, ref esv1alpha1.Exter
 	return secretData, nil
 }
 
+// GetAllSecrets returns the value of all parameters that match the find criteria.
+// Without tags the parameters are fetched with GetParametersByPath below the path, which defaults to '/'.
+// With tags the parameters are looked up with DescribeParameters and fetched one by one,
+// because GetParametersByPath does not return tags.
+func (pm *ParameterStore) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	matcher, err := utils.NewFindMatcher(ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(ref.Tags) > 0 {
+		return pm.findByTags(ctx, ref, matcher)
+	}
+
+	path := "/"
+	if ref.Path != nil && *ref.Path != "" {
+		path = *ref.Path
+	}
+	input := &ssm.GetParametersByPathInput{
+		Path:           &path,
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	}
+	secrets := make(map[string][]byte)
+	for {
+		out, err := pm.client.GetParametersByPath(input)
+		if err != nil {
+			return nil, util.CategorizeErr(err)
+		}
+		for _, param := range out.Parameters {
+			name := aws.StringValue(param.Name)
+			if !matcher.MatchName(name) {
+				continue
+			}
+			secrets[name] = []byte(aws.StringValue(param.Value))
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return secrets, nil
+}
+
+func (pm *ParameterStore) findByTags(ctx context.Context, ref esv1alpha1.ExternalSecretFind, matcher *utils.FindMatcher) (map[string][]byte, error) {
+	input := &ssm.DescribeParametersInput{}
+	for k, v := range ref.Tags {
+		input.ParameterFilters = append(input.ParameterFilters, &ssm.ParameterStringFilter{
+			Key:    aws.String("tag:" + k),
+			Values: []*string{aws.String(v)},
+		})
+	}
+	secrets := make(map[string][]byte)
+	for {
+		out, err := pm.client.DescribeParameters(input)
+		if err != nil {
+			return nil, util.CategorizeErr(err)
+		}
+		for _, param := range out.Parameters {
+			name := aws.StringValue(param.Name)
+			if !matcher.MatchName(name) {
+				continue
+			}
+			data, err := pm.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: name})
+			if err != nil {
+				return nil, err
+			}
+			secrets[name] = data
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return secrets, nil
+}
+
+// PushSecret writes the value to the parameter referenced by remoteRef.
+// New parameters are created as SecureString, existing parameters keep their type.
+// If a property is given the value is set as property of the JSON object stored in the parameter.
+// No new version is created if the parameter already contains the value.
+func (pm *ParameterStore) PushSecret(ctx context.Context, value []byte, remoteRef esv1alpha1.PushSecretRemoteRef) error {
+	log.Info("pushing secret value", "key", remoteRef.RemoteKey)
+	out, err := pm.client.GetParameter(&ssm.GetParameterInput{
+		Name:           &remoteRef.RemoteKey,
+		WithDecryption: aws.Bool(true),
+	})
+	var aerr awserr.Error
+	var current string
+	paramType := ssm.ParameterTypeSecureString
+	if errors.As(err, &aerr) && aerr.Code() == ssm.ErrCodeParameterNotFound {
+		out = nil
+	} else if err != nil {
+		return util.CategorizeErr(err)
+	}
+	if out != nil && out.Parameter != nil {
+		if out.Parameter.Value != nil {
+			current = *out.Parameter.Value
+		}
+		if out.Parameter.Type != nil {
+			paramType = *out.Parameter.Type
+		}
+	}
+
+	payload := string(value)
+	if remoteRef.Property != "" {
+		payload, err = util.SetJSONProperty(current, remoteRef.Property, value)
+		if err != nil {
+			return fmt.Errorf("unable to set property %s of secret %s: %w", remoteRef.Property, remoteRef.RemoteKey, err)
+		}
+	}
+	if out != nil && payload == current {
+		return nil
+	}
+
+	_, err = pm.client.PutParameter(&ssm.PutParameterInput{
+		Name:      &remoteRef.RemoteKey,
+		Value:     &payload,
+		Type:      &paramType,
+		Overwrite: aws.Bool(true),
+	})
+	if err != nil {
+		return util.CategorizeErr(err)
+	}
+	return nil
+}
+
+// Validate checks if credentials for ParameterStore can be retrieved.
+func (pm *ParameterStore) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	return util.ValidateCredentials(pm.sess, ssm.ServiceName)
+}
+
 func (pm *ParameterStore) Close(ctx context.Context) error {
 	return nil
 }
@@ -20,10 +20,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/google/go-cmp/cmp"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fake "github.com/external-secrets/external-secrets/pkg/provider/aws/parameterstore/fake"
 )
 
@@ -146,6 +148,94 @@ func TestGetSecret(t *testing.T) {
 	}
 }
 
+func TestGetSecretNotFound(t *testing.T) {
+	setNotFound := func(pstc *parameterstoreTestCase) {
+		pstc.apiErr = awserr.New(ssm.ErrCodeParameterNotFound, "parameter not found", nil)
+	}
+	setAPIError := func(pstc *parameterstoreTestCase) {
+		pstc.apiErr = fmt.Errorf("oh no")
+	}
+	for k, v := range []struct {
+		tc           *parameterstoreTestCase
+		wantNotFound bool
+	}{
+		{tc: makeValidParameterStoreTestCaseCustom(setNotFound), wantNotFound: true},
+		{tc: makeValidParameterStoreTestCaseCustom(setAPIError), wantNotFound: false},
+	} {
+		ps := ParameterStore{client: v.tc.fakeClient}
+		_, err := ps.GetSecret(context.Background(), *v.tc.remoteRef)
+		if provider.IsNotFound(err) != v.wantNotFound {
+			t.Errorf("[%d] unexpected not found error: %v, expected not found: %t", k, err, v.wantNotFound)
+		}
+	}
+}
+
+func TestGetSecrets(t *testing.T) {
+	params := map[string]string{
+		"/json": `{"user": "foo", "password": "bar"}`,
+	}
+	for i := 0; i < 12; i++ {
+		params[fmt.Sprintf("/param-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	var requests [][]string
+	fakeClient := &fake.Client{}
+	fakeClient.WithGetParameters(func(in *ssm.GetParametersInput) (*ssm.GetParametersOutput, error) {
+		names := aws.StringValueSlice(in.Names)
+		requests = append(requests, names)
+		if !aws.BoolValue(in.WithDecryption) {
+			return nil, fmt.Errorf("expected decryption")
+		}
+		out := &ssm.GetParametersOutput{}
+		for _, name := range names {
+			if val, ok := params[name]; ok {
+				out.Parameters = append(out.Parameters, &ssm.Parameter{Name: aws.String(name), Value: aws.String(val)})
+			} else {
+				out.InvalidParameters = append(out.InvalidParameters, aws.String(name))
+			}
+		}
+		return out, nil
+	})
+
+	refs := []esv1alpha1.ExternalSecretDataRemoteRef{
+		{Key: "/json", Property: "user"},
+		{Key: "/json", Property: "password"},
+		{Key: "/json", Property: "missing"},
+		{Key: "/missing"},
+	}
+	for i := 0; i < 12; i++ {
+		refs = append(refs, esv1alpha1.ExternalSecretDataRemoteRef{Key: fmt.Sprintf("/param-%d", i)})
+	}
+	ps := ParameterStore{client: fakeClient}
+	results := ps.GetSecrets(context.Background(), refs)
+
+	if len(requests) != 2 || len(requests[0]) != maxParametersPerRequest || len(requests[1]) != 4 {
+		t.Errorf("expected 14 unique names in 2 requests, got %v", requests)
+	}
+	if string(results[0].Value) != "foo" || string(results[1].Value) != "bar" {
+		t.Errorf("unexpected properties: %q, %q", results[0].Value, results[1].Value)
+	}
+	if provider.GetErrorCategory(results[2].Err) != provider.ErrorCategoryInvalidRef {
+		t.Errorf("expected an invalid ref error, got %v", results[2].Err)
+	}
+	if !provider.IsNotFound(results[3].Err) {
+		t.Errorf("expected a not found error, got %v", results[3].Err)
+	}
+	for i := 0; i < 12; i++ {
+		if got := string(results[4+i].Value); got != fmt.Sprintf("value-%d", i) {
+			t.Errorf("unexpected value of /param-%d: %q", i, got)
+		}
+	}
+
+	// errors of a request fail the refs of that request
+	fakeClient.WithGetParameters(func(in *ssm.GetParametersInput) (*ssm.GetParametersOutput, error) {
+		return nil, awserr.New(ssm.ErrCodeInternalServerError, "oh no", nil)
+	})
+	results = ps.GetSecrets(context.Background(), refs[:1])
+	if provider.GetErrorCategory(results[0].Err) != provider.ErrorCategoryTransient {
+		t.Errorf("expected a transient error, got %v", results[0].Err)
+	}
+}
+
 func TestGetSecretMap(t *testing.T) {
 	// good case: default version & deserialization
 	setDeserialization := func(pstc *parameterstoreTestCase) {
@@ -193,3 +283,184 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestPushSecret(t *testing.T) {
+	notFound := awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil)
+
+	tbl := []struct {
+		name        string
+		current     *ssm.GetParameterOutput
+		getErr      error
+		putErr      error
+		value       string
+		property    string
+		expectPut   *ssm.PutParameterInput
+		expectError string
+	}{
+		{
+			name:   "create secure string if not found",
+			getErr: notFound,
+			value:  "bar",
+			expectPut: &ssm.PutParameterInput{
+				Name:      aws.String("/baz"),
+				Value:     aws.String("bar"),
+				Type:      aws.String(ssm.ParameterTypeSecureString),
+				Overwrite: aws.Bool(true),
+			},
+		},
+		{
+			name: "keep type of existing parameter",
+			current: &ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{
+					Value: aws.String(`{"other":"val"}`),
+					Type:  aws.String(ssm.ParameterTypeString),
+				},
+			},
+			value:    "bar",
+			property: "foo",
+			expectPut: &ssm.PutParameterInput{
+				Name:      aws.String("/baz"),
+				Value:     aws.String(`{"foo":"bar","other":"val"}`),
+				Type:      aws.String(ssm.ParameterTypeString),
+				Overwrite: aws.Bool(true),
+			},
+		},
+		{
+			name:    "skip unchanged value",
+			current: makeValidAPIOutput(),
+			value:   "RRRRR",
+		},
+		{
+			name:        "get error",
+			getErr:      fmt.Errorf("oh no"),
+			value:       "bar",
+			expectError: "oh no",
+		},
+		{
+			name:        "put error",
+			current:     makeValidAPIOutput(),
+			putErr:      fmt.Errorf("oh no"),
+			value:       "bar",
+			expectError: "oh no",
+		},
+	}
+
+	for _, c := range tbl {
+		var put *ssm.PutParameterInput
+		fakeClient := &fake.Client{}
+		fakeClient.WithValue(makeValidAPIInput(), c.current, c.getErr)
+		fakeClient.WithPutParameter(func(in *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+			put = in
+			return &ssm.PutParameterOutput{}, c.putErr
+		})
+		ps := ParameterStore{
+			client: fakeClient,
+		}
+		err := ps.PushSecret(context.Background(), []byte(c.value), esv1alpha1.PushSecretRemoteRef{
+			RemoteKey: "/baz",
+			Property:  c.property,
+		})
+		if !ErrorContains(err, c.expectError) {
+			t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+		}
+		if c.expectError != "" {
+			continue
+		}
+		if !cmp.Equal(c.expectPut, put) {
+			t.Errorf("[%s] unexpected put parameter input: expected %v, got %v", c.name, c.expectPut, put)
+		}
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	tbl := []struct {
+		name         string
+		find         esv1alpha1.ExternalSecretFind
+		byPathErr    error
+		expectPath   string
+		expectFilter []*ssm.ParameterStringFilter
+		expectData   map[string][]byte
+		expectError  string
+	}{
+		{
+			name:       "get parameters below root by default",
+			find:       esv1alpha1.ExternalSecretFind{},
+			expectPath: "/",
+			expectData: map[string][]byte{
+				"/app/db":  []byte("db-value"),
+				"/app/api": []byte("api-value"),
+			},
+		},
+		{
+			name: "get parameters below path matching the name",
+			find: esv1alpha1.ExternalSecretFind{
+				Path: aws.String("/app"),
+				Name: &esv1alpha1.FindName{RegExp: "db$"},
+			},
+			expectPath: "/app",
+			expectData: map[string][]byte{
+				"/app/db": []byte("db-value"),
+			},
+		},
+		{
+			name: "describe parameters by tags",
+			find: esv1alpha1.ExternalSecretFind{
+				Tags: map[string]string{"env": "prod"},
+			},
+			expectFilter: []*ssm.ParameterStringFilter{
+				{Key: aws.String("tag:env"), Values: []*string{aws.String("prod")}},
+			},
+			expectData: map[string][]byte{
+				"/baz": []byte("RRRRR"),
+			},
+		},
+		{
+			name:        "get parameters by path error",
+			byPathErr:   fmt.Errorf("oh no"),
+			expectError: "oh no",
+		},
+	}
+
+	for _, c := range tbl {
+		var path string
+		var filters []*ssm.ParameterStringFilter
+		fakeClient := &fake.Client{}
+		fakeClient.WithValue(makeValidAPIInput(), makeValidAPIOutput(), nil)
+		fakeClient.WithGetParametersByPath(func(in *ssm.GetParametersByPathInput) (*ssm.GetParametersByPathOutput, error) {
+			path = aws.StringValue(in.Path)
+			return &ssm.GetParametersByPathOutput{
+				Parameters: []*ssm.Parameter{
+					{Name: aws.String("/app/db"), Value: aws.String("db-value")},
+					{Name: aws.String("/app/api"), Value: aws.String("api-value")},
+				},
+			}, c.byPathErr
+		})
+		fakeClient.WithDescribeParameters(func(in *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
+			filters = in.ParameterFilters
+			return &ssm.DescribeParametersOutput{
+				Parameters: []*ssm.ParameterMetadata{
+					{Name: aws.String("/baz")},
+				},
+			}, nil
+		})
+		ps := ParameterStore{
+			client: fakeClient,
+		}
+		data, err := ps.GetAllSecrets(context.Background(), c.find)
+		if !ErrorContains(err, c.expectError) {
+			t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+		}
+		if c.expectError != "" {
+			continue
+		}
+		if path != c.expectPath {
+			t.Errorf("[%s] unexpected path: expected %s, got %s", c.name, c.expectPath, path)
+		}
+		if !cmp.Equal(c.expectFilter, filters) {
+			t.Errorf("[%s] unexpected filters: %s", c.name, cmp.Diff(c.expectFilter, filters))
+		}
+		if !cmp.Equal(c.expectData, data) {
+			t.Errorf("[%s] unexpected secrets: %s", c.name, cmp.Diff(c.expectData, data))
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -27,6 +28,7 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 // Provider satisfies the provider interface.
@@ -42,6 +44,35 @@ func (p *Provider) NewClient(ctx context.Context, store esv1alpha1.GenericStore,
 	return newClient(ctx, store, kube, namespace, awsauth.DefaultSTSProvider)
 }
 
+// ValidateStore checks the AWS specific part of the store spec.
+func (p *Provider) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "aws")
+	prov, err := util.GetAWSProvider(store)
+	if err != nil {
+		return field.ErrorList{field.Required(fldPath, err.Error())}
+	}
+	allErrs := field.ErrorList{}
+	switch prov.Service {
+	case esv1alpha1.AWSServiceSecretsManager, esv1alpha1.AWSServiceParameterStore:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("service"), prov.Service,
+			[]string{string(esv1alpha1.AWSServiceSecretsManager), string(esv1alpha1.AWSServiceParameterStore)}))
+	}
+	if prov.Region == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("region"), ""))
+	}
+	authPath := fldPath.Child("auth")
+	if prov.Auth.SecretRef != nil {
+		refPath := authPath.Child("secretRef")
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, prov.Auth.SecretRef.AccessKeyID, refPath.Child("accessKeyIDSecretRef"))...)
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, prov.Auth.SecretRef.SecretAccessKey, refPath.Child("secretAccessKeySecretRef"))...)
+	}
+	if prov.Auth.JWTAuth != nil && prov.Auth.JWTAuth.ServiceAccountRef != nil {
+		allErrs = append(allErrs, utils.ValidateServiceAccountSelector(store, *prov.Auth.JWTAuth.ServiceAccountRef, authPath.Child("jwt", "serviceAccountRef"))...)
+	}
+	return allErrs
+}
+
 func newClient(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string, assumeRoler awsauth.STSProvider) (provider.SecretsClient, error) {
 	prov, err := util.GetAWSProvider(store)
 	if err != nil {
@@ -137,3 +137,99 @@ func TestProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStore(t *testing.T) {
+	p := Provider{}
+
+	tbl := []struct {
+		test      string
+		store     esv1alpha1.GenericStore
+		expFields []string
+	}{
+		{
+			test: "should accept a valid store",
+			store: &esv1alpha1.SecretStore{
+				Spec: esv1alpha1.SecretStoreSpec{
+					Provider: &esv1alpha1.SecretStoreProvider{
+						AWS: &esv1alpha1.AWSProvider{
+							Service: esv1alpha1.AWSServiceSecretsManager,
+							Region:  "eu-central-1",
+						},
+					},
+				},
+			},
+		},
+		{
+			test: "should require a region and a known service",
+			store: &esv1alpha1.SecretStore{
+				Spec: esv1alpha1.SecretStoreSpec{
+					Provider: &esv1alpha1.SecretStoreProvider{
+						AWS: &esv1alpha1.AWSProvider{
+							Service: "HIHIHIHHEHEHEHEHEHE",
+						},
+					},
+				},
+			},
+			expFields: []string{"spec.provider.aws.service", "spec.provider.aws.region"},
+		},
+		{
+			test: "should reject a secret namespace in a SecretStore",
+			store: &esv1alpha1.SecretStore{
+				Spec: esv1alpha1.SecretStoreSpec{
+					Provider: &esv1alpha1.SecretStoreProvider{
+						AWS: &esv1alpha1.AWSProvider{
+							Service: esv1alpha1.AWSServiceParameterStore,
+							Region:  "eu-central-1",
+							Auth: esv1alpha1.AWSAuth{
+								SecretRef: &esv1alpha1.AWSAuthSecretRef{
+									AccessKeyID: esmeta.SecretKeySelector{
+										Name:      "foo",
+										Key:       "id",
+										Namespace: aws.String("NOOP"),
+									},
+									SecretAccessKey: esmeta.SecretKeySelector{
+										Name: "foo",
+										Key:  "secret",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expFields: []string{"spec.provider.aws.auth.secretRef.accessKeyIDSecretRef.namespace"},
+		},
+		{
+			test: "should require a service account namespace in a ClusterSecretStore",
+			store: &esv1alpha1.ClusterSecretStore{
+				Spec: esv1alpha1.SecretStoreSpec{
+					Provider: &esv1alpha1.SecretStoreProvider{
+						AWS: &esv1alpha1.AWSProvider{
+							Service: esv1alpha1.AWSServiceParameterStore,
+							Region:  "eu-central-1",
+							Auth: esv1alpha1.AWSAuth{
+								JWTAuth: &esv1alpha1.AWSJWTAuth{
+									ServiceAccountRef: &esmeta.ServiceAccountSelector{
+										Name: "foo",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expFields: []string{"spec.provider.aws.auth.jwt.serviceAccountRef.namespace"},
+		},
+	}
+	for i := range tbl {
+		row := tbl[i]
+		t.Run(row.test, func(t *testing.T) {
+			errs := p.ValidateStore(row.store)
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, row.expFields, fields)
+		})
+	}
+}
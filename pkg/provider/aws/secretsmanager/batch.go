@@ -0,0 +1,73 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// maxSecretsPerBatch is the maximum number of secret ids BatchGetSecretValue accepts.
+const maxSecretsPerBatch = 20
+
+// BatchGetSecretValueInput is the input of the BatchGetSecretValue operation.
+// The vendored aws-sdk-go predates the operation, the types follow the API reference:
+// https://docs.aws.amazon.com/secretsmanager/latest/apireference/API_BatchGetSecretValue.html
+type BatchGetSecretValueInput struct {
+	_ struct{} `type:"structure"`
+
+	SecretIdList []*string `min:"1" type:"list"`
+}
+
+// BatchGetSecretValueOutput is the output of the BatchGetSecretValue operation.
+// A SecretValueEntry has the same fields as a GetSecretValueOutput.
+type BatchGetSecretValueOutput struct {
+	_ struct{} `type:"structure"`
+
+	Errors []*APIErrorType `type:"list"`
+
+	NextToken *string `type:"string"`
+
+	SecretValues []*awssm.GetSecretValueOutput `type:"list"`
+}
+
+// APIErrorType is the error of a single secret of a BatchGetSecretValue call.
+type APIErrorType struct {
+	_ struct{} `type:"structure"`
+
+	ErrorCode *string `type:"string"`
+
+	Message *string `type:"string"`
+
+	SecretId *string `min:"1" type:"string"`
+}
+
+// sdkClient adds the BatchGetSecretValue operation to the SDK client.
+type sdkClient struct {
+	*awssm.SecretsManager
+}
+
+// BatchGetSecretValue sends the request through the handlers of the SDK client,
+// which sign it and marshal it with the JSON-RPC protocol of Secrets Manager.
+func (c sdkClient) BatchGetSecretValue(input *BatchGetSecretValueInput) (*BatchGetSecretValueOutput, error) {
+	op := &request.Operation{
+		Name:       "BatchGetSecretValue",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	output := &BatchGetSecretValueOutput{}
+	req := c.NewRequest(op, input, output)
+	return output, req.Send()
+}
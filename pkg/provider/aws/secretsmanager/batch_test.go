@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretsmanager
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	fakesm "github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager/fake"
+)
+
+// batchClient is a fake client that implements SMBatchInterface.
+type batchClient struct {
+	*fakesm.Client
+	batchCalls [][]string
+	batchFn    func(ids []string) (*BatchGetSecretValueOutput, error)
+}
+
+func (c *batchClient) BatchGetSecretValue(in *BatchGetSecretValueInput) (*BatchGetSecretValueOutput, error) {
+	ids := aws.StringValueSlice(in.SecretIdList)
+	c.batchCalls = append(c.batchCalls, ids)
+	return c.batchFn(ids)
+}
+
+func TestBatchGetSecretValueRequest(t *testing.T) {
+	var target string
+	var body map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.Header.Get("X-Amz-Target")
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		_, _ = w.Write([]byte(`{
+			"SecretValues": [{"Name": "db", "ARN": "arn:aws:secretsmanager:eu-west-1:123456789012:secret:db-AbCdEf", "SecretString": "s3cr3t", "VersionId": "v1"},
+				{"Name": "cert", "SecretBinary": "Y2VydA=="}],
+			"Errors": [{"SecretId": "missing", "ErrorCode": "ResourceNotFoundException", "Message": "not found"}]
+		}`))
+	}))
+	defer srv.Close()
+	sess := session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(srv.URL),
+		Region:      aws.String("eu-west-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	}))
+
+	out, err := sdkClient{awssm.New(sess)}.BatchGetSecretValue(&BatchGetSecretValueInput{
+		SecretIdList: aws.StringSlice([]string{"db", "cert", "missing"}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if target != "secretsmanager.BatchGetSecretValue" {
+		t.Errorf("unexpected target %q", target)
+	}
+	if ids := body["SecretIdList"]; len(ids) != 3 || ids[0] != "db" {
+		t.Errorf("unexpected request body %v", body)
+	}
+	if len(out.SecretValues) != 2 || aws.StringValue(out.SecretValues[0].SecretString) != "s3cr3t" || string(out.SecretValues[1].SecretBinary) != "cert" {
+		t.Errorf("unexpected secret values %v", out.SecretValues)
+	}
+	if len(out.Errors) != 1 || aws.StringValue(out.Errors[0].ErrorCode) != "ResourceNotFoundException" {
+		t.Errorf("unexpected errors %v", out.Errors)
+	}
+}
+
+func TestGetSecretsBatch(t *testing.T) {
+	client := &batchClient{Client: fakesm.NewClient()}
+	client.batchFn = func(ids []string) (*BatchGetSecretValueOutput, error) {
+		return &BatchGetSecretValueOutput{
+			SecretValues: []*awssm.GetSecretValueOutput{
+				{Name: aws.String("db"), SecretString: aws.String(`{"user":"admin","pass":"s3cr3t"}`)},
+				{Name: aws.String("api"), ARN: aws.String("arn:aws:secretsmanager:eu-west-1:123456789012:secret:api-AbCdEf"), SecretString: aws.String("token")},
+			},
+			Errors: []*APIErrorType{
+				{SecretId: aws.String("missing"), ErrorCode: aws.String("ResourceNotFoundException"), Message: aws.String("not found")},
+			},
+		}, nil
+	}
+	previous := "AWSPREVIOUS"
+	client.WithValue(&awssm.GetSecretValueInput{SecretId: aws.String("db"), VersionStage: &previous}, &awssm.GetSecretValueOutput{
+		SecretString: aws.String(`{"user":"admin","pass":"old"}`),
+	}, nil)
+	sm := SecretsManager{client: client}
+
+	results := sm.GetSecrets(context.Background(), []esv1alpha1.ExternalSecretDataRemoteRef{
+		{Key: "db", Property: "user"},
+		{Key: "db", Property: "pass"},
+		{Key: "arn:aws:secretsmanager:eu-west-1:123456789012:secret:api-AbCdEf"},
+		{Key: "missing"},
+		{Key: "db", Property: "pass", Version: previous},
+	})
+
+	if len(client.batchCalls) != 1 || len(client.batchCalls[0]) != 3 {
+		t.Fatalf("expected one batch of the three current secrets, got %v", client.batchCalls)
+	}
+	for i, want := range []string{"admin", "s3cr3t", "token"} {
+		if results[i].Err != nil || string(results[i].Value) != want {
+			t.Errorf("[%d] unexpected result %q: %v", i, results[i].Value, results[i].Err)
+		}
+	}
+	if !provider.IsNotFound(results[3].Err) {
+		t.Errorf("expected a not found error, got %v", results[3].Err)
+	}
+	if results[4].Err != nil || string(results[4].Value) != "old" {
+		t.Errorf("unexpected result of the previous version %q: %v", results[4].Value, results[4].Err)
+	}
+	if client.ExecutionCounter != 1 {
+		t.Errorf("expected only the previous version to be read with GetSecretValue, got %d calls", client.ExecutionCounter)
+	}
+}
+
+func TestGetSecretsBatchDenied(t *testing.T) {
+	client := &batchClient{Client: fakesm.NewClient()}
+	client.batchFn = func(ids []string) (*BatchGetSecretValueOutput, error) {
+		return nil, awserr.New("AccessDeniedException", "not allowed to call BatchGetSecretValue", nil)
+	}
+	tc := makeValidSecretsManagerTestCaseCustom(func(smtc *secretsManagerTestCase) {
+		smtc.fakeClient = client.Client
+		smtc.apiOutput.SecretString = aws.String("value")
+	})
+	sm := SecretsManager{client: client}
+
+	results := sm.GetSecrets(context.Background(), []esv1alpha1.ExternalSecretDataRemoteRef{*tc.remoteRef})
+	if results[0].Err != nil || string(results[0].Value) != "value" {
+		t.Errorf("expected the secret to be read with GetSecretValue, got %q: %v", results[0].Value, results[0].Err)
+	}
+}
@@ -25,6 +25,9 @@ import (
 type Client struct {
 	ExecutionCounter int
 	valFn            map[string]func(*awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error)
+	createFn         func(*awssm.CreateSecretInput) (*awssm.CreateSecretOutput, error)
+	putFn            func(*awssm.PutSecretValueInput) (*awssm.PutSecretValueOutput, error)
+	listFn           func(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error)
 }
 
 // NewClient init a new fake client.
@@ -61,3 +64,39 @@ func (sm *Client) WithValue(in *awssm.GetSecretValueInput, val *awssm.GetSecretV
 		return val, err
 	}
 }
+
+func (sm *Client) CreateSecret(in *awssm.CreateSecretInput) (*awssm.CreateSecretOutput, error) {
+	if sm.createFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.createFn(in)
+}
+
+// WithCreateSecret sets the function called when a secret is created.
+func (sm *Client) WithCreateSecret(fn func(*awssm.CreateSecretInput) (*awssm.CreateSecretOutput, error)) {
+	sm.createFn = fn
+}
+
+func (sm *Client) PutSecretValue(in *awssm.PutSecretValueInput) (*awssm.PutSecretValueOutput, error) {
+	if sm.putFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.putFn(in)
+}
+
+// WithPutSecretValue sets the function called when a secret value is written.
+func (sm *Client) WithPutSecretValue(fn func(*awssm.PutSecretValueInput) (*awssm.PutSecretValueOutput, error)) {
+	sm.putFn = fn
+}
+
+func (sm *Client) ListSecrets(in *awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error) {
+	if sm.listFn == nil {
+		return nil, fmt.Errorf("test case not found")
+	}
+	return sm.listFn(in)
+}
+
+// WithListSecrets sets the function called when secrets are listed.
+func (sm *Client) WithListSecrets(fn func(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error)) {
+	sm.listFn = fn
+}
@@ -17,48 +17,77 @@ package secretsmanager
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/tidwall/gjson"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/aws/util"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 // SecretsManager is a provider for AWS SecretsManager.
 type SecretsManager struct {
+	sess   client.ConfigProvider
 	client SMInterface
-	cache  map[string]*awssm.GetSecretValueOutput
+	// cache holds the fetched secret values, it is read by concurrent GetSecret calls
+	// and reset after fetchCacheTTL
+	cacheMutex   sync.Mutex
+	cache        map[string]*awssm.GetSecretValueOutput
+	cacheExpires time.Time
 }
 
+// fetchCacheTTL bounds how long the fetched secret values are reused. The cache only
+// deduplicates the reads of a single sync, a client that is shared between syncs
+// by the client cache must not serve outdated values.
+const fetchCacheTTL = 10 * time.Second
+
+// currentVersionStage is the version stage of the current version of a secret.
+const currentVersionStage = "AWSCURRENT"
+
 // SMInterface is a subset of the smiface api.
 // see: https://docs.aws.amazon.com/sdk-for-go/api/service/secretsmanager/secretsmanageriface/
 type SMInterface interface {
 	GetSecretValue(*awssm.GetSecretValueInput) (*awssm.GetSecretValueOutput, error)
+	CreateSecret(*awssm.CreateSecretInput) (*awssm.CreateSecretOutput, error)
+	PutSecretValue(*awssm.PutSecretValueInput) (*awssm.PutSecretValueOutput, error)
+	ListSecrets(*awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error)
 }
 
+// SMBatchInterface is implemented by the SMInterface clients that can read
+// many secrets with a single BatchGetSecretValue call.
+type SMBatchInterface interface {
+	BatchGetSecretValue(*BatchGetSecretValueInput) (*BatchGetSecretValueOutput, error)
+}
+
+var _ provider.SecretsWriter = &SecretsManager{}
+var _ provider.SecretsBatchReader = &SecretsManager{}
+
 var log = ctrl.Log.WithName("provider").WithName("aws").WithName("secretsmanager")
 
 // New creates a new SecretsManager client.
 func New(sess client.ConfigProvider) (*SecretsManager, error) {
 	return &SecretsManager{
-		client: awssm.New(sess),
+		sess:   sess,
+		client: sdkClient{awssm.New(sess)},
 		cache:  make(map[string]*awssm.GetSecretValueOutput),
 	}, nil
 }
 
 func (sm *SecretsManager) fetch(_ context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (*awssm.GetSecretValueOutput, error) {
-	ver := "AWSCURRENT"
-	if ref.Version != "" {
-		ver = ref.Version
-	}
+	ver := versionStage(ref)
 	log.Info("fetching secret value", "key", ref.Key, "version", ver)
 
-	cacheKey := fmt.Sprintf("%s#%s", ref.Key, ver)
-	if secretOut, found := sm.cache[cacheKey]; found {
+	if secretOut, found := sm.cached(ref.Key, ver); found {
 		log.Info("found secret in cache", "key", ref.Key, "version", ver)
 		return secretOut, nil
 	}
@@ -69,17 +98,118 @@ func (sm *SecretsManager) fetch(_ context.Context, ref esv1alpha1.ExternalSecret
 	if err != nil {
 		return nil, err
 	}
-	sm.cache[cacheKey] = secretOut
-
+	sm.addToCache(ref.Key, ver, secretOut)
 	return secretOut, nil
 }
 
+// versionStage returns the version stage the ref reads, AWSCURRENT if it has no version.
+func versionStage(ref esv1alpha1.ExternalSecretDataRemoteRef) string {
+	if ref.Version != "" {
+		return ref.Version
+	}
+	return currentVersionStage
+}
+
+// cached returns the fetched value of the secret version, the cache is reset when it expired.
+func (sm *SecretsManager) cached(key, ver string) (*awssm.GetSecretValueOutput, bool) {
+	sm.cacheMutex.Lock()
+	defer sm.cacheMutex.Unlock()
+	if now := time.Now(); now.After(sm.cacheExpires) {
+		sm.cache = make(map[string]*awssm.GetSecretValueOutput)
+		sm.cacheExpires = now.Add(fetchCacheTTL)
+	}
+	secretOut, found := sm.cache[fmt.Sprintf("%s#%s", key, ver)]
+	return secretOut, found
+}
+
+func (sm *SecretsManager) addToCache(key, ver string, secretOut *awssm.GetSecretValueOutput) {
+	sm.cacheMutex.Lock()
+	defer sm.cacheMutex.Unlock()
+	sm.cache[fmt.Sprintf("%s#%s", key, ver)] = secretOut
+}
+
+// GetSecrets reads the current version of the secrets of the refs with
+// BatchGetSecretValue, twenty at a time, and adds them to the fetch cache.
+// BatchGetSecretValue can't read other version stages, the refs that set one
+// are read with GetSecretValue, as are all refs when the role is not allowed
+// to call BatchGetSecretValue.
+func (sm *SecretsManager) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		if versionStage(ref) != currentVersionStage || seen[ref.Key] {
+			continue
+		}
+		seen[ref.Key] = true
+		if _, found := sm.cached(ref.Key, currentVersionStage); !found {
+			ids = append(ids, ref.Key)
+		}
+	}
+	errs := sm.batchFetch(ids)
+
+	results := make([]provider.SecretResult, len(refs))
+	for i, ref := range refs {
+		if err, ok := errs[ref.Key]; ok && versionStage(ref) == currentVersionStage {
+			results[i].Err = err
+			continue
+		}
+		results[i].Value, results[i].Err = sm.GetSecret(ctx, ref)
+	}
+	return results
+}
+
+// batchFetch adds the current version of the secrets to the fetch cache and
+// returns the categorized errors of the secrets that couldn't be read.
+// Secrets that are neither in the values nor in the errors of the response,
+// e.g. because the id is a partial ARN, are left to GetSecretValue.
+func (sm *SecretsManager) batchFetch(ids []string) map[string]error {
+	errs := make(map[string]error)
+	batchClient, ok := sm.client.(SMBatchInterface)
+	if !ok || len(ids) == 0 {
+		return errs
+	}
+	log.Info("fetching secret values", "keys", ids)
+	for start := 0; start < len(ids); start += maxSecretsPerBatch {
+		end := start + maxSecretsPerBatch
+		if end > len(ids) {
+			end = len(ids)
+		}
+		out, err := batchClient.BatchGetSecretValue(&BatchGetSecretValueInput{
+			SecretIdList: aws.StringSlice(ids[start:end]),
+		})
+		if err != nil {
+			err = util.CategorizeErr(err)
+			if provider.GetErrorCategory(err) == provider.ErrorCategoryPermissionDenied {
+				log.Info("BatchGetSecretValue is not allowed, reading the secrets one by one", "error", err)
+				return errs
+			}
+			for _, id := range ids[start:end] {
+				errs[id] = err
+			}
+			continue
+		}
+		for _, id := range ids[start:end] {
+			for _, secretOut := range out.SecretValues {
+				if id == aws.StringValue(secretOut.Name) || id == aws.StringValue(secretOut.ARN) {
+					sm.addToCache(id, currentVersionStage, secretOut)
+				}
+			}
+		}
+		for _, apiErr := range out.Errors {
+			id := aws.StringValue(apiErr.SecretId)
+			errs[id] = util.CategorizeErr(awserr.New(aws.StringValue(apiErr.ErrorCode), aws.StringValue(apiErr.Message), nil))
+		}
+	}
+	return errs
+}
+
 // GetSecret returns a single secret from the provider.
 func (sm *SecretsManager) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	secretOut, err := sm.fetch(ctx, ref)
 	if err != nil {
-		return nil, util.SanitizeErr(err)
+		return nil, util.CategorizeErr(err)
 	}
+	provider.RecordVersion(ctx, ref.Key, ref.Version, aws.StringValue(secretOut.VersionId))
 	if ref.Property == "" {
 		if secretOut.SecretString != nil {
 			return []byte(*secretOut.SecretString), nil
@@ -99,7 +229,7 @@ func (sm *SecretsManager) GetSecret(ctx context.Context, ref esv1alpha1.External
 
 	val := gjson.Get(payload, ref.Property)
 	if !val.Exists() {
-		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+		return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 	}
 	return []byte(val.String()), nil
 }
@@ -129,6 +259,125 @@ func (sm *SecretsManager) GetSecretMap(ctx context.Context, ref esv1alpha1.Exter
 	return secretData, nil
 }
 
+// GetAllSecrets returns the current value of all secrets that match the find criteria.
+// The path is used as name prefix filter of ListSecrets, the name regexp and the tags
+// are matched against the listed secrets.
+func (sm *SecretsManager) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	matcher, err := utils.NewFindMatcher(ref)
+	if err != nil {
+		return nil, err
+	}
+	input := &awssm.ListSecretsInput{}
+	if ref.Path != nil && *ref.Path != "" {
+		input.Filters = []*awssm.Filter{
+			{
+				Key:    aws.String(awssm.FilterNameStringTypeName),
+				Values: []*string{ref.Path},
+			},
+		}
+	}
+
+	secrets := make(map[string][]byte)
+	for {
+		out, err := sm.client.ListSecrets(input)
+		if err != nil {
+			return nil, util.CategorizeErr(err)
+		}
+		for _, entry := range out.SecretList {
+			name := aws.StringValue(entry.Name)
+			if !matcher.MatchName(name) || !matcher.MatchTags(tagMap(entry.Tags)) {
+				continue
+			}
+			data, err := sm.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: name})
+			if err != nil {
+				return nil, err
+			}
+			secrets[name] = data
+		}
+		if out.NextToken == nil {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+	return secrets, nil
+}
+
+func tagMap(tags []*awssm.Tag) map[string]string {
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return m
+}
+
+// PushSecret writes the value to the secret referenced by remoteRef.
+// The secret is created if it does not exist. If a property is given
+// the value is set as property of the JSON object stored in the secret.
+// No new version is created if the secret already contains the value.
+func (sm *SecretsManager) PushSecret(ctx context.Context, value []byte, remoteRef esv1alpha1.PushSecretRemoteRef) error {
+	log.Info("pushing secret value", "key", remoteRef.RemoteKey)
+	ver := currentVersionStage
+	secretOut, err := sm.client.GetSecretValue(&awssm.GetSecretValueInput{
+		SecretId:     &remoteRef.RemoteKey,
+		VersionStage: &ver,
+	})
+	var aerr awserr.Error
+	exists := true
+	if errors.As(err, &aerr) && aerr.Code() == awssm.ErrCodeResourceNotFoundException {
+		exists = false
+	} else if err != nil {
+		return util.CategorizeErr(err)
+	}
+
+	var current string
+	if exists && secretOut.SecretString != nil {
+		current = *secretOut.SecretString
+	}
+	if exists && secretOut.SecretBinary != nil {
+		current = string(secretOut.SecretBinary)
+	}
+
+	payload := string(value)
+	if remoteRef.Property != "" {
+		payload, err = util.SetJSONProperty(current, remoteRef.Property, value)
+		if err != nil {
+			return fmt.Errorf("unable to set property %s of secret %s: %w", remoteRef.Property, remoteRef.RemoteKey, err)
+		}
+	}
+
+	// the fetch cache must not serve the previous value
+	sm.cacheMutex.Lock()
+	delete(sm.cache, fmt.Sprintf("%s#%s", remoteRef.RemoteKey, ver))
+	sm.cacheMutex.Unlock()
+
+	if !exists {
+		_, err = sm.client.CreateSecret(&awssm.CreateSecretInput{
+			Name:         &remoteRef.RemoteKey,
+			SecretString: &payload,
+		})
+		if err != nil {
+			return util.CategorizeErr(err)
+		}
+		return nil
+	}
+	if payload == current {
+		return nil
+	}
+	_, err = sm.client.PutSecretValue(&awssm.PutSecretValueInput{
+		SecretId:     &remoteRef.RemoteKey,
+		SecretString: &payload,
+	})
+	if err != nil {
+		return util.CategorizeErr(err)
+	}
+	return nil
+}
+
+// Validate checks if credentials for SecretsManager can be retrieved.
+func (sm *SecretsManager) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	return util.ValidateCredentials(sm.sess, awssm.ServiceName)
+}
+
 func (sm *SecretsManager) Close(ctx context.Context) error {
 	return nil
 }
@@ -19,12 +19,15 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	awssm "github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/google/go-cmp/cmp"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakesm "github.com/external-secrets/external-secrets/pkg/provider/aws/secretsmanager/fake"
 )
 
@@ -183,6 +186,28 @@ func TestSecretsManagerGetSecret(t *testing.T) {
 		}
 	}
 }
+func TestSecretsManagerGetSecretNotFound(t *testing.T) {
+	setNotFound := func(smtc *secretsManagerTestCase) {
+		smtc.apiErr = awserr.New(awssm.ErrCodeResourceNotFoundException, "Secrets Manager can't find the specified secret.", nil)
+	}
+	for k, v := range []struct {
+		tc           *secretsManagerTestCase
+		wantNotFound bool
+	}{
+		{tc: makeValidSecretsManagerTestCaseCustom(setNotFound), wantNotFound: true},
+		{tc: makeValidSecretsManagerTestCaseCustom(setAPIErr), wantNotFound: false},
+	} {
+		sm := SecretsManager{
+			cache:  make(map[string]*awssm.GetSecretValueOutput),
+			client: v.tc.fakeClient,
+		}
+		_, err := sm.GetSecret(context.Background(), *v.tc.remoteRef)
+		if provider.IsNotFound(err) != v.wantNotFound {
+			t.Errorf("[%d] unexpected not found error: %v, expected not found: %t", k, err, v.wantNotFound)
+		}
+	}
+}
+
 func TestCaching(t *testing.T) {
 	fakeClient := fakesm.NewClient()
 
@@ -236,6 +261,49 @@ func TestCaching(t *testing.T) {
 	}
 }
 
+func TestCacheExpires(t *testing.T) {
+	fakeClient := fakesm.NewClient()
+	tc := makeValidSecretsManagerTestCaseCustom(func(smtc *secretsManagerTestCase) {
+		smtc.fakeClient = fakeClient
+	})
+	sm := SecretsManager{
+		cache:  make(map[string]*awssm.GetSecretValueOutput),
+		client: fakeClient,
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := sm.GetSecret(context.Background(), *tc.remoteRef); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if fakeClient.ExecutionCounter != 1 {
+		t.Fatalf("expected the second read to be served from the cache, got %d calls", fakeClient.ExecutionCounter)
+	}
+	sm.cacheExpires = time.Now().Add(-time.Second)
+	if _, err := sm.GetSecret(context.Background(), *tc.remoteRef); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fakeClient.ExecutionCounter != 2 {
+		t.Errorf("expected the expired cache to be reset, got %d calls", fakeClient.ExecutionCounter)
+	}
+}
+
+func TestGetSecretRecordsVersion(t *testing.T) {
+	tc := makeValidSecretsManagerTestCaseCustom(func(smtc *secretsManagerTestCase) {
+		smtc.apiOutput.VersionId = aws.String("a1b2c3")
+	})
+	sm := SecretsManager{
+		cache:  make(map[string]*awssm.GetSecretValueOutput),
+		client: tc.fakeClient,
+	}
+	ctx, rec := provider.WithVersionRecorder(context.Background())
+	if _, err := sm.GetSecret(ctx, *tc.remoteRef); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rec.Version(tc.remoteRef.Key, tc.remoteRef.Version); got != "a1b2c3" {
+		t.Errorf("unexpected version: %q", got)
+	}
+}
+
 func TestGetSecretMap(t *testing.T) {
 	// good case: default version & deserialization
 	setDeserialization := func(smtc *secretsManagerTestCase) {
@@ -298,3 +366,212 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestPushSecret(t *testing.T) {
+	notFound := awserr.New(awssm.ErrCodeResourceNotFoundException, "not found", nil)
+
+	tbl := []struct {
+		name         string
+		current      *awssm.GetSecretValueOutput
+		getErr       error
+		putErr       error
+		value        string
+		property     string
+		expectCreate *string
+		expectPut    *string
+		expectError  string
+	}{
+		{
+			name:         "create if not found",
+			getErr:       notFound,
+			value:        "bar",
+			expectCreate: aws.String("bar"),
+		},
+		{
+			name:         "create property if not found",
+			getErr:       notFound,
+			value:        "bar",
+			property:     "foo",
+			expectCreate: aws.String(`{"foo":"bar"}`),
+		},
+		{
+			name:      "put new value",
+			current:   &awssm.GetSecretValueOutput{SecretString: aws.String("old")},
+			value:     "bar",
+			expectPut: aws.String("bar"),
+		},
+		{
+			name:      "merge property",
+			current:   &awssm.GetSecretValueOutput{SecretString: aws.String(`{"other":"val"}`)},
+			value:     "bar",
+			property:  "foo",
+			expectPut: aws.String(`{"foo":"bar","other":"val"}`),
+		},
+		{
+			name:     "skip unchanged value",
+			current:  &awssm.GetSecretValueOutput{SecretString: aws.String(`{"foo":"bar"}`)},
+			value:    "bar",
+			property: "foo",
+		},
+		{
+			name:        "invalid json with property",
+			current:     &awssm.GetSecretValueOutput{SecretString: aws.String("------")},
+			value:       "bar",
+			property:    "foo",
+			expectError: "unable to set property foo of secret /baz",
+		},
+		{
+			name:        "get error",
+			getErr:      fmt.Errorf("oh no"),
+			value:       "bar",
+			expectError: "oh no",
+		},
+		{
+			name:        "put error",
+			current:     &awssm.GetSecretValueOutput{SecretString: aws.String("old")},
+			putErr:      fmt.Errorf("oh no"),
+			value:       "bar",
+			expectError: "oh no",
+		},
+	}
+
+	for _, c := range tbl {
+		var created, put *string
+		fakeClient := fakesm.NewClient()
+		fakeClient.WithValue(makeValidAPIInput(), c.current, c.getErr)
+		fakeClient.WithCreateSecret(func(in *awssm.CreateSecretInput) (*awssm.CreateSecretOutput, error) {
+			created = in.SecretString
+			return &awssm.CreateSecretOutput{}, nil
+		})
+		fakeClient.WithPutSecretValue(func(in *awssm.PutSecretValueInput) (*awssm.PutSecretValueOutput, error) {
+			put = in.SecretString
+			return &awssm.PutSecretValueOutput{}, c.putErr
+		})
+		sm := SecretsManager{
+			cache:  make(map[string]*awssm.GetSecretValueOutput),
+			client: fakeClient,
+		}
+		err := sm.PushSecret(context.Background(), []byte(c.value), esv1alpha1.PushSecretRemoteRef{
+			RemoteKey: "/baz",
+			Property:  c.property,
+		})
+		if !ErrorContains(err, c.expectError) {
+			t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+		}
+		if c.expectError != "" {
+			continue
+		}
+		if !cmp.Equal(c.expectCreate, created) {
+			t.Errorf("[%s] unexpected created secret: expected %v, got %v", c.name, aws.StringValue(c.expectCreate), aws.StringValue(created))
+		}
+		if !cmp.Equal(c.expectPut, put) {
+			t.Errorf("[%s] unexpected secret value: expected %v, got %v", c.name, aws.StringValue(c.expectPut), aws.StringValue(put))
+		}
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	listed := []*awssm.SecretListEntry{
+		{Name: aws.String("app/db"), Tags: []*awssm.Tag{{Key: aws.String("env"), Value: aws.String("prod")}}},
+		{Name: aws.String("app/api"), Tags: []*awssm.Tag{{Key: aws.String("env"), Value: aws.String("dev")}}},
+		{Name: aws.String("other/db")},
+	}
+
+	tbl := []struct {
+		name         string
+		find         esv1alpha1.ExternalSecretFind
+		listErr      error
+		expectFilter []*awssm.Filter
+		expectData   map[string][]byte
+		expectError  string
+	}{
+		{
+			name: "match name regexp",
+			find: esv1alpha1.ExternalSecretFind{
+				Name: &esv1alpha1.FindName{RegExp: "db$"},
+			},
+			expectData: map[string][]byte{
+				"app/db":   []byte("app/db-value"),
+				"other/db": []byte("other/db-value"),
+			},
+		},
+		{
+			name: "filter by path",
+			find: esv1alpha1.ExternalSecretFind{
+				Path: aws.String("app/"),
+			},
+			expectFilter: []*awssm.Filter{
+				{Key: aws.String(awssm.FilterNameStringTypeName), Values: []*string{aws.String("app/")}},
+			},
+			expectData: map[string][]byte{
+				"app/db":  []byte("app/db-value"),
+				"app/api": []byte("app/api-value"),
+			},
+		},
+		{
+			name: "match tags",
+			find: esv1alpha1.ExternalSecretFind{
+				Tags: map[string]string{"env": "prod"},
+			},
+			expectData: map[string][]byte{
+				"app/db": []byte("app/db-value"),
+			},
+		},
+		{
+			name: "invalid regexp",
+			find: esv1alpha1.ExternalSecretFind{
+				Name: &esv1alpha1.FindName{RegExp: "(db"},
+			},
+			expectError: "invalid name regexp",
+		},
+		{
+			name:        "list error",
+			listErr:     fmt.Errorf("oh no"),
+			expectError: "oh no",
+		},
+	}
+
+	for _, c := range tbl {
+		var filters []*awssm.Filter
+		fakeClient := fakesm.NewClient()
+		fakeClient.WithListSecrets(func(in *awssm.ListSecretsInput) (*awssm.ListSecretsOutput, error) {
+			filters = in.Filters
+			if c.listErr != nil {
+				return nil, c.listErr
+			}
+			// the name filter is applied on server side
+			out := &awssm.ListSecretsOutput{}
+			for _, entry := range listed {
+				if len(in.Filters) == 0 || strings.HasPrefix(*entry.Name, *in.Filters[0].Values[0]) {
+					out.SecretList = append(out.SecretList, entry)
+				}
+			}
+			return out, nil
+		})
+		for _, entry := range listed {
+			fakeClient.WithValue(&awssm.GetSecretValueInput{
+				SecretId:     entry.Name,
+				VersionStage: aws.String("AWSCURRENT"),
+			}, &awssm.GetSecretValueOutput{
+				SecretString: aws.String(*entry.Name + "-value"),
+			}, nil)
+		}
+		sm := SecretsManager{
+			cache:  make(map[string]*awssm.GetSecretValueOutput),
+			client: fakeClient,
+		}
+		data, err := sm.GetAllSecrets(context.Background(), c.find)
+		if !ErrorContains(err, c.expectError) {
+			t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+		}
+		if c.expectError != "" {
+			continue
+		}
+		if !cmp.Equal(c.expectFilter, filters) {
+			t.Errorf("[%s] unexpected filters: %s", c.name, cmp.Diff(c.expectFilter, filters))
+		}
+		if !cmp.Equal(c.expectData, data) {
+			t.Errorf("[%s] unexpected secrets: %s", c.name, cmp.Diff(c.expectData, data))
+		}
+	}
+}
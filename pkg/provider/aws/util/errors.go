@@ -16,7 +16,13 @@ package util
 
 import (
 	"errors"
+	"net/http"
 	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	"github.com/external-secrets/external-secrets/pkg/provider"
 )
 
 var regexReqID = regexp.MustCompile(`request id: (\S+)`)
@@ -27,3 +33,35 @@ var regexReqID = regexp.MustCompile(`request id: (\S+)`)
 func SanitizeErr(err error) error {
 	return errors.New(string(regexReqID.ReplaceAll([]byte(err.Error()), nil)))
 }
+
+// CategorizeErr sanitizes err and categorizes it by the code of the AWS error.
+func CategorizeErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	sanitized := SanitizeErr(err)
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return sanitized
+	}
+	switch aerr.Code() {
+	case "ResourceNotFoundException", "ParameterNotFound", "ParameterVersionNotFound":
+		return provider.NewNotFoundError(sanitized)
+	case "AccessDeniedException", "AccessDenied":
+		return provider.NewPermissionDeniedError(sanitized)
+	case "UnrecognizedClientException", "InvalidClientTokenId", "ExpiredTokenException", "ExpiredToken",
+		"InvalidSignatureException", "IncompleteSignature", "MissingAuthenticationToken":
+		return provider.NewUnauthenticatedError(sanitized)
+	case "ThrottlingException", "Throttling", "TooManyRequestsException", "RequestLimitExceeded":
+		return provider.NewRateLimitedError(sanitized)
+	case "InvalidParameterException", "InvalidRequestException", "ValidationException":
+		return provider.NewInvalidRefError(sanitized)
+	case "InternalServiceError", "InternalServerError", "InternalFailure", "ServiceUnavailable", request.ErrCodeRequestError:
+		return provider.NewTransientError(sanitized)
+	}
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() >= http.StatusInternalServerError {
+		return provider.NewTransientError(sanitized)
+	}
+	return sanitized
+}
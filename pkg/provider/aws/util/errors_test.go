@@ -17,7 +17,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/external-secrets/external-secrets/pkg/provider"
 )
 
 func TestSanitize(t *testing.T) {
@@ -40,3 +43,28 @@ func TestSanitize(t *testing.T) {
 		assert.Equal(t, c.expected, out.Error())
 	}
 }
+
+func TestCategorizeErr(t *testing.T) {
+	tbl := []struct {
+		err      error
+		expected provider.ErrorCategory
+	}{
+		{err: awserr.New("ResourceNotFoundException", "secret not found", nil), expected: provider.ErrorCategoryNotFound},
+		{err: awserr.New("ParameterNotFound", "parameter not found", nil), expected: provider.ErrorCategoryNotFound},
+		{err: awserr.New("AccessDeniedException", "not authorized", nil), expected: provider.ErrorCategoryPermissionDenied},
+		{err: awserr.New("ExpiredTokenException", "token expired", nil), expected: provider.ErrorCategoryUnauthenticated},
+		{err: awserr.New("ThrottlingException", "rate exceeded", nil), expected: provider.ErrorCategoryRateLimited},
+		{err: awserr.New("InvalidParameterException", "invalid name", nil), expected: provider.ErrorCategoryInvalidRef},
+		{err: awserr.New("RequestError", "send request failed", nil), expected: provider.ErrorCategoryTransient},
+		{err: awserr.NewRequestFailure(awserr.New("SomethingWentWrong", "oops", nil), 503, "df34"), expected: provider.ErrorCategoryTransient},
+		{err: awserr.New("DecryptionFailure", "can't decrypt", nil), expected: provider.ErrorCategoryUnknown},
+		{err: errors.New("some generic error"), expected: provider.ErrorCategoryUnknown},
+	}
+
+	for _, c := range tbl {
+		out := CategorizeErr(c.err)
+		assert.Equal(t, c.expected, provider.GetErrorCategory(out), c.err.Error())
+		assert.NotContains(t, out.Error(), "request id: ")
+	}
+	assert.Nil(t, CategorizeErr(nil))
+}
@@ -0,0 +1,38 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SetJSONProperty sets the top level property of the JSON object
+// in payload to value and returns the resulting JSON document.
+// An empty payload is treated as an empty object.
+func SetJSONProperty(payload, property string, value []byte) (string, error) {
+	kv := make(map[string]interface{})
+	if payload != "" {
+		if err := json.Unmarshal([]byte(payload), &kv); err != nil {
+			return "", fmt.Errorf("unable to unmarshal secret: %w", err)
+		}
+	}
+	kv[property] = string(value)
+	out, err := json.Marshal(kv)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal secret: %w", err)
+	}
+	return string(out), nil
+}
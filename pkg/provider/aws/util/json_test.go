@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetJSONProperty(t *testing.T) {
+	tbl := []struct {
+		payload  string
+		property string
+		value    string
+		expected string
+		err      bool
+	}{
+		{
+			payload:  "",
+			property: "foo",
+			value:    "bar",
+			expected: `{"foo":"bar"}`,
+		},
+		{
+			payload:  `{"foo":"bar","nested":{"baz":1}}`,
+			property: "foo",
+			value:    "baz",
+			expected: `{"foo":"baz","nested":{"baz":1}}`,
+		},
+		{
+			payload:  `{"foo":"bar"}`,
+			property: "other",
+			value:    "baz",
+			expected: `{"foo":"bar","other":"baz"}`,
+		},
+		{
+			payload:  "not-json",
+			property: "foo",
+			value:    "bar",
+			err:      true,
+		},
+	}
+
+	for _, c := range tbl {
+		out, err := SetJSONProperty(c.payload, c.property, []byte(c.value))
+		assert.Equal(t, c.err, err != nil)
+		assert.Equal(t, c.expected, out)
+	}
+}
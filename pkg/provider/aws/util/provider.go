@@ -16,7 +16,10 @@ package util
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws/client"
+
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 )
 
 const (
@@ -24,6 +27,7 @@ const (
 	errMissingStoreSpec = "store is missing spec"
 	errMissingProvider  = "storeSpec is missing provider"
 	errInvalidProvider  = "invalid provider spec. Missing AWS field in store %s"
+	errInvalidCreds     = "unable to retrieve credentials: %w"
 )
 
 // GetAWSProvider does the necessary nil checks on the generic store
@@ -45,3 +49,19 @@ func GetAWSProvider(store esv1alpha1.GenericStore) (*esv1alpha1.AWSProvider, err
 	}
 	return prov, nil
 }
+
+// ValidateCredentials checks if credentials can be retrieved for the given service.
+// This resolves the credential chain, e.g. an AssumeRole or AssumeRoleWithWebIdentity call.
+func ValidateCredentials(sess client.ConfigProvider, serviceName string) (provider.ValidationResult, error) {
+	if sess == nil {
+		return provider.ValidationResultUnknown, nil
+	}
+	cfg := sess.ClientConfig(serviceName)
+	if cfg.Config == nil || cfg.Config.Credentials == nil {
+		return provider.ValidationResultUnknown, nil
+	}
+	if _, err := cfg.Config.Credentials.Get(); err != nil {
+		return provider.ValidationResultError, fmt.Errorf(errInvalidCreds, SanitizeErr(err))
+	}
+	return provider.ValidationResultReady, nil
+}
@@ -68,6 +68,13 @@ func (m *AzureMock) AddSecretWithVersion(vaultBaseURL, secretName, secretVersion
 	m.knownSecrets[vaultBaseURL][secretName].lastVersion = secretVersion
 }
 
+// AddSecretWithTags adds a secret with the given tags.
+func (m *AzureMock) AddSecretWithTags(vaultBaseURL, secretName, secretContent string, tags map[string]*string, enabled bool) string {
+	uid := m.AddSecret(vaultBaseURL, secretName, secretContent, enabled)
+	m.knownSecrets[vaultBaseURL][secretName].item.Tags = tags
+	return uid
+}
+
 func newValidSecretBundle(secretBundleID, secretContent string) keyvault.SecretBundle {
 	return keyvault.SecretBundle{
 		Value: &secretContent,
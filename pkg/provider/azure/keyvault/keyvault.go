@@ -17,20 +17,24 @@ package keyvault
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
+	"github.com/Azure/go-autorest/autorest"
 	kvauth "github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const (
@@ -41,6 +45,10 @@ const (
 // Provider satisfies the provider interface.
 type Provider struct{}
 
+// ConcurrentGetSecret marks the clients as safe for concurrent use:
+// GetSecret only reads the fields set by NewClient and the autorest client is shared safely.
+func (p *Provider) ConcurrentGetSecret() {}
+
 // interface to keyvault.BaseClient.
 type SecretClient interface {
 	GetKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string) (result keyvault.KeyBundle, err error)
@@ -69,6 +77,41 @@ func (p *Provider) NewClient(ctx context.Context, store esv1alpha1.GenericStore,
 	return newClient(ctx, store, kube, namespace)
 }
 
+// ValidateStore checks the Azure Key Vault specific part of the store spec.
+func (p *Provider) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "azurekv")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.AzureKV == nil {
+		return field.ErrorList{field.Required(fldPath, "missing Azure Key Vault provider")}
+	}
+	spec := storeSpec.Provider.AzureKV
+	allErrs := field.ErrorList{}
+	if spec.VaultURL == nil || *spec.VaultURL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("vaultUrl"), ""))
+	}
+	if spec.AuthType == nil || *spec.AuthType != esv1alpha1.ServicePrincipal {
+		return allErrs
+	}
+	if spec.TenantID == nil || *spec.TenantID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tenantId"), "tenantId is required for ServicePrincipal auth"))
+	}
+	authPath := fldPath.Child("authSecretRef")
+	if spec.AuthSecretRef == nil {
+		return append(allErrs, field.Required(authPath, "authSecretRef is required for ServicePrincipal auth"))
+	}
+	if spec.AuthSecretRef.ClientID == nil {
+		allErrs = append(allErrs, field.Required(authPath.Child("clientId"), ""))
+	} else {
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, *spec.AuthSecretRef.ClientID, authPath.Child("clientId"))...)
+	}
+	if spec.AuthSecretRef.ClientSecret == nil {
+		allErrs = append(allErrs, field.Required(authPath.Child("clientSecret"), ""))
+	} else {
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, *spec.AuthSecretRef.ClientSecret, authPath.Child("clientSecret"))...)
+	}
+	return allErrs
+}
+
 func newClient(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (provider.SecretsClient, error) {
 	anAzure := &Azure{
 		kube:      kube,
@@ -107,14 +150,15 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretData
 		// https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#SecretBundle
 		secretResp, err := basicClient.GetSecret(context.Background(), a.vaultURL, secretName, version)
 		if err != nil {
-			return nil, err
+			return nil, parseError(err)
 		}
+		recordVersion(ctx, ref, secretResp.ID)
 		if ref.Property == "" {
 			return []byte(*secretResp.Value), nil
 		}
 		res := gjson.Get(*secretResp.Value, ref.Property)
 		if !res.Exists() {
-			return nil, fmt.Errorf("property %s does not exist in key %s", ref.Property, ref.Key)
+			return nil, provider.NewInvalidRefError(fmt.Errorf("property %s does not exist in key %s", ref.Property, ref.Key))
 		}
 		return []byte(res.String()), err
 	case "cert":
@@ -122,8 +166,9 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretData
 		// see: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#CertificateBundle
 		secretResp, err := basicClient.GetCertificate(context.Background(), a.vaultURL, secretName, version)
 		if err != nil {
-			return nil, err
+			return nil, parseError(err)
 		}
+		recordVersion(ctx, ref, secretResp.ID)
 		return *secretResp.Cer, nil
 	case "key":
 		// returns a KeyBundla that contains a jwk
@@ -131,7 +176,10 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretData
 		// see: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#KeyBundle
 		keyResp, err := basicClient.GetKey(context.Background(), a.vaultURL, secretName, version)
 		if err != nil {
-			return nil, err
+			return nil, parseError(err)
+		}
+		if keyResp.Key != nil {
+			recordVersion(ctx, ref, keyResp.Key.Kid)
 		}
 		return json.Marshal(keyResp.Key)
 	}
@@ -139,6 +187,17 @@ func (a *Azure) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretData
 	return nil, fmt.Errorf("unknown Azure Keyvault object Type for %s", secretName)
 }
 
+// recordVersion reports the version of the object the ref was resolved to,
+// which is the last segment of the object identifier, e.g. https://vault/secrets/name/version.
+func recordVersion(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef, id *string) {
+	if id == nil {
+		return
+	}
+	if i := strings.LastIndex(*id, "/"); i >= 0 {
+		provider.RecordVersion(ctx, ref.Key, ref.Version, (*id)[i+1:])
+	}
+}
+
 // Implements store.Client.GetSecretMap Interface.
 // New version of GetSecretMap.
 func (a *Azure) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
@@ -172,6 +231,49 @@ func (a *Azure) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretD
 	return nil, fmt.Errorf("unknown Azure Keyvault object Type for %s", secretName)
 }
 
+// GetAllSecrets returns the current version of all enabled secrets that match the find criteria.
+// Keys and certificates are not considered.
+func (a *Azure) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	matcher, err := utils.NewFindMatcher(ref)
+	if err != nil {
+		return nil, err
+	}
+	it, err := a.baseClient.GetSecretsComplete(ctx, a.vaultURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string][]byte)
+	for ; it.NotDone(); err = it.NextWithContext(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		item := it.Value()
+		if item.ID == nil || (item.Attributes != nil && item.Attributes.Enabled != nil && !*item.Attributes.Enabled) {
+			continue
+		}
+		name := (*item.ID)[strings.LastIndex(*item.ID, "/")+1:]
+		tags := make(map[string]string, len(item.Tags))
+		for k, v := range item.Tags {
+			if v != nil {
+				tags[k] = *v
+			}
+		}
+		if !matcher.MatchName(name) || !matcher.MatchTags(tags) {
+			continue
+		}
+		data, err := a.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: name})
+		if err != nil {
+			return nil, err
+		}
+		secrets[name] = data
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
 func (a *Azure) setAzureClientWithManagedIdentity() (bool, error) {
 	spec := *a.store.GetSpec().Provider.AzureKV
 
@@ -264,10 +366,34 @@ func (a *Azure) secretKeyRef(ctx context.Context, namespace string, secretRef sm
 	return value, nil
 }
 
+// Validate requests the first secret of the vault listing, it returns the
+// secret attributes without the value and needs only the list permission
+// of the access policy.
+func (a *Azure) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	maxResults := int32(1)
+	if _, err := a.baseClient.GetSecretsComplete(ctx, a.vaultURL, &maxResults); err != nil {
+		return provider.ProbeResult(parseError(err))
+	}
+	return provider.ValidationResultReady, nil
+}
+
 func (a *Azure) Close(ctx context.Context) error {
 	return nil
 }
 
+// parseError categorizes a keyvault error by the status code of its response.
+func parseError(err error) error {
+	var detailedErr autorest.DetailedError
+	if !errors.As(err, &detailedErr) {
+		return err
+	}
+	statusCode, ok := detailedErr.StatusCode.(int)
+	if !ok {
+		return err
+	}
+	return provider.FromHTTPStatus(statusCode, err)
+}
+
 func getObjType(ref esv1alpha1.ExternalSecretDataRemoteRef) (string, string) {
 	objectType := defaultObjType
 
@@ -17,15 +17,18 @@ package keyvault
 import (
 	context "context"
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
 	tassert "github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fake "github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault/fake"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
 )
@@ -188,6 +191,32 @@ func TestGetSecretWithoutVersion(t *testing.T) {
 	tassert.Equal(t, []byte("My Secret"), secret)
 }
 
+func TestGetSecretNotFound(t *testing.T) {
+	testAzure, azureMock := newAzure()
+	ctx := context.Background()
+	rf := esv1alpha1.ExternalSecretDataRemoteRef{
+		Key: "missing",
+	}
+	notFound := autorest.DetailedError{StatusCode: http.StatusNotFound, Message: "SecretNotFound"}
+	azureMock.On("GetSecret", ctx, testAzure.vaultURL, "missing", "").Return(keyvault.SecretBundle{}, notFound)
+
+	_, err := testAzure.GetSecret(ctx, rf)
+	tassert.True(t, provider.IsNotFound(err), "the return err should be a not found error")
+}
+
+func TestGetSecretForbidden(t *testing.T) {
+	testAzure, azureMock := newAzure()
+	ctx := context.Background()
+	rf := esv1alpha1.ExternalSecretDataRemoteRef{
+		Key: "cert/forbidden",
+	}
+	forbidden := autorest.DetailedError{StatusCode: http.StatusForbidden, Message: "Forbidden"}
+	azureMock.On("GetCertificate", ctx, testAzure.vaultURL, "forbidden", "").Return(keyvault.CertificateBundle{}, forbidden)
+
+	_, err := testAzure.GetSecret(ctx, rf)
+	tassert.Equal(t, provider.ErrorCategoryPermissionDenied, provider.GetErrorCategory(err))
+}
+
 func TestGetSecretMap(t *testing.T) {
 	testAzure, azureMock := newAzure()
 	ctx := context.Background()
@@ -210,3 +239,46 @@ func newKVJWK(b []byte) *keyvault.JSONWebKey {
 	}
 	return &key
 }
+
+func TestGetAllSecrets(t *testing.T) {
+	testAzure, azureMock := newAzure()
+	ctx := context.Background()
+	prod := "prod"
+	dev := "dev"
+	azureMock.AddSecretWithTags(testAzure.vaultURL, "db-password", "db", map[string]*string{"env": &prod}, true)
+	azureMock.AddSecretWithTags(testAzure.vaultURL, "api-password", "api", map[string]*string{"env": &prod}, true)
+	azureMock.AddSecretWithTags(testAzure.vaultURL, "db-user", "user", map[string]*string{"env": &dev}, true)
+	azureMock.AddSecretWithTags(testAzure.vaultURL, "db-disabled", "disabled", map[string]*string{"env": &prod}, false)
+	azureMock.ExpectsGetSecretsComplete(ctx, testAzure.vaultURL, nil)
+	azureMock.ExpectsGetSecret(ctx, testAzure.vaultURL, "db-password", "")
+
+	secrets, err := testAzure.GetAllSecrets(ctx, esv1alpha1.ExternalSecretFind{
+		Name: &esv1alpha1.FindName{RegExp: "^db-"},
+		Tags: map[string]string{"env": "prod"},
+	})
+	azureMock.AssertExpectations(t)
+	tassert.Nil(t, err, "the return err should be nil")
+	tassert.Equal(t, map[string][]byte{"db-password": []byte("db")}, secrets)
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+	maxResults := int32(1)
+	for _, c := range []struct {
+		name      string
+		listErr   error
+		expectRes provider.ValidationResult
+		expectErr bool
+	}{
+		{name: "list secrets", expectRes: provider.ValidationResultReady},
+		{name: "list forbidden", listErr: autorest.DetailedError{StatusCode: http.StatusForbidden}, expectRes: provider.ValidationResultUnknown},
+		{name: "unauthorized", listErr: autorest.DetailedError{StatusCode: http.StatusUnauthorized}, expectRes: provider.ValidationResultError, expectErr: true},
+	} {
+		testAzure, azureMock := newAzure()
+		azureMock.On("GetSecretsComplete", ctx, testAzure.vaultURL, &maxResults).Return(keyvault.SecretListResultIterator{}, c.listErr)
+		res, err := testAzure.Validate(ctx)
+		azureMock.AssertExpectations(t)
+		tassert.Equal(t, c.expectRes, res, c.name)
+		tassert.Equal(t, c.expectErr, err != nil, c.name)
+	}
+}
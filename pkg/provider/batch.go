@@ -0,0 +1,59 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"sync"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// Concurrency returns the number of GetSecret calls GetSecrets may run at a time
+// for a client of the provider, max if the provider is a ConcurrentReader and 1 otherwise.
+func Concurrency(p Provider, max int) int {
+	if _, ok := p.(ConcurrentReader); ok {
+		return max
+	}
+	return 1
+}
+
+// GetSecrets reads the refs with the SecretsBatchReader of the client.
+// Clients that don't implement it are called with GetSecret for every ref,
+// running up to concurrency calls at a time, see Concurrency.
+// The results are returned in the order of the refs.
+func GetSecrets(ctx context.Context, client SecretsClient, refs []esv1alpha1.ExternalSecretDataRemoteRef, concurrency int) []SecretResult {
+	if reader, ok := client.(SecretsBatchReader); ok {
+		return reader.GetSecrets(ctx, refs)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	results := make([]SecretResult, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range refs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			value, err := client.GetSecret(ctx, refs[i])
+			results[i] = SecretResult{Value: value, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
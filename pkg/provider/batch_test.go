@@ -0,0 +1,150 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// countingClient returns the key of the ref as value
+// and records the maximum number of concurrent calls.
+type countingClient struct {
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (c *countingClient) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	n := atomic.AddInt32(&c.inFlight, 1)
+	defer atomic.AddInt32(&c.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&c.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&c.maxInFlight, max, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if ref.Key == "missing" {
+		return nil, NewNotFoundError(errors.New("not found"))
+	}
+	return []byte(ref.Key), nil
+}
+
+func (c *countingClient) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (c *countingClient) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (c *countingClient) Validate(ctx context.Context) (ValidationResult, error) {
+	return ValidationResultReady, nil
+}
+
+func (c *countingClient) Close(ctx context.Context) error {
+	return nil
+}
+
+// batchClient answers GetSecrets with a single call.
+type batchClient struct {
+	countingClient
+	batches int
+}
+
+func (c *batchClient) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []SecretResult {
+	c.batches++
+	results := make([]SecretResult, len(refs))
+	for i, ref := range refs {
+		results[i] = SecretResult{Value: []byte("batch-" + ref.Key)}
+	}
+	return results
+}
+
+func makeRefs(keys ...string) []esv1alpha1.ExternalSecretDataRemoteRef {
+	refs := make([]esv1alpha1.ExternalSecretDataRemoteRef, len(keys))
+	for i, key := range keys {
+		refs[i] = esv1alpha1.ExternalSecretDataRemoteRef{Key: key}
+	}
+	return refs
+}
+
+func TestGetSecretsFallback(t *testing.T) {
+	keys := make([]string, 0)
+	for i := 0; i < 20; i++ {
+		keys = append(keys, fmt.Sprintf("key-%d", i))
+	}
+	keys = append(keys, "missing")
+	client := &countingClient{}
+
+	results := GetSecrets(context.Background(), client, makeRefs(keys...), 4)
+
+	if len(results) != len(keys) {
+		t.Fatalf("unexpected number of results: %d", len(results))
+	}
+	for i, key := range keys[:20] {
+		if results[i].Err != nil || string(results[i].Value) != key {
+			t.Errorf("unexpected result %d: %q, %v", i, results[i].Value, results[i].Err)
+		}
+	}
+	if !IsNotFound(results[20].Err) {
+		t.Errorf("expected a not found error, got %v", results[20].Err)
+	}
+	if client.maxInFlight > 4 {
+		t.Errorf("expected at most 4 concurrent calls, got %d", client.maxInFlight)
+	}
+}
+
+func TestGetSecretsBatchReader(t *testing.T) {
+	client := &batchClient{}
+	results := GetSecrets(context.Background(), client, makeRefs("a", "b"), 4)
+	if client.batches != 1 {
+		t.Errorf("expected a single batch, got %d", client.batches)
+	}
+	if string(results[0].Value) != "batch-a" || string(results[1].Value) != "batch-b" {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
+
+// countingProvider constructs countingClients.
+type countingProvider struct{}
+
+func (p *countingProvider) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (SecretsClient, error) {
+	return &countingClient{}, nil
+}
+
+// concurrentProvider constructs countingClients, which are safe for concurrent use.
+type concurrentProvider struct {
+	countingProvider
+}
+
+func (p *concurrentProvider) ConcurrentGetSecret() {}
+
+func TestConcurrency(t *testing.T) {
+	if got := Concurrency(&countingProvider{}, 5); got != 1 {
+		t.Errorf("expected the clients of an unmarked provider to be called one at a time, got %d", got)
+	}
+	if got := Concurrency(&concurrentProvider{}, 5); got != 5 {
+		t.Errorf("expected the clients of a ConcurrentReader to be called concurrently, got %d", got)
+	}
+}
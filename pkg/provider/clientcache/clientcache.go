@@ -0,0 +1,354 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientcache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/utils"
+)
+
+const (
+	errGetAuthSecret = "could not get auth secret %s: %w"
+	errCloseClient   = "could not close evicted provider client"
+)
+
+var log = ctrl.Log.WithName("provider").WithName("clientcache")
+
+// Cache shares the provider clients of a store between reconciles.
+//
+// A client is cached by the UID and resourceVersion of the store, the namespace
+// it is used in and the resourceVersions of the auth secrets referenced by the store.
+// Any change to the store or its auth secrets therefore results in a new client,
+// the clients of the previous versions are evicted.
+// Clients are evicted after the TTL and closed once they are no longer in use.
+type Cache struct {
+	kube client.Client
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	key     string
+	storeID string
+	client  provider.SecretsClient
+	created time.Time
+	refs    int
+	evicted bool
+}
+
+// New returns a Cache that reads the auth secrets with kube
+// and evicts clients after the ttl, the ttl must be positive.
+func New(kube client.Client, ttl time.Duration) *Cache {
+	return &Cache{
+		kube:    kube,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Get returns the cached client of the store for the namespace,
+// or constructs and caches a new client with prov.
+// The returned client must be closed to release it,
+// closing it does not close the cached client.
+func (c *Cache) Get(ctx context.Context, prov provider.Provider, store esv1alpha1.GenericStore, namespace string) (provider.SecretsClient, error) {
+	key, err := Key(ctx, c.kube, store, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	e, closeClients := c.lookup(key)
+	if e != nil {
+		e.refs++
+	}
+	c.mu.Unlock()
+	closeAll(ctx, closeClients)
+	if e != nil {
+		return c.handle(e), nil
+	}
+
+	secretClient, err := prov.NewClient(ctx, store, c.kube, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	e, closeClients = c.lookup(key)
+	if e != nil {
+		// another reconcile constructed a client for the same key in the meantime
+		closeClients = append(closeClients, secretClient)
+	} else {
+		id := storeID(store, namespace)
+		for _, old := range c.entries {
+			if old.storeID == id {
+				closeClients = append(closeClients, c.evict(old)...)
+			}
+		}
+		e = &entry{
+			key:     key,
+			storeID: id,
+			client:  secretClient,
+			created: c.now(),
+		}
+		c.entries[key] = e
+	}
+	e.refs++
+	c.mu.Unlock()
+	closeAll(ctx, closeClients)
+
+	return c.handle(e), nil
+}
+
+// Start evicts the expired clients periodically until the context is done,
+// then it evicts all clients. It implements manager.Runnable.
+func (c *Cache) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			var closeClients []provider.SecretsClient
+			for _, e := range c.entries {
+				closeClients = append(closeClients, c.evict(e)...)
+			}
+			c.mu.Unlock()
+			closeAll(context.Background(), closeClients)
+			return nil
+		case <-ticker.C:
+			c.mu.Lock()
+			var closeClients []provider.SecretsClient
+			for _, e := range c.entries {
+				if c.expired(e) {
+					closeClients = append(closeClients, c.evict(e)...)
+				}
+			}
+			c.mu.Unlock()
+			closeAll(ctx, closeClients)
+		}
+	}
+}
+
+// Len returns the number of cached clients.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// lookup returns the entry of the key unless it is expired.
+// Expired entries are evicted, their clients are returned to be closed
+// by the caller after releasing the lock.
+func (c *Cache) lookup(key string) (*entry, []provider.SecretsClient) {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	if c.expired(e) {
+		return nil, c.evict(e)
+	}
+	return e, nil
+}
+
+func (c *Cache) expired(e *entry) bool {
+	return c.now().Sub(e.created) >= c.ttl
+}
+
+// evict removes the entry from the cache. The client of the entry is returned
+// to be closed if it is not in use, otherwise it is closed by the last release.
+func (c *Cache) evict(e *entry) []provider.SecretsClient {
+	delete(c.entries, e.key)
+	e.evicted = true
+	if e.refs > 0 {
+		return nil
+	}
+	return []provider.SecretsClient{e.client}
+}
+
+// invalidate evicts the entry if it is still cached.
+func (c *Cache) invalidate(ctx context.Context, e *entry) {
+	c.mu.Lock()
+	var closeClients []provider.SecretsClient
+	if c.entries[e.key] == e {
+		closeClients = c.evict(e)
+	}
+	c.mu.Unlock()
+	closeAll(ctx, closeClients)
+}
+
+// release returns a reference of the entry and closes
+// the client of an evicted entry that is no longer in use.
+func (c *Cache) release(ctx context.Context, e *entry) error {
+	c.mu.Lock()
+	e.refs--
+	closeClient := e.evicted && e.refs == 0
+	c.mu.Unlock()
+	if closeClient {
+		return e.client.Close(ctx)
+	}
+	return nil
+}
+
+// Key identifies the client of the store used in the namespace, it changes
+// whenever the store or one of its auth secrets changes.
+func Key(ctx context.Context, kube client.Reader, store esv1alpha1.GenericStore, namespace string) (string, error) {
+	versions := make([]string, 0)
+	for _, ref := range authSecretRefs(store, namespace) {
+		var secret v1.Secret
+		err := kube.Get(ctx, ref, &secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", fmt.Errorf(errGetAuthSecret, ref, err)
+		}
+		versions = append(versions, fmt.Sprintf("%s=%s", ref, secret.ResourceVersion))
+	}
+	sort.Strings(versions)
+	return fmt.Sprintf("%s/%s/%s", storeID(store, namespace), store.GetResourceVersion(), strings.Join(versions, ",")), nil
+}
+
+// storeID identifies all versions of the store used in the namespace.
+func storeID(store esv1alpha1.GenericStore, namespace string) string {
+	return fmt.Sprintf("%s/%s", store.GetUID(), namespace)
+}
+
+// authSecretRefs returns the secrets referenced by the provider spec of the store.
+// References without namespace point to the namespace of a SecretStore
+// or, for a ClusterSecretStore, to the namespace the client is used in.
+func authSecretRefs(store esv1alpha1.GenericStore, namespace string) []types.NamespacedName {
+	defaultNamespace := store.GetNamespace()
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok {
+		defaultNamespace = namespace
+	}
+	var refs []types.NamespacedName
+	seen := make(map[types.NamespacedName]bool)
+	for _, storeRef := range utils.StoreRefs(store) {
+		if storeRef.Kind != utils.KindSecret {
+			continue
+		}
+		ref := types.NamespacedName{Name: storeRef.Name, Namespace: defaultNamespace}
+		if storeRef.Namespace != nil {
+			ref.Namespace = *storeRef.Namespace
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+func closeAll(ctx context.Context, clients []provider.SecretsClient) {
+	for _, secretClient := range clients {
+		if err := secretClient.Close(ctx); err != nil {
+			log.Error(err, errCloseClient)
+		}
+	}
+}
+
+// handle returns a new client handed out for the entry,
+// it keeps the batch reader of the cached client.
+func (c *Cache) handle(e *entry) provider.SecretsClient {
+	h := &cachedClient{SecretsClient: e.client, cache: c, entry: e}
+	if reader, ok := e.client.(provider.SecretsBatchReader); ok {
+		return &cachedBatchClient{cachedClient: h, reader: reader}
+	}
+	return h
+}
+
+// cachedClient is the client handed out by the cache,
+// closing it releases the cached client.
+type cachedClient struct {
+	provider.SecretsClient
+	cache *Cache
+	entry *entry
+	once  sync.Once
+}
+
+// GetSecret implements provider.SecretsClient.
+func (c *cachedClient) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	value, err := c.SecretsClient.GetSecret(ctx, ref)
+	c.observe(ctx, err)
+	return value, err
+}
+
+// GetSecretMap implements provider.SecretsClient.
+func (c *cachedClient) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	values, err := c.SecretsClient.GetSecretMap(ctx, ref)
+	c.observe(ctx, err)
+	return values, err
+}
+
+// GetAllSecrets implements provider.SecretsClient.
+func (c *cachedClient) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	values, err := c.SecretsClient.GetAllSecrets(ctx, ref)
+	c.observe(ctx, err)
+	return values, err
+}
+
+// Validate implements provider.SecretsClient.
+func (c *cachedClient) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	result, err := c.SecretsClient.Validate(ctx)
+	c.observe(ctx, err)
+	return result, err
+}
+
+// observe evicts the cached client once the provider rejects its credentials,
+// e.g. an expired token, so the next reconcile constructs a new one.
+func (c *cachedClient) observe(ctx context.Context, err error) {
+	if provider.IsUnauthenticated(err) || provider.IsPermissionDenied(err) {
+		c.cache.invalidate(ctx, c.entry)
+	}
+}
+
+// Close releases the cached client.
+func (c *cachedClient) Close(ctx context.Context) error {
+	var err error
+	c.once.Do(func() {
+		err = c.cache.release(ctx, c.entry)
+	})
+	return err
+}
+
+// cachedBatchClient is a cachedClient of a client that implements provider.SecretsBatchReader.
+type cachedBatchClient struct {
+	*cachedClient
+	reader provider.SecretsBatchReader
+}
+
+// GetSecrets implements provider.SecretsBatchReader.
+func (c *cachedBatchClient) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	results := c.reader.GetSecrets(ctx, refs)
+	for _, result := range results {
+		c.observe(ctx, result.Err)
+	}
+	return results
+}
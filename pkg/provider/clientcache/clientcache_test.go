@@ -0,0 +1,362 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientcache
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	_ "github.com/external-secrets/external-secrets/pkg/provider/alibaba"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+	_ "github.com/external-secrets/external-secrets/pkg/provider/gitlab"
+	_ "github.com/external-secrets/external-secrets/pkg/provider/ibm"
+	_ "github.com/external-secrets/external-secrets/pkg/provider/oracle"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+)
+
+// closeCounter is a provider client that counts how often it was closed.
+type closeCounter struct {
+	*fake.Client
+	closed int
+}
+
+func (c *closeCounter) Close(ctx context.Context) error {
+	c.closed++
+	return nil
+}
+
+// countingProvider constructs a new closeCounter on every call.
+type countingProvider struct {
+	clients []*closeCounter
+}
+
+func (p *countingProvider) NewClient(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+	c := &closeCounter{Client: fake.New()}
+	p.clients = append(p.clients, c)
+	return c, nil
+}
+
+func makeStore() *esv1alpha1.SecretStore {
+	return &esv1alpha1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "vault",
+			Namespace:       "default",
+			UID:             "store-uid",
+			ResourceVersion: "1",
+		},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				Vault: &esv1alpha1.VaultProvider{
+					Auth: esv1alpha1.VaultAuth{
+						TokenSecretRef: &esmeta.SecretKeySelector{
+							Name: "vault-token",
+							Key:  "token",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeAuthSecret() *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-token",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("token"),
+		},
+	}
+}
+
+func get(t *testing.T, c *Cache, prov provider.Provider, store esv1alpha1.GenericStore) provider.SecretsClient {
+	t.Helper()
+	secretClient, err := c.Get(context.Background(), prov, store, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return secretClient
+}
+
+func release(t *testing.T, secretClient provider.SecretsClient) {
+	t.Helper()
+	if err := secretClient.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetSharesClient(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(makeAuthSecret()).Build()
+	c := New(kube, time.Hour)
+	prov := &countingProvider{}
+	store := makeStore()
+
+	release(t, get(t, c, prov, store))
+	release(t, get(t, c, prov, store))
+
+	if len(prov.clients) != 1 {
+		t.Fatalf("expected one constructed client, got %d", len(prov.clients))
+	}
+	if prov.clients[0].closed != 0 {
+		t.Errorf("expected the cached client not to be closed")
+	}
+}
+
+func TestGetInvalidatesChangedStore(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(makeAuthSecret()).Build()
+	c := New(kube, time.Hour)
+	prov := &countingProvider{}
+	store := makeStore()
+
+	release(t, get(t, c, prov, store))
+	store.ResourceVersion = "2"
+	release(t, get(t, c, prov, store))
+
+	if len(prov.clients) != 2 {
+		t.Fatalf("expected two constructed clients, got %d", len(prov.clients))
+	}
+	if prov.clients[0].closed != 1 {
+		t.Errorf("expected the client of the old store version to be closed")
+	}
+	if c.Len() != 1 {
+		t.Errorf("expected one cached client, got %d", c.Len())
+	}
+}
+
+func TestGetInvalidatesChangedAuthSecret(t *testing.T) {
+	secret := makeAuthSecret()
+	kube := clientfake.NewClientBuilder().WithObjects(secret).Build()
+	c := New(kube, time.Hour)
+	prov := &countingProvider{}
+	store := makeStore()
+
+	release(t, get(t, c, prov, store))
+	secret.Data["token"] = []byte("rotated")
+	if err := kube.Update(context.Background(), secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release(t, get(t, c, prov, store))
+
+	if len(prov.clients) != 2 {
+		t.Fatalf("expected two constructed clients, got %d", len(prov.clients))
+	}
+	if prov.clients[0].closed != 1 {
+		t.Errorf("expected the client of the old auth secret to be closed")
+	}
+}
+
+func TestEvictedClientIsClosedAfterRelease(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(makeAuthSecret()).Build()
+	c := New(kube, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	prov := &countingProvider{}
+	store := makeStore()
+
+	inUse := get(t, c, prov, store)
+	now = now.Add(time.Hour)
+	release(t, get(t, c, prov, store))
+
+	if len(prov.clients) != 2 {
+		t.Fatalf("expected the expired client to be replaced, got %d clients", len(prov.clients))
+	}
+	if prov.clients[0].closed != 0 {
+		t.Fatalf("expected the client in use not to be closed")
+	}
+	release(t, inUse)
+	release(t, inUse)
+	if prov.clients[0].closed != 1 {
+		t.Errorf("expected the evicted client to be closed once after the release, got %d", prov.clients[0].closed)
+	}
+}
+
+func TestGetEvictsRejectedClient(t *testing.T) {
+	tests := map[string]error{
+		"unauthenticated":   provider.NewUnauthenticatedError(errors.New("token expired")),
+		"permission denied": provider.NewPermissionDeniedError(errors.New("permission denied")),
+	}
+	for name, rejected := range tests {
+		t.Run(name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(makeAuthSecret()).Build()
+			c := New(kube, time.Hour)
+			prov := &countingProvider{}
+			store := makeStore()
+
+			secretClient := get(t, c, prov, store)
+			prov.clients[0].WithGetSecret(nil, rejected)
+			if _, err := secretClient.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "key"}); !errors.Is(err, rejected) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.Len() != 0 {
+				t.Fatalf("expected the rejected client to be evicted")
+			}
+			release(t, secretClient)
+			if prov.clients[0].closed != 1 {
+				t.Errorf("expected the rejected client to be closed after the release")
+			}
+
+			release(t, get(t, c, prov, store))
+			if len(prov.clients) != 2 {
+				t.Errorf("expected a new client, got %d clients", len(prov.clients))
+			}
+		})
+	}
+}
+
+func TestGetKeepsClientOnOtherErrors(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(makeAuthSecret()).Build()
+	c := New(kube, time.Hour)
+	prov := &countingProvider{}
+	store := makeStore()
+
+	secretClient := get(t, c, prov, store)
+	prov.clients[0].WithGetSecret(nil, provider.NewNotFoundError(errors.New("not found")))
+	if _, err := secretClient.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "key"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+	release(t, secretClient)
+	if c.Len() != 1 || prov.clients[0].closed != 0 {
+		t.Errorf("expected the client to stay cached")
+	}
+}
+
+func TestStartClosesClients(t *testing.T) {
+	kube := clientfake.NewClientBuilder().WithObjects(makeAuthSecret()).Build()
+	c := New(kube, time.Hour)
+	prov := &countingProvider{}
+	release(t, get(t, c, prov, makeStore()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Len() != 0 || prov.clients[0].closed != 1 {
+		t.Errorf("expected all clients to be evicted and closed")
+	}
+}
+
+// TestGetDoesNotShareProviderClients builds the clients of two stores through the
+// registered providers, which must not hand out the same client for both.
+func TestGetDoesNotShareProviderClients(t *testing.T) {
+	// the oracle client parses its private key
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	creds := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"},
+		Data: map[string][]byte{
+			"key":         pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+			"fingerprint": []byte("fingerprint"),
+		},
+	}
+	ref := esmeta.SecretKeySelector{Name: "creds", Key: "key"}
+	serviceURL := "https://ibm.example.com"
+	tests := map[string]esv1alpha1.SecretStoreProvider{
+		"alibaba": {Alibaba: &esv1alpha1.AlibabaProvider{
+			Auth:     &esv1alpha1.AlibabaAuth{SecretRef: esv1alpha1.AlibabaAuthSecretRef{AccessKeyID: ref, AccessKeySecret: ref}},
+			RegionID: "eu-central-1",
+		}},
+		"gitlab": {Gitlab: &esv1alpha1.GitlabProvider{
+			Auth:      esv1alpha1.GitlabAuth{SecretRef: esv1alpha1.GitlabSecretRef{AccessToken: ref}},
+			ProjectID: "1",
+		}},
+		"ibm": {IBM: &esv1alpha1.IBMProvider{
+			Auth:       esv1alpha1.IBMAuth{SecretRef: esv1alpha1.IBMAuthSecretRef{SecretAPIKey: ref}},
+			ServiceURL: &serviceURL,
+		}},
+		"oracle": {Oracle: &esv1alpha1.OracleProvider{
+			Auth: esv1alpha1.OracleAuth{SecretRef: esv1alpha1.OracleSecretRef{
+				PrivateKey:  ref,
+				Fingerprint: esmeta.SecretKeySelector{Name: "creds", Key: "fingerprint"},
+			}},
+			User:    "user",
+			Tenancy: "tenancy",
+			Region:  "eu-frankfurt-1",
+		}},
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			kube := clientfake.NewClientBuilder().WithObjects(creds.DeepCopy()).Build()
+			c := New(kube, time.Hour)
+			var clients []provider.SecretsClient
+			for _, uid := range []types.UID{"store-a", "store-b"} {
+				store := &esv1alpha1.SecretStore{
+					ObjectMeta: metav1.ObjectMeta{Name: string(uid), Namespace: "default", UID: uid},
+					Spec:       esv1alpha1.SecretStoreSpec{Provider: spec.DeepCopy()},
+				}
+				prov, err := schema.GetProvider(store)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				secretClient := get(t, c, prov, store)
+				defer release(t, secretClient)
+				clients = append(clients, secretClient.(*cachedClient).SecretsClient)
+			}
+			if clients[0] == clients[1] {
+				t.Errorf("expected the stores not to share a client")
+			}
+		})
+	}
+}
+
+func TestAuthSecretRefs(t *testing.T) {
+	ns := "auth"
+	css := &esv1alpha1.ClusterSecretStore{
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				Vault: &esv1alpha1.VaultProvider{
+					Auth: esv1alpha1.VaultAuth{
+						TokenSecretRef: &esmeta.SecretKeySelector{Name: "token"},
+						AppRole: &esv1alpha1.VaultAppRole{
+							SecretRef: esmeta.SecretKeySelector{Name: "approle", Namespace: &ns},
+						},
+					},
+				},
+			},
+		},
+	}
+	got := authSecretRefs(css, "es-namespace")
+	want := []types.NamespacedName{
+		{Name: "token", Namespace: "es-namespace"},
+		{Name: "approle", Namespace: "auth"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected refs: %v, want: %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected ref %d: %v, want: %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCategory groups provider errors by what caused them, so callers
+// can react to them without knowing the errors of every provider SDK.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryNotFound means the referenced secret does not exist.
+	ErrorCategoryNotFound ErrorCategory = "NotFound"
+	// ErrorCategoryPermissionDenied means the credentials are not allowed to read the secret.
+	ErrorCategoryPermissionDenied ErrorCategory = "PermissionDenied"
+	// ErrorCategoryUnauthenticated means the credentials are missing, invalid or expired.
+	ErrorCategoryUnauthenticated ErrorCategory = "Unauthenticated"
+	// ErrorCategoryRateLimited means the provider throttled the request.
+	ErrorCategoryRateLimited ErrorCategory = "RateLimited"
+	// ErrorCategoryInvalidRef means the remote reference can't be resolved, e.g. a missing property.
+	ErrorCategoryInvalidRef ErrorCategory = "InvalidRef"
+	// ErrorCategoryTransient means the provider could not be reached or failed, retrying may succeed.
+	ErrorCategoryTransient ErrorCategory = "Transient"
+	// ErrorCategoryUnknown is the category of all other errors.
+	ErrorCategoryUnknown ErrorCategory = "Unknown"
+)
+
+var (
+	// ErrSecretNotFound is matched by errors.Is if the referenced secret
+	// does not exist in the provider. Unlike other errors it means the
+	// secret is gone, not that the provider could not be reached.
+	ErrSecretNotFound = errors.New("secret not found")
+	// ErrPermissionDenied is matched by errors.Is if the provider denied access to the secret.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrUnauthenticated is matched by errors.Is if the provider rejected the credentials.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrRateLimited is matched by errors.Is if the provider throttled the request.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInvalidRef is matched by errors.Is if the remote reference can't be resolved.
+	ErrInvalidRef = errors.New("invalid remote reference")
+	// ErrTransient is matched by errors.Is if the provider failed in a way that may go away on retry.
+	ErrTransient = errors.New("transient provider error")
+)
+
+// categories lists the sentinel error of every category.
+var categories = []struct {
+	category ErrorCategory
+	sentinel error
+}{
+	{ErrorCategoryNotFound, ErrSecretNotFound},
+	{ErrorCategoryPermissionDenied, ErrPermissionDenied},
+	{ErrorCategoryUnauthenticated, ErrUnauthenticated},
+	{ErrorCategoryRateLimited, ErrRateLimited},
+	{ErrorCategoryInvalidRef, ErrInvalidRef},
+	{ErrorCategoryTransient, ErrTransient},
+}
+
+// categorizedError keeps the message of the provider error
+// and makes it match the sentinel error of its category.
+type categorizedError struct {
+	err      error
+	sentinel error
+}
+
+func (e *categorizedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *categorizedError) Unwrap() error {
+	return e.err
+}
+
+func (e *categorizedError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+func newCategorizedError(sentinel, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &categorizedError{err: err, sentinel: sentinel}
+}
+
+// NewNotFoundError marks err as error of a secret that does not exist.
+// Providers must map the not found errors of their SDK with it.
+func NewNotFoundError(err error) error {
+	return newCategorizedError(ErrSecretNotFound, err)
+}
+
+// NewPermissionDeniedError marks err as error of a denied access.
+func NewPermissionDeniedError(err error) error {
+	return newCategorizedError(ErrPermissionDenied, err)
+}
+
+// NewUnauthenticatedError marks err as error of rejected credentials.
+func NewUnauthenticatedError(err error) error {
+	return newCategorizedError(ErrUnauthenticated, err)
+}
+
+// NewRateLimitedError marks err as error of a throttled request.
+func NewRateLimitedError(err error) error {
+	return newCategorizedError(ErrRateLimited, err)
+}
+
+// NewInvalidRefError marks err as error of a remote reference that can't be resolved.
+func NewInvalidRefError(err error) error {
+	return newCategorizedError(ErrInvalidRef, err)
+}
+
+// NewTransientError marks err as error that may go away on retry.
+func NewTransientError(err error) error {
+	return newCategorizedError(ErrTransient, err)
+}
+
+// IsNotFound returns true if err is or wraps an error of a secret that does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrSecretNotFound)
+}
+
+// IsUnauthenticated returns true if err is or wraps an error of rejected credentials.
+func IsUnauthenticated(err error) bool {
+	return errors.Is(err, ErrUnauthenticated)
+}
+
+// IsPermissionDenied returns true if err is or wraps an error of a denied access.
+func IsPermissionDenied(err error) bool {
+	return errors.Is(err, ErrPermissionDenied)
+}
+
+// GetErrorCategory returns the category of err, or ErrorCategoryUnknown
+// if err is not a categorized provider error.
+func GetErrorCategory(err error) ErrorCategory {
+	if err == nil {
+		return ErrorCategoryUnknown
+	}
+	for _, c := range categories {
+		if errors.Is(err, c.sentinel) {
+			return c.category
+		}
+	}
+	return ErrorCategoryUnknown
+}
+
+// FromHTTPStatus categorizes err by the HTTP status code of the response
+// that caused it. Errors of other status codes are returned as they are.
+func FromHTTPStatus(code int, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case code == http.StatusNotFound:
+		return NewNotFoundError(err)
+	case code == http.StatusForbidden:
+		return NewPermissionDeniedError(err)
+	case code == http.StatusUnauthorized:
+		return NewUnauthenticatedError(err)
+	case code == http.StatusTooManyRequests:
+		return NewRateLimitedError(err)
+	case code == http.StatusBadRequest:
+		return NewInvalidRefError(err)
+	case code >= http.StatusInternalServerError:
+		return NewTransientError(err)
+	}
+	return err
+}
+
+// FromGRPCError categorizes err by its gRPC status code.
+// Errors of other codes are returned as they are.
+func FromGRPCError(err error) error {
+	switch status.Code(err) {
+	case codes.OK:
+		return err
+	case codes.NotFound:
+		return NewNotFoundError(err)
+	case codes.PermissionDenied:
+		return NewPermissionDeniedError(err)
+	case codes.Unauthenticated:
+		return NewUnauthenticatedError(err)
+	case codes.ResourceExhausted:
+		return NewRateLimitedError(err)
+	case codes.InvalidArgument:
+		return NewInvalidRefError(err)
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.Internal:
+		return NewTransientError(err)
+	}
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsNotFound(t *testing.T) {
+	cause := errors.New("ResourceNotFoundException: secret foo")
+	tbl := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "other error", err: cause, want: false},
+		{name: "sentinel", err: ErrSecretNotFound, want: true},
+		{name: "not found error", err: NewNotFoundError(cause), want: true},
+		{name: "wrapped not found error", err: fmt.Errorf("key foo: %w", NewNotFoundError(cause)), want: true},
+	}
+	for _, tt := range tbl {
+		if got := IsNotFound(tt.err); got != tt.want {
+			t.Errorf("[%s] IsNotFound() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	err := NewNotFoundError(cause)
+	if err.Error() != cause.Error() {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the cause to be wrapped")
+	}
+	if NewNotFoundError(nil) != nil {
+		t.Errorf("expected nil for a nil error")
+	}
+}
+
+func TestGetErrorCategory(t *testing.T) {
+	cause := errors.New("boom")
+	tbl := []struct {
+		name string
+		err  error
+		want ErrorCategory
+	}{
+		{name: "nil", err: nil, want: ErrorCategoryUnknown},
+		{name: "other error", err: cause, want: ErrorCategoryUnknown},
+		{name: "not found", err: NewNotFoundError(cause), want: ErrorCategoryNotFound},
+		{name: "permission denied", err: NewPermissionDeniedError(cause), want: ErrorCategoryPermissionDenied},
+		{name: "unauthenticated", err: NewUnauthenticatedError(cause), want: ErrorCategoryUnauthenticated},
+		{name: "rate limited", err: NewRateLimitedError(cause), want: ErrorCategoryRateLimited},
+		{name: "invalid ref", err: NewInvalidRefError(cause), want: ErrorCategoryInvalidRef},
+		{name: "transient", err: NewTransientError(cause), want: ErrorCategoryTransient},
+		{name: "wrapped", err: fmt.Errorf("key foo: %w", NewRateLimitedError(cause)), want: ErrorCategoryRateLimited},
+	}
+	for _, tt := range tbl {
+		if got := GetErrorCategory(tt.err); got != tt.want {
+			t.Errorf("[%s] GetErrorCategory() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	cause := errors.New("boom")
+	tbl := []struct {
+		code int
+		want ErrorCategory
+	}{
+		{code: http.StatusNotFound, want: ErrorCategoryNotFound},
+		{code: http.StatusForbidden, want: ErrorCategoryPermissionDenied},
+		{code: http.StatusUnauthorized, want: ErrorCategoryUnauthenticated},
+		{code: http.StatusTooManyRequests, want: ErrorCategoryRateLimited},
+		{code: http.StatusBadRequest, want: ErrorCategoryInvalidRef},
+		{code: http.StatusBadGateway, want: ErrorCategoryTransient},
+		{code: http.StatusConflict, want: ErrorCategoryUnknown},
+	}
+	for _, tt := range tbl {
+		err := FromHTTPStatus(tt.code, cause)
+		if got := GetErrorCategory(err); got != tt.want {
+			t.Errorf("[%d] unexpected category %v, want %v", tt.code, got, tt.want)
+		}
+		if !errors.Is(err, cause) {
+			t.Errorf("[%d] expected the cause to be wrapped", tt.code)
+		}
+	}
+	if FromHTTPStatus(http.StatusNotFound, nil) != nil {
+		t.Errorf("expected nil for a nil error")
+	}
+}
+
+func TestFromGRPCError(t *testing.T) {
+	tbl := []struct {
+		code codes.Code
+		want ErrorCategory
+	}{
+		{code: codes.NotFound, want: ErrorCategoryNotFound},
+		{code: codes.PermissionDenied, want: ErrorCategoryPermissionDenied},
+		{code: codes.Unauthenticated, want: ErrorCategoryUnauthenticated},
+		{code: codes.ResourceExhausted, want: ErrorCategoryRateLimited},
+		{code: codes.InvalidArgument, want: ErrorCategoryInvalidRef},
+		{code: codes.Unavailable, want: ErrorCategoryTransient},
+		{code: codes.AlreadyExists, want: ErrorCategoryUnknown},
+	}
+	for _, tt := range tbl {
+		err := FromGRPCError(status.Error(tt.code, "boom"))
+		if got := GetErrorCategory(err); got != tt.want {
+			t.Errorf("[%s] unexpected category %v, want %v", tt.code, got, tt.want)
+		}
+	}
+	if FromGRPCError(nil) != nil {
+		t.Errorf("expected nil for a nil error")
+	}
+}
+
+func TestProbeResult(t *testing.T) {
+	cause := errors.New("request failed")
+	tests := []struct {
+		name    string
+		err     error
+		want    ValidationResult
+		wantErr bool
+	}{
+		{name: "success", err: nil, want: ValidationResultReady},
+		{name: "permission denied", err: fmt.Errorf("list: %w", NewPermissionDeniedError(cause)), want: ValidationResultUnknown},
+		{name: "unauthenticated", err: NewUnauthenticatedError(cause), want: ValidationResultError, wantErr: true},
+		{name: "other error", err: cause, want: ValidationResultError, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProbeResult(tt.err)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("ProbeResult() = %v, %v, want %v, error: %t", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package fake
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -25,13 +26,18 @@ import (
 )
 
 var _ provider.Provider = &Client{}
+var _ provider.SecretsWriter = &Client{}
 
 // Client is a fake client for testing.
 type Client struct {
 	NewFn func(context.Context, esv1alpha1.GenericStore, client.Client,
 		string) (provider.SecretsClient, error)
-	GetSecretFn    func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error)
-	GetSecretMapFn func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error)
+	GetSecretFn     func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error)
+	GetSecretMapFn  func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error)
+	GetAllSecretsFn func(context.Context, esv1alpha1.ExternalSecretFind) (map[string][]byte, error)
+	ValidateFn      func(context.Context) (provider.ValidationResult, error)
+	ValidateStoreFn func(esv1alpha1.GenericStore) field.ErrorList
+	PushSecretFn    func(context.Context, []byte, esv1alpha1.PushSecretRemoteRef) error
 }
 
 // New returns a fake provider/client.
@@ -43,6 +49,18 @@ func New() *Client {
 		GetSecretMapFn: func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
 			return nil, nil
 		},
+		GetAllSecretsFn: func(context.Context, esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+			return nil, nil
+		},
+		ValidateFn: func(context.Context) (provider.ValidationResult, error) {
+			return provider.ValidationResultReady, nil
+		},
+		ValidateStoreFn: func(esv1alpha1.GenericStore) field.ErrorList {
+			return nil
+		},
+		PushSecretFn: func(context.Context, []byte, esv1alpha1.PushSecretRemoteRef) error {
+			return nil
+		},
 	}
 
 	v.NewFn = func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
@@ -74,10 +92,55 @@ func (v *Client) WithGetSecret(secData []byte, err error) *Client {
 func (v *Client) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
 	return v.GetSecretMapFn(ctx, ref)
 }
+
+// GetAllSecrets implements the provider.Provider interface.
+func (v *Client) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return v.GetAllSecretsFn(ctx, ref)
+}
+
 func (v *Client) Close(ctx context.Context) error {
 	return nil
 }
 
+// Validate implements the provider.SecretsClient interface.
+func (v *Client) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	return v.ValidateFn(ctx)
+}
+
+// WithValidate wraps the validation result returned by this fake provider.
+func (v *Client) WithValidate(res provider.ValidationResult, err error) *Client {
+	v.ValidateFn = func(context.Context) (provider.ValidationResult, error) {
+		return res, err
+	}
+	return v
+}
+
+// ValidateStore implements the provider.StoreValidator interface.
+func (v *Client) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	return v.ValidateStoreFn(store)
+}
+
+// WithValidateStore wraps the store validation errors returned by this fake provider.
+func (v *Client) WithValidateStore(errs field.ErrorList) *Client {
+	v.ValidateStoreFn = func(esv1alpha1.GenericStore) field.ErrorList {
+		return errs
+	}
+	return v
+}
+
+// PushSecret implements the provider.SecretsWriter interface.
+func (v *Client) PushSecret(ctx context.Context, value []byte, remoteRef esv1alpha1.PushSecretRemoteRef) error {
+	return v.PushSecretFn(ctx, value, remoteRef)
+}
+
+// WithPushSecret wraps the error returned by this fake provider when pushing a secret.
+func (v *Client) WithPushSecret(err error) *Client {
+	v.PushSecretFn = func(context.Context, []byte, esv1alpha1.PushSecretRemoteRef) error {
+		return err
+	}
+	return v
+}
+
 // WithGetSecretMap wraps the secret data map returned by this fake provider.
 func (v *Client) WithGetSecretMap(secData map[string][]byte, err error) *Client {
 	v.GetSecretMapFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
@@ -86,6 +149,14 @@ func (v *Client) WithGetSecretMap(secData map[string][]byte, err error) *Client
 	return v
 }
 
+// WithGetAllSecrets wraps the secrets found by this fake provider.
+func (v *Client) WithGetAllSecrets(secData map[string][]byte, err error) *Client {
+	v.GetAllSecretsFn = func(context.Context, esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+		return secData, err
+	}
+	return v
+}
+
 // WithNew wraps the fake provider factory function.
 func (v *Client) WithNew(f func(context.Context, esv1alpha1.GenericStore, client.Client,
 	string) (provider.SecretsClient, error)) *Client {
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	grpc "github.com/googleapis/gax-go"
@@ -25,6 +26,7 @@ import (
 
 type MockSMClient struct {
 	accessSecretFn func(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...grpc.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+	listSecretsFn  func(ctx context.Context, req *secretmanagerpb.ListSecretsRequest) ([]*secretmanagerpb.Secret, error)
 	closeFn        func() error
 }
 
@@ -32,6 +34,24 @@ func (mc *MockSMClient) AccessSecretVersion(ctx context.Context, req *secretmana
 	return mc.accessSecretFn(ctx, req)
 }
 
+// ListSecrets returns an iterator with a single page of the secrets of WithListSecrets.
+func (mc *MockSMClient) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...grpc.CallOption) *secretmanager.SecretIterator {
+	return &secretmanager.SecretIterator{
+		InternalFetch: func(pageSize int, pageToken string) ([]*secretmanagerpb.Secret, string, error) {
+			if mc.listSecretsFn == nil {
+				return nil, "", fmt.Errorf("test case not found")
+			}
+			secrets, err := mc.listSecretsFn(ctx, req)
+			return secrets, "", err
+		},
+	}
+}
+
+// WithListSecrets sets the function called when secrets are listed.
+func (mc *MockSMClient) WithListSecrets(fn func(ctx context.Context, req *secretmanagerpb.ListSecretsRequest) ([]*secretmanagerpb.Secret, error)) {
+	mc.listSecretsFn = fn
+}
+
 func (mc *MockSMClient) Close() error {
 	return mc.closeFn()
 }
@@ -17,6 +17,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"github.com/googleapis/gax-go"
@@ -27,6 +29,7 @@ import (
 	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -51,10 +54,12 @@ const (
 	errUninitalizedGCPProvider                = "provider GCP is not initialized"
 	errClientGetSecretAccess                  = "unable to access Secret from SecretManager Client: %w"
 	errJSONSecretUnmarshal                    = "unable to unmarshal secret: %w"
+	errClientListSecrets                      = "unable to list Secrets from SecretManager Client: %w"
 )
 
 type GoogleSecretManagerClient interface {
 	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...gax.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+	ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, opts ...gax.CallOption) *secretmanager.SecretIterator
 	Close() error
 }
 
@@ -125,6 +130,23 @@ func serviceAccountTokenSource(ctx context.Context, store esv1alpha1.GenericStor
 	return config.TokenSource(ctx), nil
 }
 
+// NewTokenSource returns the token source for the auth of a GCPSM store.
+// It uses the service account key of the store, workload identity
+// or the default credentials of the environment in that order.
+func NewTokenSource(ctx context.Context, store esv1alpha1.GenericStore, kube kclient.Client, namespace string) (oauth2.TokenSource, error) {
+	wi, err := newWorkloadIdentity(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize workload identity")
+	}
+	cliStore := gClient{
+		kube:             kube,
+		namespace:        namespace,
+		storeKind:        store.GetObjectKind().GroupVersionKind().Kind,
+		workloadIdentity: wi,
+	}
+	return cliStore.getTokenSource(ctx, store, kube, namespace)
+}
+
 // NewClient constructs a GCP Provider.
 func (sm *ProviderGCP) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube kclient.Client, namespace string) (provider.SecretsClient, error) {
 	storeSpec := store.GetSpec()
@@ -146,8 +168,6 @@ func (sm *ProviderGCP) NewClient(ctx context.Context, store esv1alpha1.GenericSt
 		workloadIdentity: wi,
 	}
 
-	sm.projectID = cliStore.store.ProjectID
-
 	ts, err := cliStore.getTokenSource(ctx, store, kube, namespace)
 	if err != nil {
 		return nil, fmt.Errorf(errUnableCreateGCPSMClient, err)
@@ -157,8 +177,39 @@ func (sm *ProviderGCP) NewClient(ctx context.Context, store esv1alpha1.GenericSt
 	if err != nil {
 		return nil, fmt.Errorf(errUnableCreateGCPSMClient, err)
 	}
-	sm.SecretManagerClient = clientGCPSM
-	return sm, nil
+	return &ProviderGCP{
+		projectID:           cliStore.store.ProjectID,
+		SecretManagerClient: clientGCPSM,
+	}, nil
+}
+
+// ValidateStore checks the GCP specific part of the store spec.
+func (sm *ProviderGCP) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "gcpsm")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.GCPSM == nil {
+		return field.ErrorList{field.Required(fldPath, errGCPSMStore)}
+	}
+	gcpStore := storeSpec.Provider.GCPSM
+	allErrs := field.ErrorList{}
+	if gcpStore.ProjectID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("projectID"), ""))
+	}
+	authPath := fldPath.Child("auth")
+	if gcpStore.Auth.SecretRef != nil {
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, gcpStore.Auth.SecretRef.SecretAccessKey, authPath.Child("secretRef", "secretAccessKeySecretRef"))...)
+	}
+	if wi := gcpStore.Auth.WorkloadIdentity; wi != nil {
+		wiPath := authPath.Child("workloadIdentity")
+		allErrs = append(allErrs, utils.ValidateServiceAccountSelector(store, wi.ServiceAccountRef, wiPath.Child("serviceAccountRef"))...)
+		if wi.ClusterLocation == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("clusterLocation"), ""))
+		}
+		if wi.ClusterName == "" {
+			allErrs = append(allErrs, field.Required(wiPath.Child("clusterName"), ""))
+		}
+	}
+	return allErrs
 }
 
 // GetSecret returns a single secret from the provider.
@@ -177,7 +228,11 @@ func (sm *ProviderGCP) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSec
 	}
 	result, err := sm.SecretManagerClient.AccessSecretVersion(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf(errClientGetSecretAccess, err)
+		return nil, fmt.Errorf(errClientGetSecretAccess, provider.FromGRPCError(err))
+	}
+	// the name of the response contains the version number, even if an alias was requested
+	if i := strings.LastIndex(result.Name, "/versions/"); i >= 0 {
+		provider.RecordVersion(ctx, ref.Key, ref.Version, result.Name[i+len("/versions/"):])
 	}
 
 	if ref.Property == "" {
@@ -194,7 +249,7 @@ func (sm *ProviderGCP) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSec
 
 	val := gjson.Get(payload, ref.Property)
 	if !val.Exists() {
-		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+		return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 	}
 	return []byte(val.String()), nil
 }
@@ -230,6 +285,74 @@ func (sm *ProviderGCP) GetSecretMap(ctx context.Context, ref esv1alpha1.External
 	return secretData, nil
 }
 
+// GetAllSecrets returns the latest version of all secrets of the project that match the find criteria.
+// The tags are matched against the labels of the secrets on server side, the name regexp
+// and the path are matched against the secret names.
+func (sm *ProviderGCP) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	if utils.IsNil(sm.SecretManagerClient) || sm.projectID == "" {
+		return nil, fmt.Errorf(errUninitalizedGCPProvider)
+	}
+	matcher, err := utils.NewFindMatcher(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]string, 0, len(ref.Tags))
+	for k, v := range ref.Tags {
+		labels = append(labels, fmt.Sprintf("labels.%s=%s", k, v))
+	}
+	sort.Strings(labels)
+	it := sm.SecretManagerClient.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", sm.projectID),
+		Filter: strings.Join(labels, " AND "),
+	})
+
+	secrets := make(map[string][]byte)
+	pageToken := ""
+	for {
+		// the pages are fetched directly so the iterator can be replaced in tests
+		page, nextPageToken, err := it.InternalFetch(0, pageToken)
+		if err != nil {
+			return nil, fmt.Errorf(errClientListSecrets, provider.FromGRPCError(err))
+		}
+		for _, secret := range page {
+			name := secret.Name[strings.LastIndex(secret.Name, "/")+1:]
+			if !matcher.MatchName(name) {
+				continue
+			}
+			data, err := sm.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: name})
+			if err != nil {
+				return nil, err
+			}
+			secrets[name] = data
+		}
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+	return secrets, nil
+}
+
+// Validate lists a single secret of the project, which needs the
+// secretmanager.secrets.list permission but doesn't access any secret data.
+func (sm *ProviderGCP) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	if utils.IsNil(sm.SecretManagerClient) || sm.projectID == "" {
+		return provider.ValidationResultError, fmt.Errorf(errUninitalizedGCPProvider)
+	}
+	it := sm.SecretManagerClient.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", sm.projectID),
+	})
+	if _, _, err := it.InternalFetch(1, ""); err != nil {
+		return provider.ProbeResult(fmt.Errorf(errClientListSecrets, provider.FromGRPCError(err)))
+	}
+	return provider.ValidationResultReady, nil
+}
+
+// ConcurrentGetSecret marks the clients as safe for concurrent use,
+// the gRPC client of Secret Manager multiplexes the calls over its connection.
+func (sm *ProviderGCP) ConcurrentGetSecret() {}
+
 func (sm *ProviderGCP) Close(ctx context.Context) error {
 	err := sm.SecretManagerClient.Close()
 	if err != nil {
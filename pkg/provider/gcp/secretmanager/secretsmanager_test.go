@@ -21,8 +21,11 @@ import (
 	"testing"
 
 	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakesm "github.com/external-secrets/external-secrets/pkg/provider/gcp/secretmanager/fake"
 )
 
@@ -159,6 +162,37 @@ func TestSecretManagerGetSecret(t *testing.T) {
 	}
 }
 
+func TestSecretManagerGetSecretErrorCategory(t *testing.T) {
+	setAPIStatus := func(code codes.Code) func(smtc *secretManagerTestCase) {
+		return func(smtc *secretManagerTestCase) {
+			smtc.apiErr = status.Error(code, "Secret [projects/default/secrets/baz] can't be accessed.")
+		}
+	}
+	setMissingProperty := func(smtc *secretManagerTestCase) {
+		smtc.ref.Property = "missing"
+		smtc.apiOutput.Payload.Data = []byte(`{"foo":"bar"}`)
+	}
+	for k, v := range []struct {
+		tc           *secretManagerTestCase
+		wantCategory provider.ErrorCategory
+	}{
+		{tc: makeValidSecretManagerTestCaseCustom(setAPIStatus(codes.NotFound)), wantCategory: provider.ErrorCategoryNotFound},
+		{tc: makeValidSecretManagerTestCaseCustom(setAPIStatus(codes.PermissionDenied)), wantCategory: provider.ErrorCategoryPermissionDenied},
+		{tc: makeValidSecretManagerTestCaseCustom(setAPIStatus(codes.Unavailable)), wantCategory: provider.ErrorCategoryTransient},
+		{tc: makeValidSecretManagerTestCaseCustom(setMissingProperty), wantCategory: provider.ErrorCategoryInvalidRef},
+		{tc: makeValidSecretManagerTestCaseCustom(setAPIErr), wantCategory: provider.ErrorCategoryUnknown},
+	} {
+		sm := ProviderGCP{
+			projectID:           v.tc.projectID,
+			SecretManagerClient: v.tc.mockClient,
+		}
+		_, err := sm.GetSecret(context.Background(), *v.tc.ref)
+		if got := provider.GetErrorCategory(err); got != v.wantCategory {
+			t.Errorf("[%d] unexpected error category %s of error %v, expected %s", k, got, err, v.wantCategory)
+		}
+	}
+}
+
 func TestGetSecretMap(t *testing.T) {
 	// good case: default version & deserialization
 	setDeserialization := func(smtc *secretManagerTestCase) {
@@ -210,3 +244,106 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestGetAllSecrets(t *testing.T) {
+	tbl := []struct {
+		name         string
+		find         esv1alpha1.ExternalSecretFind
+		listErr      error
+		expectFilter string
+		expectData   map[string][]byte
+		expectError  string
+	}{
+		{
+			name: "match name regexp",
+			find: esv1alpha1.ExternalSecretFind{
+				Name: &esv1alpha1.FindName{RegExp: "^db"},
+			},
+			expectData: map[string][]byte{
+				"db": []byte("db-value"),
+			},
+		},
+		{
+			name: "filter by labels",
+			find: esv1alpha1.ExternalSecretFind{
+				Name: &esv1alpha1.FindName{RegExp: "^db"},
+				Tags: map[string]string{"team": "a", "env": "prod"},
+			},
+			expectFilter: "labels.env=prod AND labels.team=a",
+			expectData: map[string][]byte{
+				"db": []byte("db-value"),
+			},
+		},
+		{
+			name:        "list error",
+			listErr:     fmt.Errorf("oh no"),
+			expectError: "oh no",
+		},
+	}
+
+	for _, c := range tbl {
+		var filter string
+		mockClient := &fakesm.MockSMClient{}
+		mockClient.WithValue(context.Background(), &secretmanagerpb.AccessSecretVersionRequest{
+			Name: "projects/default/secrets/db/versions/latest",
+		}, &secretmanagerpb.AccessSecretVersionResponse{
+			Payload: &secretmanagerpb.SecretPayload{Data: []byte("db-value")},
+		}, nil)
+		mockClient.WithListSecrets(func(ctx context.Context, req *secretmanagerpb.ListSecretsRequest) ([]*secretmanagerpb.Secret, error) {
+			filter = req.Filter
+			return []*secretmanagerpb.Secret{
+				{Name: "projects/default/secrets/db"},
+				{Name: "projects/default/secrets/api"},
+			}, c.listErr
+		})
+		sm := ProviderGCP{
+			SecretManagerClient: mockClient,
+			projectID:           "default",
+		}
+		data, err := sm.GetAllSecrets(context.Background(), c.find)
+		if !ErrorContains(err, c.expectError) {
+			t.Errorf("[%s] unexpected error: %v, expected: '%s'", c.name, err, c.expectError)
+		}
+		if c.expectError != "" {
+			continue
+		}
+		if filter != c.expectFilter {
+			t.Errorf("[%s] unexpected filter: expected '%s', got '%s'", c.name, c.expectFilter, filter)
+		}
+		if !reflect.DeepEqual(c.expectData, data) {
+			t.Errorf("[%s] unexpected secrets: expected %v, got %v", c.name, c.expectData, data)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tbl := []struct {
+		name        string
+		listErr     error
+		expectRes   provider.ValidationResult
+		expectError bool
+	}{
+		{name: "list secrets", expectRes: provider.ValidationResultReady},
+		{name: "list denied", listErr: status.Error(codes.PermissionDenied, "denied"), expectRes: provider.ValidationResultUnknown},
+		{name: "unauthenticated", listErr: status.Error(codes.Unauthenticated, "invalid token"), expectRes: provider.ValidationResultError, expectError: true},
+	}
+	for _, c := range tbl {
+		var parent string
+		client := &fakesm.MockSMClient{}
+		client.WithListSecrets(func(ctx context.Context, req *secretmanagerpb.ListSecretsRequest) ([]*secretmanagerpb.Secret, error) {
+			parent = req.Parent
+			return nil, c.listErr
+		})
+		sm := ProviderGCP{projectID: "default", SecretManagerClient: client}
+		res, err := sm.Validate(context.Background())
+		if res != c.expectRes {
+			t.Errorf("[%s] unexpected result: expected %v, got %v", c.name, c.expectRes, res)
+		}
+		if (err != nil) != c.expectError {
+			t.Errorf("[%s] unexpected error: %v", c.name, err)
+		}
+		if parent != "projects/default" {
+			t.Errorf("[%s] unexpected parent %q", c.name, parent)
+		}
+	}
+}
@@ -37,3 +37,15 @@ func (mc *GitlabMockClient) WithValue(projectIDinput, keyInput string, output *g
 		}
 	}
 }
+
+// GitlabMockProjectsClient fakes the projects API used by Validate.
+type GitlabMockProjectsClient struct {
+	Err error
+}
+
+func (mc *GitlabMockProjectsClient) GetProject(pid interface{}, opt *gitlab.GetProjectOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Project, *gitlab.Response, error) {
+	if mc.Err != nil {
+		return nil, nil, mc.Err
+	}
+	return &gitlab.Project{}, nil, nil
+}
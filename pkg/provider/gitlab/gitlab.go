@@ -16,13 +16,14 @@ package gitlab
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/tidwall/gjson"
 	gitlab "github.com/xanzy/go-gitlab"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -47,10 +48,16 @@ type Client interface {
 	GetVariable(pid interface{}, key string, options ...gitlab.RequestOptionFunc) (*gitlab.ProjectVariable, *gitlab.Response, error)
 }
 
+// ProjectsClient is the part of the gitlab projects API used to validate the store.
+type ProjectsClient interface {
+	GetProject(pid interface{}, opt *gitlab.GetProjectOptions, options ...gitlab.RequestOptionFunc) (*gitlab.Project, *gitlab.Response, error)
+}
+
 // Gitlab Provider struct with reference to a GitLab client and a projectID.
 type Gitlab struct {
-	client    Client
-	projectID interface{}
+	client         Client
+	projectsClient ProjectsClient
+	projectID      interface{}
 }
 
 // Client for interacting with kubernetes cluster...?
@@ -142,18 +149,32 @@ func (g *Gitlab) NewClient(ctx context.Context, store esv1alpha1.GenericStore, k
 		log.Logf("Failed to create client: %v", err)
 	}
 
-	g.client = gitlabClient.ProjectVariables
-	g.projectID = cliStore.store.ProjectID
+	return &Gitlab{
+		client:         gitlabClient.ProjectVariables,
+		projectsClient: gitlabClient.Projects,
+		projectID:      cliStore.store.ProjectID,
+	}, nil
+}
 
-	return g, nil
+// ValidateStore checks the Gitlab specific part of the store spec.
+func (g *Gitlab) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "gitlab")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Gitlab == nil {
+		return field.ErrorList{field.Required(fldPath, "missing Gitlab provider")}
+	}
+	spec := storeSpec.Provider.Gitlab
+	allErrs := field.ErrorList{}
+	if spec.ProjectID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("projectID"), ""))
+	}
+	return append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.AccessToken, fldPath.Child("auth", "SecretRef", "accessToken"))...)
 }
 
 func (g *Gitlab) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	if utils.IsNil(g.client) {
 		return nil, fmt.Errorf(errUninitalizedGitlabProvider)
 	}
-	// Need to replace hyphens with underscores to work with Gitlab API
-	ref.Key = strings.ReplaceAll(ref.Key, "-", "_")
 	// Retrieves a gitlab variable in the form
 	// {
 	// 	"key": "TEST_VARIABLE_1",
@@ -162,6 +183,10 @@ func (g *Gitlab) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDat
 	// 	"protected": false,
 	// 	"masked": true
 	data, _, err := g.client.GetVariable(g.projectID, ref.Key, nil) // Optional 'filter' parameter could be added later
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		return nil, provider.FromHTTPStatus(errResp.Response.StatusCode, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +205,7 @@ func (g *Gitlab) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDat
 
 	val := gjson.Get(payload, ref.Property)
 	if !val.Exists() {
-		return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+		return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 	}
 	return []byte(val.String()), nil
 }
@@ -207,6 +232,28 @@ func (g *Gitlab) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecret
 	return secretData, nil
 }
 
+// GetAllSecrets is not supported by the Gitlab provider.
+func (g *Gitlab) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
+
+// Validate fetches the project of the store, which checks the access token
+// and the projectID without reading any of the project variables.
+func (g *Gitlab) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	if utils.IsNil(g.projectsClient) {
+		return provider.ValidationResultError, fmt.Errorf(errUninitalizedGitlabProvider)
+	}
+	_, _, err := g.projectsClient.GetProject(g.projectID, nil, gitlab.WithContext(ctx))
+	var errResp *gitlab.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		return provider.ProbeResult(provider.FromHTTPStatus(errResp.Response.StatusCode, err))
+	}
+	if err != nil {
+		return provider.ValidationResultError, err
+	}
+	return provider.ValidationResultReady, nil
+}
+
 func (g *Gitlab) Close(ctx context.Context) error {
 	return nil
 }
@@ -16,6 +16,7 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
@@ -23,6 +24,7 @@ import (
 	gitlab "github.com/xanzy/go-gitlab"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakegitlab "github.com/external-secrets/external-secrets/pkg/provider/gitlab/fake"
 )
 
@@ -100,6 +102,21 @@ var setNilMockClient = func(smtc *secretManagerTestCase) {
 	smtc.expectError = errUninitalizedGitlabProvider
 }
 
+func TestGitlabSecretManagerGetSecretNotFound(t *testing.T) {
+	smtc := makeValidSecretManagerTestCase()
+	smtc.apiErr = &gitlab.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+		Message:  "404 Variable Not Found",
+	}
+	smtc.mockClient.WithValue(smtc.apiInputProjectID, smtc.apiInputKey, smtc.apiOutput, smtc.apiErr)
+
+	sm := Gitlab{client: smtc.mockClient}
+	_, err := sm.GetSecret(context.Background(), *smtc.ref)
+	if !provider.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
 // test the sm<->gcp interface
 // make sure correct values are passed and errors are handled accordingly.
 func TestGitlabSecretManagerGetSecret(t *testing.T) {
@@ -176,3 +193,29 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestValidate(t *testing.T) {
+	apiErr := func(code int) error {
+		return &gitlab.ErrorResponse{Response: &http.Response{StatusCode: code}, Message: http.StatusText(code)}
+	}
+	for _, c := range []struct {
+		name      string
+		apiErr    error
+		expectRes provider.ValidationResult
+		expectErr bool
+	}{
+		{name: "project found", expectRes: provider.ValidationResultReady},
+		{name: "forbidden", apiErr: apiErr(http.StatusForbidden), expectRes: provider.ValidationResultUnknown},
+		{name: "unauthorized", apiErr: apiErr(http.StatusUnauthorized), expectRes: provider.ValidationResultError, expectErr: true},
+		{name: "missing project", apiErr: apiErr(http.StatusNotFound), expectRes: provider.ValidationResultError, expectErr: true},
+	} {
+		sm := Gitlab{projectsClient: &fakegitlab.GitlabMockProjectsClient{Err: c.apiErr}, projectID: "1234"}
+		res, err := sm.Validate(context.Background())
+		if res != c.expectRes {
+			t.Errorf("[%s] unexpected result: expected %v, got %v", c.name, c.expectRes, res)
+		}
+		if (err != nil) != c.expectErr {
+			t.Errorf("[%s] unexpected error: %v", c.name, err)
+		}
+	}
+}
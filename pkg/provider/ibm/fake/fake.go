@@ -14,7 +14,9 @@ limitations under the License.
 package fake
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	sm "github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
@@ -23,7 +25,8 @@ import (
 )
 
 type IBMMockClient struct {
-	getSecret func(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error)
+	getSecret      func(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error)
+	listAllSecrets func(listAllSecretsOptions *sm.ListAllSecretsOptions) (result *sm.ListSecrets, response *core.DetailedResponse, err error)
 }
 
 func (mc *IBMMockClient) GetSecret(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error) {
@@ -42,3 +45,31 @@ func (mc *IBMMockClient) WithValue(input *sm.GetSecretOptions, output *sm.GetSec
 		}
 	}
 }
+
+func (mc *IBMMockClient) WithNotFound() {
+	if mc != nil {
+		mc.getSecret = func(paramReq *sm.GetSecretOptions) (*sm.GetSecret, *core.DetailedResponse, error) {
+			return nil, &core.DetailedResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("Not found")
+		}
+	}
+}
+
+func (mc *IBMMockClient) ListAllSecretsWithContext(ctx context.Context, listAllSecretsOptions *sm.ListAllSecretsOptions) (result *sm.ListSecrets, response *core.DetailedResponse, err error) {
+	return mc.listAllSecrets(listAllSecretsOptions)
+}
+
+// WithListStatus makes ListAllSecretsWithContext respond with the status code, an error
+// is returned for any status other than 200. The list is expected to be limited to one secret.
+func (mc *IBMMockClient) WithListStatus(statusCode int) {
+	if mc != nil {
+		mc.listAllSecrets = func(paramReq *sm.ListAllSecretsOptions) (*sm.ListSecrets, *core.DetailedResponse, error) {
+			if paramReq.Limit == nil || *paramReq.Limit != 1 {
+				return nil, nil, fmt.Errorf("unexpected test argument")
+			}
+			if statusCode != http.StatusOK {
+				return nil, &core.DetailedResponse{StatusCode: statusCode}, fmt.Errorf("%s", http.StatusText(statusCode))
+			}
+			return &sm.ListSecrets{}, &core.DetailedResponse{StatusCode: statusCode}, nil
+		}
+	}
+}
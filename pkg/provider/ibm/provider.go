@@ -18,12 +18,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/IBM/go-sdk-core/v5/core"
 	sm "github.com/IBM/secrets-manager-go-sdk/secretsmanagerv1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -48,6 +48,7 @@ const (
 
 type SecretManagerClient interface {
 	GetSecret(getSecretOptions *sm.GetSecretOptions) (result *sm.GetSecret, response *core.DetailedResponse, err error)
+	ListAllSecretsWithContext(ctx context.Context, listAllSecretsOptions *sm.ListAllSecretsOptions) (result *sm.ListSecrets, response *core.DetailedResponse, err error)
 }
 
 type providerIBM struct {
@@ -135,8 +136,17 @@ func (ibm *providerIBM) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSe
 	}
 }
 
+// getSecret fetches a secret and categorizes errors by the status code of the response.
+func (ibm *providerIBM) getSecret(getSecretOptions *sm.GetSecretOptions) (*sm.GetSecret, error) {
+	secret, response, err := ibm.IBMClient.GetSecret(getSecretOptions)
+	if err != nil && response != nil {
+		return nil, provider.FromHTTPStatus(response.StatusCode, err)
+	}
+	return secret, err
+}
+
 func getArbitrarySecret(ibm *providerIBM, secretName *string) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
+	response, err := ibm.getSecret(
 		&sm.GetSecretOptions{
 			SecretType: core.StringPtr(sm.GetSecretOptionsSecretTypeArbitraryConst),
 			ID:         secretName,
@@ -152,7 +162,7 @@ func getArbitrarySecret(ibm *providerIBM, secretName *string) ([]byte, error) {
 }
 
 func getImportCertSecret(ibm *providerIBM, secretName *string, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
+	response, err := ibm.getSecret(
 		&sm.GetSecretOptions{
 			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeImportedCertConst),
 			ID:         secretName,
@@ -167,11 +177,11 @@ func getImportCertSecret(ibm *providerIBM, secretName *string, ref esv1alpha1.Ex
 	if val, ok := secretData[ref.Property]; ok {
 		return []byte(val.(string)), nil
 	}
-	return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+	return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 }
 
 func getIamCredentialsSecret(ibm *providerIBM, secretName *string) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
+	response, err := ibm.getSecret(
 		&sm.GetSecretOptions{
 			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeIamCredentialsConst),
 			ID:         secretName,
@@ -187,7 +197,7 @@ func getIamCredentialsSecret(ibm *providerIBM, secretName *string) ([]byte, erro
 }
 
 func getUsernamePasswordSecret(ibm *providerIBM, secretName *string, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	response, _, err := ibm.IBMClient.GetSecret(
+	response, err := ibm.getSecret(
 		&sm.GetSecretOptions{
 			SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeUsernamePasswordConst),
 			ID:         secretName,
@@ -202,7 +212,7 @@ func getUsernamePasswordSecret(ibm *providerIBM, secretName *string, ref esv1alp
 	if val, ok := secretData[ref.Property]; ok {
 		return []byte(val.(string)), nil
 	}
-	return nil, fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key)
+	return nil, provider.NewInvalidRefError(fmt.Errorf("key %s does not exist in secret %s", ref.Property, ref.Key))
 }
 
 func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
@@ -221,7 +231,7 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1alpha1.Externa
 
 	switch secretType {
 	case sm.GetSecretOptionsSecretTypeArbitraryConst:
-		response, _, err := ibm.IBMClient.GetSecret(
+		response, err := ibm.getSecret(
 			&sm.GetSecretOptions{
 				SecretType: core.StringPtr(sm.GetSecretOptionsSecretTypeArbitraryConst),
 				ID:         &ref.Key,
@@ -245,7 +255,7 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1alpha1.Externa
 		return secretMap, nil
 
 	case sm.CreateSecretOptionsSecretTypeUsernamePasswordConst:
-		response, _, err := ibm.IBMClient.GetSecret(
+		response, err := ibm.getSecret(
 			&sm.GetSecretOptions{
 				SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeUsernamePasswordConst),
 				ID:         &secretName,
@@ -262,7 +272,7 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1alpha1.Externa
 		return secretMap, nil
 
 	case sm.CreateSecretOptionsSecretTypeIamCredentialsConst:
-		response, _, err := ibm.IBMClient.GetSecret(
+		response, err := ibm.getSecret(
 			&sm.GetSecretOptions{
 				SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeIamCredentialsConst),
 				ID:         &secretName,
@@ -280,7 +290,7 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1alpha1.Externa
 		return secretMap, nil
 
 	case sm.CreateSecretOptionsSecretTypeImportedCertConst:
-		response, _, err := ibm.IBMClient.GetSecret(
+		response, err := ibm.getSecret(
 			&sm.GetSecretOptions{
 				SecretType: core.StringPtr(sm.CreateSecretOptionsSecretTypeImportedCertConst),
 				ID:         &secretName,
@@ -301,6 +311,11 @@ func (ibm *providerIBM) GetSecretMap(ctx context.Context, ref esv1alpha1.Externa
 	}
 }
 
+// GetAllSecrets is not supported by the IBM provider.
+func (ibm *providerIBM) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
+
 func byteArrayMap(secretData map[string]interface{}) map[string][]byte {
 	secretMap := make(map[string][]byte)
 	for k, v := range secretData {
@@ -309,6 +324,24 @@ func byteArrayMap(secretData map[string]interface{}) map[string][]byte {
 	return secretMap
 }
 
+// Validate lists the metadata of a single secret of the instance, the IAM
+// token is exchanged for the apikey on the way, so a revoked key fails here.
+func (ibm *providerIBM) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	if utils.IsNil(ibm.IBMClient) {
+		return provider.ValidationResultError, fmt.Errorf(errUninitalizedIBMProvider)
+	}
+	_, response, err := ibm.IBMClient.ListAllSecretsWithContext(ctx, &sm.ListAllSecretsOptions{
+		Limit: core.Int64Ptr(1),
+	})
+	if err != nil && response != nil {
+		return provider.ProbeResult(provider.FromHTTPStatus(response.StatusCode, err))
+	}
+	if err != nil {
+		return provider.ValidationResultError, err
+	}
+	return provider.ValidationResultReady, nil
+}
+
 func (ibm *providerIBM) Close(ctx context.Context) error {
 	return nil
 }
@@ -334,35 +367,28 @@ func (ibm *providerIBM) NewClient(ctx context.Context, store esv1alpha1.GenericS
 			ApiKey: string(iStore.credentials),
 		},
 	})
-
-	// Setup retry options, but only if present
-	if storeSpec.RetrySettings != nil {
-		var retryAmount int
-		var retryDuration time.Duration
-
-		if storeSpec.RetrySettings.MaxRetries != nil {
-			retryAmount = int(*storeSpec.RetrySettings.MaxRetries)
-		} else {
-			retryAmount = 3
-		}
-
-		if storeSpec.RetrySettings.RetryInterval != nil {
-			retryDuration, err = time.ParseDuration(*storeSpec.RetrySettings.RetryInterval)
-		} else {
-			retryDuration = 5 * time.Second
-		}
-
-		if err == nil {
-			secretsManager.Service.EnableRetries(retryAmount, retryDuration)
-		}
-	}
-
+	// the retrySettings of the store are applied by the retry client of the controller,
+	// the retries of the SDK would multiply them
 	if err != nil {
 		return nil, fmt.Errorf(errIBMClient, err)
 	}
 
-	ibm.IBMClient = secretsManager
-	return ibm, nil
+	return &providerIBM{IBMClient: secretsManager}, nil
+}
+
+// ValidateStore checks the IBM specific part of the store spec.
+func (ibm *providerIBM) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "ibm")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.IBM == nil {
+		return field.ErrorList{field.Required(fldPath, "missing IBM provider")}
+	}
+	spec := storeSpec.Provider.IBM
+	allErrs := field.ErrorList{}
+	if spec.ServiceURL == nil || *spec.ServiceURL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceUrl"), ""))
+	}
+	return append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.SecretAPIKey, fldPath.Child("auth", "secretRef", "secretApiKeySecretRef"))...)
 }
 
 func init() {
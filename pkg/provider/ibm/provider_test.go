@@ -16,6 +16,7 @@ package ibm
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
@@ -29,6 +30,7 @@ import (
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	v1 "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakesm "github.com/external-secrets/external-secrets/pkg/provider/ibm/fake"
 )
 
@@ -111,6 +113,17 @@ var setNilMockClient = func(smtc *secretManagerTestCase) {
 	smtc.expectError = errUninitalizedIBMProvider
 }
 
+func TestIBMSecretManagerGetSecretNotFound(t *testing.T) {
+	smtc := makeValidSecretManagerTestCase()
+	smtc.mockClient.WithNotFound()
+
+	sm := providerIBM{IBMClient: smtc.mockClient}
+	_, err := sm.GetSecret(context.Background(), *smtc.ref)
+	if !provider.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
 // test the sm<->gcp interface
 // make sure correct values are passed and errors are handled accordingly.
 func TestIBMSecretManagerGetSecret(t *testing.T) {
@@ -376,10 +389,12 @@ func TestGetSecretMap(t *testing.T) {
 	}
 }
 
-func TestValidRetryInput(t *testing.T) {
-	sm := providerIBM{}
+// The retrySettings of the store are applied by the retry client of the controller,
+// the SDK must not retry the requests on its own.
+func TestNewClientWithoutSDKRetries(t *testing.T) {
+	ibm := providerIBM{}
 
-	invalid := "Invalid"
+	interval := "1s"
 	serviceURL := "http://fake-service-url.cool"
 
 	spec := &esv1alpha1.SecretStore{
@@ -398,12 +413,12 @@ func TestValidRetryInput(t *testing.T) {
 				},
 			},
 			RetrySettings: &esv1alpha1.SecretStoreRetrySettings{
-				RetryInterval: &invalid,
+				MaxRetries:    utilpointer.Int32(3),
+				RetryInterval: &interval,
 			},
 		},
 	}
 
-	expected := fmt.Sprintf("cannot setup new ibm client: time: invalid duration %q", invalid)
 	ctx := context.TODO()
 	kube := &test.MockClient{
 		MockGet: test.NewMockGetFn(nil, func(obj kclient.Object) error {
@@ -417,10 +432,13 @@ func TestValidRetryInput(t *testing.T) {
 		}),
 	}
 
-	_, err := sm.NewClient(ctx, spec, kube, "default")
-
-	if !ErrorContains(err, expected) {
-		t.Errorf("CheckValidRetryInput unexpected error: %s, expected: '%s'", err.Error(), expected)
+	secretClient, err := ibm.NewClient(ctx, spec, kube, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	service := secretClient.(*providerIBM).IBMClient.(*sm.SecretsManagerV1).Service
+	if _, ok := service.Client.Transport.(*http.Transport); !ok {
+		t.Errorf("expected the default transport, got %T", service.Client.Transport)
 	}
 }
 
@@ -433,3 +451,26 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestValidate(t *testing.T) {
+	for _, c := range []struct {
+		statusCode int
+		expectRes  provider.ValidationResult
+		expectErr  bool
+	}{
+		{statusCode: http.StatusOK, expectRes: provider.ValidationResultReady},
+		{statusCode: http.StatusForbidden, expectRes: provider.ValidationResultUnknown},
+		{statusCode: http.StatusUnauthorized, expectRes: provider.ValidationResultError, expectErr: true},
+	} {
+		mockClient := &fakesm.IBMMockClient{}
+		mockClient.WithListStatus(c.statusCode)
+		ibm := providerIBM{IBMClient: mockClient}
+		res, err := ibm.Validate(context.Background())
+		if res != c.expectRes {
+			t.Errorf("[%d] unexpected result: expected %v, got %v", c.statusCode, c.expectRes, res)
+		}
+		if (err != nil) != c.expectErr {
+			t.Errorf("[%d] unexpected error: %v", c.statusCode, err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics measures the calls of the provider clients.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+)
+
+const (
+	ProviderSubsystem  = "provider"
+	RequestsKey        = "requests_total"
+	RequestDurationKey = "request_duration_seconds"
+
+	// OperationAuth is the operation label of the construction of a provider client,
+	// which authenticates with the provider.
+	OperationAuth = "auth"
+
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+var (
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      RequestsKey,
+		Help:      "Total number of the provider calls by provider, store, operation and status",
+	}, []string{"provider", "name", "namespace", "kind", "operation", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      RequestDurationKey,
+		Help:      "Duration of the provider calls by provider, store and operation",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"provider", "name", "namespace", "kind", "operation"})
+)
+
+// NewProvider wraps the provider, so the construction of its clients is measured
+// as operation auth and the calls of the clients by their operation.
+func NewProvider(prov provider.Provider) provider.Provider {
+	return &instrumentedProvider{Provider: prov}
+}
+
+type instrumentedProvider struct {
+	provider.Provider
+}
+
+// NewClient implements provider.Provider.
+func (p *instrumentedProvider) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (provider.SecretsClient, error) {
+	providerName, _ := schema.GetProviderName(store)
+	start := time.Now()
+	secretClient, err := p.Provider.NewClient(ctx, store, kube, namespace)
+	observe(storeLabels(store, providerName, OperationAuth), start, err)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(store, providerName, secretClient), nil
+}
+
+// Client is a provider.SecretsClient that measures the calls of the wrapped client.
+type Client struct {
+	client       provider.SecretsClient
+	store        esv1alpha1.GenericStore
+	providerName string
+}
+
+var _ provider.SecretsClient = &Client{}
+
+// NewClient wraps the client of the store.
+func NewClient(store esv1alpha1.GenericStore, client provider.SecretsClient) provider.SecretsClient {
+	providerName, _ := schema.GetProviderName(store)
+	return newClient(store, providerName, client)
+}
+
+func newClient(store esv1alpha1.GenericStore, providerName string, client provider.SecretsClient) provider.SecretsClient {
+	c := &Client{client: client, store: store, providerName: providerName}
+	if reader, ok := client.(provider.SecretsBatchReader); ok {
+		return &BatchClient{Client: c, reader: reader}
+	}
+	return c
+}
+
+// GetSecret implements provider.SecretsClient.
+func (c *Client) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	start := time.Now()
+	secret, err := c.client.GetSecret(ctx, ref)
+	c.observe("GetSecret", start, err)
+	return secret, err
+}
+
+// GetSecretMap implements provider.SecretsClient.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	start := time.Now()
+	secretMap, err := c.client.GetSecretMap(ctx, ref)
+	c.observe("GetSecretMap", start, err)
+	return secretMap, err
+}
+
+// GetAllSecrets implements provider.SecretsClient.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	start := time.Now()
+	secrets, err := c.client.GetAllSecrets(ctx, ref)
+	c.observe("GetAllSecrets", start, err)
+	return secrets, err
+}
+
+// Validate implements provider.SecretsClient.
+func (c *Client) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	start := time.Now()
+	res, err := c.client.Validate(ctx)
+	c.observe("Validate", start, err)
+	return res, err
+}
+
+// Close implements provider.SecretsClient.
+func (c *Client) Close(ctx context.Context) error {
+	return c.client.Close(ctx)
+}
+
+// BatchClient is a Client of a client that implements provider.SecretsBatchReader.
+type BatchClient struct {
+	*Client
+	reader provider.SecretsBatchReader
+}
+
+var _ provider.SecretsBatchReader = &BatchClient{}
+
+// GetSecrets implements provider.SecretsBatchReader.
+// The batch is counted as a single call that failed if any of the refs failed.
+func (c *BatchClient) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	start := time.Now()
+	results := c.reader.GetSecrets(ctx, refs)
+	var err error
+	for _, res := range results {
+		if res.Err != nil {
+			err = res.Err
+			break
+		}
+	}
+	c.observe("GetSecrets", start, err)
+	return results
+}
+
+func (c *Client) observe(operation string, start time.Time, err error) {
+	observe(storeLabels(c.store, c.providerName, operation), start, err)
+}
+
+func observe(labels prometheus.Labels, start time.Time, err error) {
+	requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	labels["status"] = statusSuccess
+	if err != nil {
+		labels["status"] = statusError
+	}
+	requests.With(labels).Inc()
+}
+
+func storeLabels(store esv1alpha1.GenericStore, providerName, operation string) prometheus.Labels {
+	kind := esv1alpha1.SecretStoreKind
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok {
+		kind = esv1alpha1.ClusterSecretStoreKind
+	}
+	return prometheus.Labels{
+		"provider":  providerName,
+		"name":      store.GetName(),
+		"namespace": store.GetNamespace(),
+		"kind":      kind,
+		"operation": operation,
+	}
+}
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(requests, requestDuration)
+}
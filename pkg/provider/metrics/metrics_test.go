@@ -0,0 +1,109 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+)
+
+func makeStore(name string) *esv1alpha1.SecretStore {
+	return &esv1alpha1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				AWS: &esv1alpha1.AWSProvider{},
+			},
+		},
+	}
+}
+
+func requestCount(store esv1alpha1.GenericStore, operation, status string) float64 {
+	labels := storeLabels(store, "aws", operation)
+	labels["status"] = status
+	return testutil.ToFloat64(requests.With(labels))
+}
+
+func durationCount(store esv1alpha1.GenericStore, operation string) uint64 {
+	var m dto.Metric
+	observer := requestDuration.With(storeLabels(store, "aws", operation))
+	if err := observer.(prometheus.Histogram).Write(&m); err != nil {
+		return 0
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestProviderMeasuresCalls(t *testing.T) {
+	fakeProvider := fake.New()
+	fakeProvider.WithGetSecret([]byte("value"), nil)
+	fakeProvider.WithGetSecretMap(nil, errors.New("boom"))
+	store := makeStore("measured")
+
+	secretClient, err := NewProvider(fakeProvider).NewClient(context.Background(), store, nil, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := secretClient.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "foo"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := secretClient.GetSecretMap(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "foo"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	for _, tc := range []struct {
+		operation string
+		status    string
+	}{
+		{OperationAuth, statusSuccess},
+		{"GetSecret", statusSuccess},
+		{"GetSecretMap", statusError},
+	} {
+		if got := requestCount(store, tc.operation, tc.status); got != 1 {
+			t.Errorf("%s: expected 1 %s request, got %v", tc.operation, tc.status, got)
+		}
+		if got := durationCount(store, tc.operation); got != 1 {
+			t.Errorf("%s: expected 1 observed duration, got %v", tc.operation, got)
+		}
+	}
+}
+
+func TestProviderMeasuresFailedAuth(t *testing.T) {
+	fakeProvider := fake.New()
+	fakeProvider.WithNew(func(context.Context, esv1alpha1.GenericStore, client.Client, string) (provider.SecretsClient, error) {
+		return nil, errors.New("invalid credentials")
+	})
+	store := makeStore("failed-auth")
+
+	if _, err := NewProvider(fakeProvider).NewClient(context.Background(), store, nil, "default"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got := requestCount(store, OperationAuth, statusError); got != 1 {
+		t.Errorf("expected 1 failed auth, got %v", got)
+	}
+}
@@ -20,7 +20,8 @@ import (
 )
 
 type OracleMockClient struct {
-	getSecret func(ctx context.Context, request vault.GetSecretRequest) (response vault.GetSecretResponse, err error)
+	getSecret      func(ctx context.Context, request vault.GetSecretRequest) (response vault.GetSecretResponse, err error)
+	listSecretsErr error
 }
 
 func (mc *OracleMockClient) GetSecret(ctx context.Context, request vault.GetSecretRequest) (response vault.GetSecretResponse, err error) {
@@ -34,3 +35,14 @@ func (mc *OracleMockClient) WithValue(input vault.GetSecretRequest, output vault
 		}
 	}
 }
+
+func (mc *OracleMockClient) ListSecrets(ctx context.Context, request vault.ListSecretsRequest) (response vault.ListSecretsResponse, err error) {
+	return vault.ListSecretsResponse{}, mc.listSecretsErr
+}
+
+// WithListSecretsErr makes ListSecrets fail with err.
+func (mc *OracleMockClient) WithListSecretsErr(err error) {
+	if mc != nil {
+		mc.listSecretsErr = err
+	}
+}
@@ -2,7 +2,9 @@
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
+
     http://www.apache.org/licenses/LICENSE-2.0
+
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
 WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
@@ -14,13 +16,16 @@ package oracle
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/oracle/oci-go-sdk/v45/common"
 	vault "github.com/oracle/oci-go-sdk/v45/vault"
 	"github.com/tidwall/gjson"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -63,11 +68,13 @@ type client struct {
 }
 
 type VaultManagementService struct {
-	Client VMInterface
+	Client  VMInterface
+	tenancy string
 }
 
 type VMInterface interface {
 	GetSecret(ctx context.Context, request vault.GetSecretRequest) (response vault.GetSecretResponse, err error)
+	ListSecrets(ctx context.Context, request vault.ListSecretsRequest) (response vault.ListSecretsResponse, err error)
 }
 
 func (c *client) setAuth(ctx context.Context) error {
@@ -130,6 +137,10 @@ func (vms *VaultManagementService) GetSecret(ctx context.Context, ref esv1alpha1
 		SecretId: &ref.Key,
 	}
 	secretOut, err := vms.Client.GetSecret(context.Background(), vmsRequest)
+	var serviceErr common.ServiceError
+	if errors.As(err, &serviceErr) {
+		return nil, provider.FromHTTPStatus(serviceErr.GetHTTPStatusCode(), util.SanitizeErr(err))
+	}
 	if err != nil {
 		return nil, util.SanitizeErr(err)
 	}
@@ -148,7 +159,7 @@ func (vms *VaultManagementService) GetSecret(ctx context.Context, ref esv1alpha1
 
 	val := gjson.Get(payloadval, ref.Property)
 	if !val.Exists() {
-		return nil, fmt.Errorf(errMissingKey, ref.Key)
+		return nil, provider.NewInvalidRefError(fmt.Errorf(errMissingKey, ref.Key))
 	}
 
 	return []byte(val.String()), nil
@@ -171,6 +182,11 @@ func (vms *VaultManagementService) GetSecretMap(ctx context.Context, ref esv1alp
 	return secretData, nil
 }
 
+// GetAllSecrets is not supported by the Oracle provider.
+func (vms *VaultManagementService) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
+
 // NewClient constructs a new secrets client based on the provided store.
 func (vms *VaultManagementService) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube kclient.Client, namespace string) (provider.SecretsClient, error) {
 	storeSpec := store.GetSpec()
@@ -198,8 +214,57 @@ func (vms *VaultManagementService) NewClient(ctx context.Context, store esv1alph
 	if err != nil {
 		return nil, fmt.Errorf(errOracleClient, err)
 	}
-	vms.Client = vaultManagementService
-	return vms, nil
+	return &VaultManagementService{Client: vaultManagementService, tenancy: oracleTenancy}, nil
+}
+
+// ValidateStore checks the Oracle specific part of the store spec.
+func (vms *VaultManagementService) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "oracle")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Oracle == nil {
+		return field.ErrorList{field.Required(fldPath, "missing Oracle provider")}
+	}
+	spec := storeSpec.Provider.Oracle
+	allErrs := field.ErrorList{}
+	if spec.User == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("user"), ""))
+	}
+	if spec.Tenancy == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tenancy"), ""))
+	}
+	if spec.Region == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("region"), ""))
+	}
+	refPath := fldPath.Child("auth", "secretRef")
+	allErrs = append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.PrivateKey, refPath.Child("privatekey"))...)
+	return append(allErrs, utils.ValidateSecretSelector(store, spec.Auth.SecretRef.Fingerprint, refPath.Child("fingerprint"))...)
+}
+
+// Validate lists one secret of the root compartment of the tenancy, the
+// request is signed with the API key of the user. The store doesn't say which
+// compartment holds the secrets, so a user whose policies are limited to
+// another compartment is denied and the result is Unknown. OCI answers such
+// a request with 404 NotAuthorizedOrNotFound rather than 403.
+func (vms *VaultManagementService) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	if utils.IsNil(vms.Client) {
+		return provider.ValidationResultError, fmt.Errorf(errUninitalizedOracleProvider)
+	}
+	_, err := vms.Client.ListSecrets(ctx, vault.ListSecretsRequest{
+		CompartmentId: &vms.tenancy,
+		Limit:         common.Int(1),
+	})
+	var serviceErr common.ServiceError
+	if errors.As(err, &serviceErr) {
+		statusCode := serviceErr.GetHTTPStatusCode()
+		if statusCode == http.StatusNotFound {
+			statusCode = http.StatusForbidden
+		}
+		return provider.ProbeResult(provider.FromHTTPStatus(statusCode, util.SanitizeErr(err)))
+	}
+	if err != nil {
+		return provider.ValidationResultError, util.SanitizeErr(err)
+	}
+	return provider.ValidationResultReady, nil
 }
 
 func (vms *VaultManagementService) Close(ctx context.Context) error {
@@ -2,7 +2,9 @@
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
+
     http://www.apache.org/licenses/LICENSE-2.0
+
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
 WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
@@ -14,6 +16,7 @@ package oracle
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
@@ -22,6 +25,7 @@ import (
 	utilpointer "k8s.io/utils/pointer"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	fakeoracle "github.com/external-secrets/external-secrets/pkg/provider/oracle/fake"
 )
 
@@ -93,6 +97,28 @@ var setNilMockClient = func(smtc *vaultTestCase) {
 	smtc.expectError = errUninitalizedOracleProvider
 }
 
+type fakeServiceError struct {
+	statusCode int
+}
+
+func (e fakeServiceError) Error() string           { return fmt.Sprintf("service error %d", e.statusCode) }
+func (e fakeServiceError) GetHTTPStatusCode() int  { return e.statusCode }
+func (e fakeServiceError) GetMessage() string      { return "" }
+func (e fakeServiceError) GetCode() string         { return "" }
+func (e fakeServiceError) GetOpcRequestID() string { return "" }
+
+func TestOracleVaultGetSecretNotFound(t *testing.T) {
+	smtc := makeValidVaultTestCase()
+	smtc.apiErr = fakeServiceError{statusCode: http.StatusNotFound}
+	smtc.mockClient.WithValue(*smtc.apiInput, *smtc.apiOutput, smtc.apiErr)
+
+	sm := VaultManagementService{Client: smtc.mockClient}
+	_, err := sm.GetSecret(context.Background(), *smtc.ref)
+	if !provider.IsNotFound(err) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
 func TestOracleVaultGetSecret(t *testing.T) {
 	secretValue := "changedvalue"
 	// good case: default version is set
@@ -171,3 +197,29 @@ func ErrorContains(out error, want string) bool {
 	}
 	return strings.Contains(out.Error(), want)
 }
+
+func TestValidate(t *testing.T) {
+	for _, c := range []struct {
+		statusCode int
+		expectRes  provider.ValidationResult
+		expectErr  bool
+	}{
+		{statusCode: http.StatusOK, expectRes: provider.ValidationResultReady},
+		{statusCode: http.StatusNotFound, expectRes: provider.ValidationResultUnknown},
+		{statusCode: http.StatusForbidden, expectRes: provider.ValidationResultUnknown},
+		{statusCode: http.StatusUnauthorized, expectRes: provider.ValidationResultError, expectErr: true},
+	} {
+		mockClient := &fakeoracle.OracleMockClient{}
+		if c.statusCode != http.StatusOK {
+			mockClient.WithListSecretsErr(fakeServiceError{statusCode: c.statusCode})
+		}
+		vms := VaultManagementService{Client: mockClient, tenancy: "ocid1.tenancy.oc1..example"}
+		res, err := vms.Validate(context.Background())
+		if res != c.expectRes {
+			t.Errorf("[%d] unexpected result: expected %v, got %v", c.statusCode, c.expectRes, res)
+		}
+		if (err != nil) != c.expectErr {
+			t.Errorf("[%d] unexpected error: %v", c.statusCode, err)
+		}
+	}
+}
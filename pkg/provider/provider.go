@@ -16,18 +16,92 @@ package provider
 
 import (
 	"context"
+	"errors"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 )
 
+// ErrGetAllSecretsNotImplemented is returned by SecretsClients
+// that can not find secrets in their provider.
+var ErrGetAllSecretsNotImplemented = errors.New("finding secrets is not supported by this provider")
+
 // Provider is a common interface for interacting with secret backends.
 type Provider interface {
 	// NewClient constructs a SecretsManager Provider
 	NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (SecretsClient, error)
 }
 
+// StoreValidator is an optional interface a Provider can implement
+// to statically check the store spec without talking to the provider.
+type StoreValidator interface {
+	// ValidateStore returns the errors found in the provider specific part of the store spec.
+	// Every error points to the offending field, e.g. spec.provider.vault.auth.
+	ValidateStore(store esv1alpha1.GenericStore) field.ErrorList
+}
+
+// SecretsWriter is an optional interface a SecretsClient can implement
+// to write secrets back to the provider.
+type SecretsWriter interface {
+	// PushSecret writes the value to the location referenced by remoteRef.
+	// If remoteRef.Property is set only that property of the remote secret is changed.
+	PushSecret(ctx context.Context, value []byte, remoteRef esv1alpha1.PushSecretRemoteRef) error
+}
+
+// SecretsBatchReader is an optional interface a SecretsClient can implement
+// to read many secrets with fewer requests than a GetSecret call per ref.
+type SecretsBatchReader interface {
+	// GetSecrets returns the result of every ref, in the order of the refs.
+	// The error of a single ref does not fail the other refs.
+	GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []SecretResult
+}
+
+// ConcurrentReader is an optional interface a Provider can implement when
+// the GetSecret method of its clients is safe for concurrent use.
+type ConcurrentReader interface {
+	// ConcurrentGetSecret is a marker, it is never called.
+	ConcurrentGetSecret()
+}
+
+// SecretResult is the value or error of a single ref read by SecretsBatchReader.GetSecrets.
+type SecretResult struct {
+	Value []byte
+	Err   error
+}
+
+// ValidationResult is the outcome of a SecretsClient.Validate call.
+type ValidationResult uint8
+
+const (
+	// ValidationResultReady indicates that the client is configured correctly
+	// and was able to reach the provider.
+	ValidationResultReady ValidationResult = iota
+
+	// ValidationResultUnknown indicates that the client can be used
+	// but the provider does not offer a cheap way to verify connectivity.
+	ValidationResultUnknown
+
+	// ValidationResultError indicates that the client is misconfigured
+	// or the provider could not be reached.
+	ValidationResultError
+)
+
+// ProbeResult returns the outcome of a Validate call that made a cheap read-only
+// request to the provider, err is the categorized error of the request.
+// A denied request is ValidationResultUnknown: the provider accepted the credentials,
+// they may be allowed to read secrets but not to make the request.
+func ProbeResult(err error) (ValidationResult, error) {
+	switch {
+	case err == nil:
+		return ValidationResultReady, nil
+	case IsPermissionDenied(err):
+		return ValidationResultUnknown, nil
+	}
+	return ValidationResultError, err
+}
+
 // SecretsClient provides access to secrets.
 type SecretsClient interface {
 	// GetSecret returns a single secret from the provider
@@ -35,5 +109,13 @@ type SecretsClient interface {
 
 	// GetSecretMap returns multiple k/v pairs from the provider
 	GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error)
+
+	// GetAllSecrets returns the value of every secret that matches the find criteria,
+	// keyed by the name of the secret in the provider
+	GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error)
+
+	// Validate checks if the client is able to talk to the provider
+	Validate(ctx context.Context) (ValidationResult, error)
+
 	Close(ctx context.Context) error
 }
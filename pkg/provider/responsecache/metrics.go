@@ -0,0 +1,67 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+const (
+	ProviderSubsystem = "provider"
+	CacheHitsKey      = "response_cache_hits_total"
+	CacheMissesKey    = "response_cache_misses_total"
+)
+
+var (
+	cacheHits = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      CacheHitsKey,
+		Help:      "Total number of the provider calls served from the response cache",
+	}, []string{"name", "namespace", "kind", "operation"})
+
+	cacheMisses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ProviderSubsystem,
+		Name:      CacheMissesKey,
+		Help:      "Total number of the provider calls not found in the response cache",
+	}, []string{"name", "namespace", "kind", "operation"})
+)
+
+func countHit(store esv1alpha1.GenericStore, operation string) {
+	cacheHits.With(storeLabels(store, operation)).Inc()
+}
+
+func countMiss(store esv1alpha1.GenericStore, operation string) {
+	cacheMisses.With(storeLabels(store, operation)).Inc()
+}
+
+func storeLabels(store esv1alpha1.GenericStore, operation string) prometheus.Labels {
+	kind := esv1alpha1.SecretStoreKind
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok {
+		kind = esv1alpha1.ClusterSecretStoreKind
+	}
+	return prometheus.Labels{
+		"name":      store.GetName(),
+		"namespace": store.GetNamespace(),
+		"kind":      kind,
+		"operation": operation,
+	}
+}
+
+func init() {
+	metrics.Registry.MustRegister(cacheHits, cacheMisses)
+}
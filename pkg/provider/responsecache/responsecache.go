@@ -0,0 +1,280 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/clientcache"
+)
+
+const (
+	opGetSecret    = "GetSecret"
+	opGetSecretMap = "GetSecretMap"
+
+	// fetchTimeout limits a fetch shared by concurrent calls,
+	// it doesn't end with the call that started it.
+	fetchTimeout = time.Minute
+
+	errCacheKey = "could not build the response cache key, the responses are not cached"
+)
+
+var log = ctrl.Log.WithName("provider").WithName("responsecache")
+
+// Cache holds the responses of GetSecret and GetSecretMap calls for a TTL,
+// so ExternalSecrets that read the same remote ref through the same store
+// share a single provider call.
+//
+// The responses are cached by the same key as the clients of the clientcache,
+// the version of the store and its auth secrets and the namespace. A change to the
+// store or its credentials therefore doesn't serve responses fetched before.
+// Errors are not cached.
+type Cache struct {
+	kube    client.Reader
+	ttl     time.Duration
+	entries *cache.LRUExpireCache
+	group   singleflight.Group
+}
+
+// New returns a Cache that holds up to size responses for the ttl
+// and reads the auth secrets of the stores with kube.
+// The least recently used responses are dropped when the cache is full.
+func New(kube client.Reader, ttl time.Duration, size int) *Cache {
+	return &Cache{
+		kube:    kube,
+		ttl:     ttl,
+		entries: cache.NewLRUExpireCache(size),
+	}
+}
+
+// NewClient wraps the client of the store used in the namespace,
+// its GetSecret and GetSecretMap calls are served from the cache.
+// The client is returned as it is if the auth secrets of the store can't be read.
+func (c *Cache) NewClient(ctx context.Context, store esv1alpha1.GenericStore, namespace string, secretClient provider.SecretsClient) provider.SecretsClient {
+	prefix, err := clientcache.Key(ctx, c.kube, store, namespace)
+	if err != nil {
+		log.Error(err, errCacheKey)
+		return secretClient
+	}
+	cached := &Client{
+		SecretsClient: secretClient,
+		cache:         c,
+		store:         store,
+		prefix:        prefix,
+	}
+	if reader, ok := secretClient.(provider.SecretsBatchReader); ok {
+		return &BatchClient{Client: cached, reader: reader}
+	}
+	return cached
+}
+
+type refreshKey struct{}
+
+// WithRefresh returns a context whose calls are not served from the cache,
+// the fetched responses replace the cached ones.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+func isRefresh(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+	return refresh
+}
+
+// Client is a provider.SecretsClient that caches the responses of the wrapped client.
+type Client struct {
+	provider.SecretsClient
+	cache  *Cache
+	store  esv1alpha1.GenericStore
+	prefix string
+}
+
+// entry is a cached response with the provider version it was resolved to.
+type entry struct {
+	value     interface{}
+	versionID string
+}
+
+// GetSecret implements provider.SecretsClient.
+func (c *Client) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	val, err := c.get(ctx, opGetSecret, ref, func(ctx context.Context) (interface{}, error) {
+		return c.SecretsClient.GetSecret(ctx, ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyBytes(val.([]byte)), nil
+}
+
+// GetSecretMap implements provider.SecretsClient.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	val, err := c.get(ctx, opGetSecretMap, ref, func(ctx context.Context) (interface{}, error) {
+		return c.SecretsClient.GetSecretMap(ctx, ref)
+	})
+	if err != nil {
+		return nil, err
+	}
+	secretMap := val.(map[string][]byte)
+	if secretMap == nil {
+		return nil, nil
+	}
+	out := make(map[string][]byte, len(secretMap))
+	for k, v := range secretMap {
+		out[k] = copyBytes(v)
+	}
+	return out, nil
+}
+
+// get returns the cached response of the operation on the ref or calls fetch.
+// Concurrent calls with the same key wait for a single fetch.
+// The provider version of the response is recorded in ctx, also if it is served from the cache.
+func (c *Client) get(ctx context.Context, operation string, ref esv1alpha1.ExternalSecretDataRemoteRef, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	key, ok := c.key(operation, ref)
+	if !ok {
+		return fetch(ctx)
+	}
+	if val, ok := c.cache.entries.Get(key); ok && !isRefresh(ctx) {
+		countHit(c.store, operation)
+		e := val.(entry)
+		provider.RecordVersion(ctx, ref.Key, ref.Version, e.versionID)
+		return e.value, nil
+	}
+	countMiss(c.store, operation)
+	ch := c.cache.group.DoChan(key, func() (interface{}, error) {
+		// the fetch is shared with the other callers, it must not be canceled with ctx
+		fetchCtx, cancel := context.WithTimeout(detach(ctx), fetchTimeout)
+		defer cancel()
+		fetchCtx, rec := provider.WithVersionRecorder(fetchCtx)
+		val, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		e := entry{value: val, versionID: rec.Version(ref.Key, ref.Version)}
+		c.cache.entries.Add(key, e, c.cache.ttl)
+		return e, nil
+	})
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	e := res.Val.(entry)
+	provider.RecordVersion(ctx, ref.Key, ref.Version, e.versionID)
+	return e.value, nil
+}
+
+// detachedContext keeps the values of its parent, e.g. the trace span,
+// without its deadline and cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
+
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// key returns the cache key of the operation on the ref.
+func (c *Client) key(operation string, ref esv1alpha1.ExternalSecretDataRemoteRef) (string, bool) {
+	refKey, err := json.Marshal(ref)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%s/%s", c.prefix, operation, refKey), true
+}
+
+// BatchClient is a Client of a client that implements provider.SecretsBatchReader.
+type BatchClient struct {
+	*Client
+	reader provider.SecretsBatchReader
+}
+
+var _ provider.SecretsBatchReader = &BatchClient{}
+
+// GetSecrets implements provider.SecretsBatchReader.
+// The refs are served from the responses cached by GetSecret,
+// the others are read in a single batch and added to the cache.
+func (c *BatchClient) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	results := make([]provider.SecretResult, len(refs))
+	keys := make([]string, len(refs))
+	var missing []int
+	for i, ref := range refs {
+		key, ok := c.key(opGetSecret, ref)
+		if ok && !isRefresh(ctx) {
+			if val, found := c.cache.entries.Get(key); found {
+				countHit(c.store, opGetSecret)
+				e := val.(entry)
+				provider.RecordVersion(ctx, ref.Key, ref.Version, e.versionID)
+				results[i].Value = copyBytes(e.value.([]byte))
+				continue
+			}
+		}
+		countMiss(c.store, opGetSecret)
+		keys[i] = key
+		missing = append(missing, i)
+	}
+	if len(missing) == 0 {
+		return results
+	}
+
+	missingRefs := make([]esv1alpha1.ExternalSecretDataRemoteRef, len(missing))
+	for j, i := range missing {
+		missingRefs[j] = refs[i]
+	}
+	fetchCtx, rec := provider.WithVersionRecorder(ctx)
+	for j, res := range c.reader.GetSecrets(fetchCtx, missingRefs) {
+		i := missing[j]
+		results[i] = res
+		if res.Err != nil {
+			continue
+		}
+		versionID := rec.Version(refs[i].Key, refs[i].Version)
+		provider.RecordVersion(ctx, refs[i].Key, refs[i].Version, versionID)
+		if keys[i] != "" {
+			c.cache.entries.Add(keys[i], entry{value: copyBytes(res.Value), versionID: versionID}, c.cache.ttl)
+		}
+	}
+	return results
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	out := make([]byte, len(b))
+	copy(out, b)
+	return out
+}
@@ -0,0 +1,366 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+)
+
+func makeStore(name string) *esv1alpha1.SecretStore {
+	return &esv1alpha1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			UID:             types.UID("uid-" + name),
+			ResourceVersion: "1",
+		},
+	}
+}
+
+// newCache returns a Cache of stores without auth secrets.
+func newCache(size int) *Cache {
+	return New(clientfake.NewClientBuilder().Build(), time.Hour, size)
+}
+
+func TestGetSecretIsCached(t *testing.T) {
+	var calls int32
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte("value"), nil
+	}
+	c := newCache(10)
+	store := makeStore("cached")
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "shared"}
+
+	for i := 0; i < 3; i++ {
+		val, err := c.NewClient(context.Background(), store, "default", fakeClient).GetSecret(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(val) != "value" {
+			t.Fatalf("unexpected value: %q", val)
+		}
+		// callers may modify the value without changing the cached response
+		val[0] = 'X'
+	}
+	if calls != 1 {
+		t.Errorf("expected one provider call, got %d", calls)
+	}
+	if hits := testutil.ToFloat64(cacheHits.With(storeLabels(store, opGetSecret))); hits != 2 {
+		t.Errorf("expected 2 hits, got %v", hits)
+	}
+	if misses := testutil.ToFloat64(cacheMisses.With(storeLabels(store, opGetSecret))); misses != 1 {
+		t.Errorf("expected 1 miss, got %v", misses)
+	}
+
+	// a different ref, store version or namespace is not served from the cache
+	client := c.NewClient(context.Background(), store, "default", fakeClient)
+	if _, err := client.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "shared", Property: "p"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.NewClient(context.Background(), store, "other", fakeClient).GetSecret(context.Background(), ref); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	store.ResourceVersion = "2"
+	if _, err := c.NewClient(context.Background(), store, "default", fakeClient).GetSecret(context.Background(), ref); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 provider calls, got %d", calls)
+	}
+}
+
+func TestChangedAuthSecretIsNotServedFromCache(t *testing.T) {
+	var calls int32
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte("value"), nil
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-token",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"token": []byte("token"),
+		},
+	}
+	kube := clientfake.NewClientBuilder().WithObjects(secret).Build()
+	c := New(kube, time.Hour, 10)
+	store := makeStore("credentials")
+	store.Spec.Provider = &esv1alpha1.SecretStoreProvider{
+		Vault: &esv1alpha1.VaultProvider{
+			Auth: esv1alpha1.VaultAuth{
+				TokenSecretRef: &esmeta.SecretKeySelector{
+					Name: "vault-token",
+					Key:  "token",
+				},
+			},
+		},
+	}
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "shared"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.NewClient(context.Background(), store, "default", fakeClient).GetSecret(context.Background(), ref); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// the responses fetched with the old credentials are not served anymore
+	secret.Data["token"] = []byte("other-token")
+	if err := kube.Update(context.Background(), secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.NewClient(context.Background(), store, "default", fakeClient).GetSecret(context.Background(), ref); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 provider calls, got %d", calls)
+	}
+}
+
+func TestRefreshReplacesCachedResponse(t *testing.T) {
+	var calls int32
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return []byte("old"), nil
+		}
+		return []byte("new"), nil
+	}
+	client := newCache(10).NewClient(context.Background(), makeStore("refreshed"), "default", fakeClient)
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "rotated"}
+
+	for _, tc := range []struct {
+		ctx  context.Context
+		want string
+	}{
+		{ctx: context.Background(), want: "old"},
+		{ctx: WithRefresh(context.Background()), want: "new"},
+		{ctx: context.Background(), want: "new"},
+	} {
+		val, err := client.GetSecret(tc.ctx, ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(val) != tc.want {
+			t.Errorf("unexpected value: %q, want: %q", val, tc.want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 provider calls, got %d", calls)
+	}
+}
+
+func TestCachedResponseRecordsVersion(t *testing.T) {
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		provider.RecordVersion(ctx, ref.Key, ref.Version, "7")
+		return []byte("value"), nil
+	}
+	c := newCache(10)
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "versioned"}
+
+	// the second call is served from the cache and still reports the version
+	for i := 0; i < 2; i++ {
+		ctx, rec := provider.WithVersionRecorder(context.Background())
+		if _, err := c.NewClient(context.Background(), makeStore("versioned"), "default", fakeClient).GetSecret(ctx, ref); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := rec.Version(ref.Key, ref.Version); got != "7" {
+			t.Errorf("call %d: unexpected version %q", i, got)
+		}
+	}
+}
+
+func TestGetSecretMapCollapsesConcurrentCalls(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	fakeClient := fake.New()
+	fakeClient.GetSecretMapFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return map[string][]byte{"foo": []byte("bar")}, nil
+	}
+	c := newCache(10)
+	client := c.NewClient(context.Background(), makeStore("concurrent"), "default", fakeClient)
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "shared"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			secretMap, err := client.GetSecretMap(context.Background(), ref)
+			if err != nil || string(secretMap["foo"]) != "bar" {
+				t.Errorf("unexpected response: %v, %v", secretMap, err)
+			}
+		}()
+	}
+	// give the goroutines a moment to join the in-flight call
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected one provider call, got %d", calls)
+	}
+}
+
+func TestCanceledCallDoesNotCancelSharedFetch(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(ctx context.Context, _ esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return []byte("value"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c := newCache(10)
+	client := c.NewClient(context.Background(), makeStore("canceled"), "default", fakeClient)
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "shared"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := client.GetSecret(ctx, ref)
+		firstErr <- err
+	}()
+	<-started
+	waiter := make(chan error)
+	go func() {
+		val, err := client.GetSecret(context.Background(), ref)
+		if err == nil && string(val) != "value" {
+			err = fmt.Errorf("unexpected value %q", val)
+		}
+		waiter <- err
+	}()
+	// give the waiter a moment to join the in-flight call
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled call to return context.Canceled, got %v", err)
+	}
+	close(release)
+	if err := <-waiter; err != nil {
+		t.Errorf("unexpected error of the waiting call: %v", err)
+	}
+}
+
+func TestErrorsAreNotCached(t *testing.T) {
+	var calls int32
+	errBoom := errors.New("boom")
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errBoom
+	}
+	c := newCache(10)
+	client := c.NewClient(context.Background(), makeStore("errors"), "default", fakeClient)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "key"}); !errors.Is(err, errBoom) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 provider calls, got %d", calls)
+	}
+}
+
+func TestSizeBound(t *testing.T) {
+	var calls int32
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		return []byte("value"), nil
+	}
+	c := newCache(1)
+	client := c.NewClient(context.Background(), makeStore("size"), "default", fakeClient)
+	for _, key := range []string{"a", "b", "a"} {
+		if _, err := client.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("expected the first response to be dropped, got %d provider calls", calls)
+	}
+}
+
+// batchFake is a fake client that implements provider.SecretsBatchReader.
+type batchFake struct {
+	*fake.Client
+	requested [][]string
+}
+
+func (b *batchFake) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	var keys []string
+	results := make([]provider.SecretResult, len(refs))
+	for i, ref := range refs {
+		keys = append(keys, ref.Key)
+		results[i].Value = []byte("value-" + ref.Key)
+	}
+	b.requested = append(b.requested, keys)
+	return results
+}
+
+func TestBatchClientServesCachedRefs(t *testing.T) {
+	fakeClient := &batchFake{Client: fake.New()}
+	fakeClient.GetSecretFn = func(_ context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		return []byte("value-" + ref.Key), nil
+	}
+	c := newCache(10)
+	store := makeStore("batch")
+	client := c.NewClient(context.Background(), store, "default", fakeClient)
+	if _, err := client.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reader, ok := client.(provider.SecretsBatchReader)
+	if !ok {
+		t.Fatalf("expected the cached client to implement the batch reader")
+	}
+	refs := []esv1alpha1.ExternalSecretDataRemoteRef{{Key: "a"}, {Key: "b"}}
+	for i := 0; i < 2; i++ {
+		results := reader.GetSecrets(context.Background(), refs)
+		if string(results[0].Value) != "value-a" || string(results[1].Value) != "value-b" {
+			t.Errorf("unexpected results: %v", results)
+		}
+	}
+	if len(fakeClient.requested) != 1 || len(fakeClient.requested[0]) != 1 || fakeClient.requested[0][0] != "b" {
+		t.Errorf("expected a single batch of the uncached ref, got %v", fakeClient.requested)
+	}
+}
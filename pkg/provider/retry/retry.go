@@ -0,0 +1,280 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+)
+
+const (
+	// DefaultMaxRetries is used when the store sets a retry interval but no maximum of retries.
+	DefaultMaxRetries = 3
+	// DefaultRetryInterval is used when the store sets a maximum of retries but no retry interval.
+	DefaultRetryInterval = 5 * time.Second
+	// MaxRetryInterval caps the backoff between two attempts.
+	MaxRetryInterval = 10 * time.Second
+	// MaxRetryDuration bounds the time the retries of a call may take, they block the reconcile.
+	// A call that still fails is left to the requeue of the controller.
+	MaxRetryDuration = 15 * time.Second
+
+	// retryJitter adds up to 50% of the backoff so clients of the same store don't retry in lockstep.
+	retryJitter = 0.5
+
+	errInvalidRetryInterval = "invalid retry interval: %w"
+	errNegativeRetries      = "maxRetries must not be negative"
+	errNegativeInterval     = "retryInterval must not be negative"
+)
+
+// Settings are the parsed retry settings of a store.
+type Settings struct {
+	MaxRetries    int
+	RetryInterval time.Duration
+}
+
+// ParseSettings returns the retry settings of the store spec
+// with the defaults applied to the unset fields.
+// Without retry settings MaxRetries is zero, i.e. calls are not retried.
+func ParseSettings(settings *esv1alpha1.SecretStoreRetrySettings) (Settings, error) {
+	if settings == nil {
+		return Settings{}, nil
+	}
+	parsed := Settings{
+		MaxRetries:    DefaultMaxRetries,
+		RetryInterval: DefaultRetryInterval,
+	}
+	if settings.MaxRetries != nil {
+		if *settings.MaxRetries < 0 {
+			return Settings{}, fmt.Errorf(errNegativeRetries)
+		}
+		parsed.MaxRetries = int(*settings.MaxRetries)
+	}
+	if settings.RetryInterval != nil {
+		interval, err := time.ParseDuration(*settings.RetryInterval)
+		if err != nil {
+			return Settings{}, fmt.Errorf(errInvalidRetryInterval, err)
+		}
+		if interval < 0 {
+			return Settings{}, fmt.Errorf(errNegativeInterval)
+		}
+		parsed.RetryInterval = interval
+	}
+	return parsed, nil
+}
+
+// ValidateSettings returns the errors of the retry settings of the store spec.
+func ValidateSettings(settings *esv1alpha1.SecretStoreRetrySettings, fldPath *field.Path) field.ErrorList {
+	if settings == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	if settings.MaxRetries != nil && *settings.MaxRetries < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("maxRetries"), *settings.MaxRetries, errNegativeRetries))
+	}
+	if settings.RetryInterval != nil {
+		interval, err := time.ParseDuration(*settings.RetryInterval)
+		if err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("retryInterval"), *settings.RetryInterval, err.Error()))
+		} else if interval < 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("retryInterval"), *settings.RetryInterval, errNegativeInterval))
+		}
+	}
+	return errs
+}
+
+// Client is a provider.SecretsClient that retries the transient errors
+// of the wrapped client with an exponential backoff.
+type Client struct {
+	client   provider.SecretsClient
+	settings Settings
+	store    esv1alpha1.GenericStore
+	// maxDuration bounds the time the retries of a call may take
+	maxDuration time.Duration
+}
+
+var _ provider.SecretsClient = &Client{}
+
+// NewClient wraps the client with the retry settings of the store.
+// The client is returned as it is if the store doesn't configure retries.
+func NewClient(store esv1alpha1.GenericStore, client provider.SecretsClient) (provider.SecretsClient, error) {
+	settings, err := ParseSettings(store.GetSpec().RetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MaxRetries == 0 {
+		return client, nil
+	}
+	c := &Client{
+		client:      client,
+		settings:    settings,
+		store:       store,
+		maxDuration: MaxRetryDuration,
+	}
+	if reader, ok := client.(provider.SecretsBatchReader); ok {
+		return &BatchClient{Client: c, reader: reader}, nil
+	}
+	return c, nil
+}
+
+// GetSecret implements provider.SecretsClient.
+func (c *Client) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	var secret []byte
+	err := c.do(ctx, "GetSecret", func() error {
+		var err error
+		secret, err = c.client.GetSecret(ctx, ref)
+		return err
+	})
+	return secret, err
+}
+
+// GetSecretMap implements provider.SecretsClient.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	var secretMap map[string][]byte
+	err := c.do(ctx, "GetSecretMap", func() error {
+		var err error
+		secretMap, err = c.client.GetSecretMap(ctx, ref)
+		return err
+	})
+	return secretMap, err
+}
+
+// GetAllSecrets implements provider.SecretsClient.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	var secrets map[string][]byte
+	err := c.do(ctx, "GetAllSecrets", func() error {
+		var err error
+		secrets, err = c.client.GetAllSecrets(ctx, ref)
+		return err
+	})
+	return secrets, err
+}
+
+// Validate implements provider.SecretsClient.
+func (c *Client) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	var res provider.ValidationResult
+	err := c.do(ctx, "Validate", func() error {
+		var err error
+		res, err = c.client.Validate(ctx)
+		return err
+	})
+	return res, err
+}
+
+// Close implements provider.SecretsClient.
+func (c *Client) Close(ctx context.Context) error {
+	return c.client.Close(ctx)
+}
+
+// do calls fn until it returns an error that is not transient or the retries are exhausted.
+// The retries are exhausted as well once the next attempt would start after maxDuration.
+// The last error is returned if the context is done while waiting for the next attempt.
+func (c *Client) do(ctx context.Context, operation string, fn func() error) error {
+	deadline := time.Now().Add(c.maxDuration)
+	err := fn()
+	for attempt := 0; attempt < c.settings.MaxRetries && isTransient(err); attempt++ {
+		delay := c.backoff(attempt)
+		if time.Until(deadline) < delay {
+			break
+		}
+		if !sleep(ctx, delay) {
+			return err
+		}
+		countRetry(c.store, operation)
+		err = fn()
+	}
+	if isTransient(err) {
+		countExhausted(c.store, operation)
+	}
+	return err
+}
+
+// sleep blocks for the delay.
+// It returns false if the context is done before.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// backoff returns the jittered delay before the retry of the given attempt.
+// The retry interval doubles with every attempt up to MaxRetryInterval.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.settings.RetryInterval
+	for i := 0; i < attempt && delay < MaxRetryInterval; i++ {
+		delay *= 2
+	}
+	if delay > MaxRetryInterval {
+		delay = MaxRetryInterval
+	}
+	return wait.Jitter(delay, retryJitter)
+}
+
+func isTransient(err error) bool {
+	return provider.GetErrorCategory(err) == provider.ErrorCategoryTransient
+}
+
+// BatchClient is a Client of a client that implements provider.SecretsBatchReader.
+type BatchClient struct {
+	*Client
+	reader provider.SecretsBatchReader
+}
+
+var _ provider.SecretsBatchReader = &BatchClient{}
+
+// GetSecrets implements provider.SecretsBatchReader.
+// The refs that failed with a transient error are read again in a single batch per retry.
+func (c *BatchClient) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	deadline := time.Now().Add(c.maxDuration)
+	results := c.reader.GetSecrets(ctx, refs)
+	for attempt := 0; ; attempt++ {
+		var failed []int
+		for i, res := range results {
+			if isTransient(res.Err) {
+				failed = append(failed, i)
+			}
+		}
+		if len(failed) == 0 {
+			return results
+		}
+		delay := c.backoff(attempt)
+		if attempt >= c.settings.MaxRetries || time.Until(deadline) < delay {
+			countExhausted(c.store, "GetSecrets")
+			return results
+		}
+		if !sleep(ctx, delay) {
+			return results
+		}
+		countRetry(c.store, "GetSecrets")
+		retryRefs := make([]esv1alpha1.ExternalSecretDataRemoteRef, len(failed))
+		for j, i := range failed {
+			retryRefs[j] = refs[i]
+		}
+		for j, res := range c.reader.GetSecrets(ctx, retryRefs) {
+			results[failed[j]] = res
+		}
+	}
+}
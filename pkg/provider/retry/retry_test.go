@@ -0,0 +1,296 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/utils/pointer"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+)
+
+func makeStore(settings *esv1alpha1.SecretStoreRetrySettings) *esv1alpha1.SecretStore {
+	store := &esv1alpha1.SecretStore{
+		Spec: esv1alpha1.SecretStoreSpec{
+			RetrySettings: settings,
+		},
+	}
+	store.Name = "store"
+	store.Namespace = "default"
+	return store
+}
+
+func TestParseSettings(t *testing.T) {
+	tbl := map[string]struct {
+		settings *esv1alpha1.SecretStoreRetrySettings
+		want     Settings
+		wantErr  bool
+	}{
+		"no settings": {
+			want: Settings{},
+		},
+		"defaults": {
+			settings: &esv1alpha1.SecretStoreRetrySettings{},
+			want:     Settings{MaxRetries: DefaultMaxRetries, RetryInterval: DefaultRetryInterval},
+		},
+		"custom": {
+			settings: &esv1alpha1.SecretStoreRetrySettings{
+				MaxRetries:    pointer.Int32(5),
+				RetryInterval: pointer.String("1s"),
+			},
+			want: Settings{MaxRetries: 5, RetryInterval: time.Second},
+		},
+		"invalid interval": {
+			settings: &esv1alpha1.SecretStoreRetrySettings{RetryInterval: pointer.String("foo")},
+			wantErr:  true,
+		},
+		"negative interval": {
+			settings: &esv1alpha1.SecretStoreRetrySettings{RetryInterval: pointer.String("-1s")},
+			wantErr:  true,
+		},
+		"negative retries": {
+			settings: &esv1alpha1.SecretStoreRetrySettings{MaxRetries: pointer.Int32(-1)},
+			wantErr:  true,
+		},
+	}
+	for name, row := range tbl {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseSettings(row.settings)
+			if (err != nil) != row.wantErr {
+				t.Fatalf("unexpected error: %v, wantErr: %t", err, row.wantErr)
+			}
+			if diff := cmp.Diff(row.want, got); diff != "" {
+				t.Errorf("unexpected settings (-want +got):\n%s", diff)
+			}
+			errs := ValidateSettings(row.settings, nil)
+			if (len(errs) > 0) != row.wantErr {
+				t.Errorf("unexpected validation errors: %v, wantErr: %t", errs, row.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewClientWithoutRetries(t *testing.T) {
+	fakeClient := fake.New()
+	for name, settings := range map[string]*esv1alpha1.SecretStoreRetrySettings{
+		"no settings":  nil,
+		"zero retries": {MaxRetries: pointer.Int32(0)},
+	} {
+		client, err := NewClient(makeStore(settings), fakeClient)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if client != provider.SecretsClient(fakeClient) {
+			t.Errorf("%s: expected the client to be returned as it is", name)
+		}
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	errTransient := provider.NewTransientError(errors.New("unavailable"))
+	errNotFound := provider.NewNotFoundError(errors.New("not found"))
+
+	tbl := map[string]struct {
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		"success": {
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		"success after transient errors": {
+			errs:      []error{errTransient, errTransient, nil},
+			wantCalls: 3,
+		},
+		"no retry of other errors": {
+			errs:      []error{errNotFound},
+			wantCalls: 1,
+			wantErr:   errNotFound,
+		},
+		"retries exhausted": {
+			errs:      []error{errTransient, errTransient, errTransient, errTransient, nil},
+			wantCalls: 4,
+			wantErr:   errTransient,
+		},
+	}
+	for name, row := range tbl {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			fakeClient := fake.New()
+			fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+				err := row.errs[calls]
+				calls++
+				if err != nil {
+					return nil, err
+				}
+				return []byte("value"), nil
+			}
+			store := makeStore(&esv1alpha1.SecretStoreRetrySettings{
+				MaxRetries:    pointer.Int32(3),
+				RetryInterval: pointer.String("1ms"),
+			})
+			store.Name = name
+			client, err := NewClient(store, fakeClient)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, err = client.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "key"})
+			if !errors.Is(err, row.wantErr) {
+				t.Errorf("unexpected error: %v, want: %v", err, row.wantErr)
+			}
+			if calls != row.wantCalls {
+				t.Errorf("unexpected number of calls: %d, want: %d", calls, row.wantCalls)
+			}
+			retried := testutil.ToFloat64(retries.With(storeLabels(store, "GetSecret")))
+			if int(retried) != row.wantCalls-1 {
+				t.Errorf("unexpected number of counted retries: %v, want: %d", retried, row.wantCalls-1)
+			}
+		})
+	}
+}
+
+func TestClientStopsOnDoneContext(t *testing.T) {
+	calls := 0
+	errTransient := provider.NewTransientError(errors.New("unavailable"))
+	fakeClient := fake.New()
+	fakeClient.GetSecretMapFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+		calls++
+		return nil, errTransient
+	}
+	client, err := NewClient(makeStore(&esv1alpha1.SecretStoreRetrySettings{
+		MaxRetries:    pointer.Int32(3),
+		RetryInterval: pointer.String("1h"),
+	}), fakeClient)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.GetSecretMap(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: "key"})
+	if !errors.Is(err, errTransient) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("unexpected number of calls: %d, want: 1", calls)
+	}
+}
+
+func TestClientStopsAtMaxRetryDuration(t *testing.T) {
+	calls := 0
+	errTransient := provider.NewTransientError(errors.New("unavailable"))
+	fakeClient := fake.New()
+	fakeClient.GetSecretFn = func(context.Context, esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		calls++
+		return nil, errTransient
+	}
+	store := makeStore(&esv1alpha1.SecretStoreRetrySettings{
+		MaxRetries:    pointer.Int32(3),
+		RetryInterval: pointer.String("10ms"),
+	})
+	store.Name = "max-retry-duration"
+	client, err := NewClient(store, fakeClient)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the first retry would wait at least 10ms
+	client.(*Client).maxDuration = 5 * time.Millisecond
+	_, err = client.GetSecret(context.Background(), esv1alpha1.ExternalSecretDataRemoteRef{Key: "key"})
+	if !errors.Is(err, errTransient) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("unexpected number of calls: %d, want: 1", calls)
+	}
+	if exhausted := testutil.ToFloat64(retriesExhausted.With(storeLabels(store, "GetSecret"))); exhausted != 1 {
+		t.Errorf("expected the retries to be counted as exhausted, got %v", exhausted)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := &Client{settings: Settings{MaxRetries: 10, RetryInterval: time.Second}}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		got := c.backoff(attempt)
+		if got < want || got > want+want/2 {
+			t.Errorf("attempt %d: backoff %s not within [%s, %s]", attempt, got, want, want+want/2)
+		}
+	}
+	if got := c.backoff(20); got > MaxRetryInterval+MaxRetryInterval/2 {
+		t.Errorf("backoff %s exceeds the maximum", got)
+	}
+}
+
+// batchFake is a fake client that implements provider.SecretsBatchReader.
+type batchFake struct {
+	*fake.Client
+	getSecrets func([]esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult
+}
+
+func (b *batchFake) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	return b.getSecrets(refs)
+}
+
+func TestBatchClientRetriesFailedRefs(t *testing.T) {
+	errTransient := provider.NewTransientError(errors.New("unavailable"))
+	var batches [][]string
+	failures := map[string]int{"flaky": 1, "down": 10}
+	client, err := NewClient(makeStore(&esv1alpha1.SecretStoreRetrySettings{
+		MaxRetries:    pointer.Int32(2),
+		RetryInterval: pointer.String("1ms"),
+	}), &batchFake{
+		Client: fake.New(),
+		getSecrets: func(refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+			var keys []string
+			results := make([]provider.SecretResult, len(refs))
+			for i, ref := range refs {
+				keys = append(keys, ref.Key)
+				if failures[ref.Key] > 0 {
+					failures[ref.Key]--
+					results[i].Err = errTransient
+					continue
+				}
+				results[i].Value = []byte(ref.Key)
+			}
+			batches = append(batches, keys)
+			return results
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reader, ok := client.(provider.SecretsBatchReader)
+	if !ok {
+		t.Fatalf("expected the retry client to implement the batch reader")
+	}
+	results := reader.GetSecrets(context.Background(), []esv1alpha1.ExternalSecretDataRemoteRef{{Key: "ok"}, {Key: "flaky"}, {Key: "down"}})
+
+	if diff := cmp.Diff([][]string{{"ok", "flaky", "down"}, {"flaky", "down"}, {"down"}}, batches); diff != "" {
+		t.Errorf("unexpected batches (-want +got):\n%s", diff)
+	}
+	if string(results[0].Value) != "ok" || string(results[1].Value) != "flaky" {
+		t.Errorf("unexpected results: %v", results)
+	}
+	if !errors.Is(results[2].Err, errTransient) {
+		t.Errorf("expected the transient error after the retries, got %v", results[2].Err)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	"github.com/external-secrets/external-secrets/pkg/provider"
 )
@@ -88,6 +90,25 @@ func GetProvider(s esv1alpha1.GenericStore) (provider.Provider, error) {
 	return f, nil
 }
 
+// GetProviderName returns the name of the provider configured in the store, e.g. aws or vault.
+func GetProviderName(s esv1alpha1.GenericStore) (string, error) {
+	return getProviderName(s.GetSpec().Provider)
+}
+
+// ValidateStore runs the static validation of the store's provider,
+// if the provider implements provider.StoreValidator.
+func ValidateStore(s esv1alpha1.GenericStore) field.ErrorList {
+	p, err := GetProvider(s)
+	if err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "provider"), s.GetSpec().Provider, err.Error())}
+	}
+	validator, ok := p.(provider.StoreValidator)
+	if !ok {
+		return nil
+	}
+	return validator.ValidateStore(s)
+}
+
 // getProviderName returns the name of the configured provider
 // or an error if the provider is not configured.
 func getProviderName(storeSpec *esv1alpha1.SecretStoreProvider) (string, error) {
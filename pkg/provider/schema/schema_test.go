@@ -43,10 +43,19 @@ func (p *PP) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretData
 	return map[string][]byte{}, nil
 }
 
+// GetAllSecrets returns every secret that matches the find criteria.
+func (p *PP) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return map[string][]byte{}, nil
+}
+
 func (p *PP) Close(ctx context.Context) error {
 	return nil
 }
 
+func (p *PP) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	return provider.ValidationResultReady, nil
+}
+
 // TestRegister tests if the Register function
 // (1) panics if it tries to register something invalid
 // (2) stores the correct provider.
@@ -0,0 +1,177 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing traces the calls of the provider clients.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	"github.com/external-secrets/external-secrets/pkg/tracing"
+)
+
+const (
+	// SpanNewClient is the name of the span of the construction of a provider client,
+	// which authenticates with the provider.
+	SpanNewClient = "provider.NewClient"
+
+	spanPrefix = "provider."
+)
+
+// NewProvider wraps the provider, so the construction of its clients and
+// the calls of the clients are traced.
+func NewProvider(prov provider.Provider) provider.Provider {
+	return &tracedProvider{Provider: prov}
+}
+
+type tracedProvider struct {
+	provider.Provider
+}
+
+// NewClient implements provider.Provider.
+func (p *tracedProvider) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube client.Client, namespace string) (provider.SecretsClient, error) {
+	attrs := storeAttributes(store)
+	ctx, span := tracing.Start(ctx, SpanNewClient, attrs...)
+	secretClient, err := p.Provider.NewClient(ctx, store, kube, namespace)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(attrs, secretClient), nil
+}
+
+// Client is a provider.SecretsClient that traces the calls of the wrapped client.
+type Client struct {
+	client provider.SecretsClient
+	attrs  []attribute.KeyValue
+}
+
+var _ provider.SecretsClient = &Client{}
+
+// NewClient wraps the client of the store.
+func NewClient(store esv1alpha1.GenericStore, client provider.SecretsClient) provider.SecretsClient {
+	return newClient(storeAttributes(store), client)
+}
+
+func newClient(attrs []attribute.KeyValue, client provider.SecretsClient) provider.SecretsClient {
+	c := &Client{client: client, attrs: attrs}
+	if reader, ok := client.(provider.SecretsBatchReader); ok {
+		return &BatchClient{Client: c, reader: reader}
+	}
+	return c
+}
+
+// GetSecret implements provider.SecretsClient.
+func (c *Client) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	ctx, span := c.start(ctx, "GetSecret", refAttributes(ref)...)
+	secret, err := c.client.GetSecret(ctx, ref)
+	tracing.End(span, err)
+	return secret, err
+}
+
+// GetSecretMap implements provider.SecretsClient.
+func (c *Client) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	ctx, span := c.start(ctx, "GetSecretMap", refAttributes(ref)...)
+	secretMap, err := c.client.GetSecretMap(ctx, ref)
+	tracing.End(span, err)
+	return secretMap, err
+}
+
+// GetAllSecrets implements provider.SecretsClient.
+func (c *Client) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	var attrs []attribute.KeyValue
+	if ref.Path != nil {
+		attrs = append(attrs, attribute.String("find.path", *ref.Path))
+	}
+	ctx, span := c.start(ctx, "GetAllSecrets", attrs...)
+	secrets, err := c.client.GetAllSecrets(ctx, ref)
+	span.SetAttributes(attribute.Int("find.count", len(secrets)))
+	tracing.End(span, err)
+	return secrets, err
+}
+
+// Validate implements provider.SecretsClient.
+func (c *Client) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	ctx, span := c.start(ctx, "Validate")
+	res, err := c.client.Validate(ctx)
+	tracing.End(span, err)
+	return res, err
+}
+
+// Close implements provider.SecretsClient.
+func (c *Client) Close(ctx context.Context) error {
+	return c.client.Close(ctx)
+}
+
+// BatchClient is a Client of a client that implements provider.SecretsBatchReader.
+type BatchClient struct {
+	*Client
+	reader provider.SecretsBatchReader
+}
+
+var _ provider.SecretsBatchReader = &BatchClient{}
+
+// GetSecrets implements provider.SecretsBatchReader.
+// The batch is traced as a single span that failed if any of the refs failed.
+func (c *BatchClient) GetSecrets(ctx context.Context, refs []esv1alpha1.ExternalSecretDataRemoteRef) []provider.SecretResult {
+	ctx, span := c.start(ctx, "GetSecrets", attribute.Int("batch.size", len(refs)))
+	results := c.reader.GetSecrets(ctx, refs)
+	var err error
+	for _, res := range results {
+		if res.Err != nil {
+			err = res.Err
+			break
+		}
+	}
+	tracing.End(span, err)
+	return results
+}
+
+func (c *Client) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Start(ctx, spanPrefix+operation, append(attrs, c.attrs...)...)
+}
+
+// storeAttributes describes the store, never its credentials.
+func storeAttributes(store esv1alpha1.GenericStore) []attribute.KeyValue {
+	providerName, _ := schema.GetProviderName(store)
+	kind := esv1alpha1.SecretStoreKind
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok {
+		kind = esv1alpha1.ClusterSecretStoreKind
+	}
+	return []attribute.KeyValue{
+		attribute.String("provider", providerName),
+		attribute.String("store.name", store.GetName()),
+		attribute.String("store.namespace", store.GetNamespace()),
+		attribute.String("store.kind", kind),
+	}
+}
+
+// refAttributes describes the remote ref, never the secret value.
+func refAttributes(ref esv1alpha1.ExternalSecretDataRemoteRef) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("remote.key", ref.Key)}
+	if ref.Property != "" {
+		attrs = append(attrs, attribute.String("remote.property", ref.Property))
+	}
+	if ref.Version != "" {
+		attrs = append(attrs, attribute.String("remote.version", ref.Version))
+	}
+	return attrs
+}
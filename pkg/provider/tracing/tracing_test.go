@@ -0,0 +1,95 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/provider/fake"
+	"github.com/external-secrets/external-secrets/pkg/tracing"
+)
+
+func TestProviderTracesCalls(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	fakeProvider := fake.New()
+	fakeProvider.WithGetSecret([]byte("value"), nil)
+	fakeProvider.WithGetSecretMap(nil, errors.New("boom"))
+	store := &esv1alpha1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "traced", Namespace: "default"},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{AWS: &esv1alpha1.AWSProvider{}},
+		},
+	}
+
+	ctx, reconcile := tracing.Start(context.Background(), "reconcile")
+	secretClient, err := NewProvider(fakeProvider).NewClient(ctx, store, nil, "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := secretClient.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: "foo", Property: "bar"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := secretClient.GetSecretMap(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: "foo"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+	tracing.End(reconcile, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 4 {
+		t.Fatalf("expected 4 spans, got %d", len(spans))
+	}
+	for i, tc := range []struct {
+		name   string
+		status codes.Code
+		attr   attribute.KeyValue
+	}{
+		{name: SpanNewClient, status: codes.Unset, attr: attribute.String("store.name", "traced")},
+		{name: "provider.GetSecret", status: codes.Unset, attr: attribute.String("remote.property", "bar")},
+		{name: "provider.GetSecretMap", status: codes.Error, attr: attribute.String("provider", "aws")},
+	} {
+		span := spans[i]
+		if span.Name != tc.name || span.Status.Code != tc.status {
+			t.Errorf("unexpected span %d: %s %v", i, span.Name, span.Status)
+		}
+		if span.Parent.SpanID() != reconcile.SpanContext().SpanID() {
+			t.Errorf("expected span %s to be a child of the reconcile", span.Name)
+		}
+		if !hasAttribute(span.Attributes, tc.attr) {
+			t.Errorf("expected span %s to have attribute %v, got %v", span.Name, tc.attr, span.Attributes)
+		}
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -18,17 +18,20 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/go-logr/logr"
 	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -36,11 +39,13 @@ import (
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
 	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 var (
 	_ provider.Provider      = &connector{}
 	_ provider.SecretsClient = &client{}
+	_ provider.SecretsWriter = &client{}
 )
 
 const (
@@ -71,6 +76,12 @@ const (
 	errClientTLSAuth = "error from Client TLS Auth: %q"
 
 	errVaultRevokeToken = "error while revoking token: %w"
+	errVaultValidate    = "cannot lookup Vault token: %w"
+	errWriteSecret      = "cannot write secret data to Vault: %w"
+	errPushFormat       = "cannot push secret without property: value is not a JSON object: %w"
+	errListSecrets      = "cannot list secrets in Vault: %w"
+	errReadMetadata     = "cannot read secret metadata from Vault: %w"
+	errFindTagsV1       = "finding secrets by tags requires the KV secrets engine version 2"
 
 	errUnknownCAProvider = "unknown caProvider type given"
 	errCANamespace       = "cannot read secret for CAProvider due to missing namespace on kind ClusterSecretStore"
@@ -110,6 +121,10 @@ type connector struct {
 	newVaultClient func(c *vault.Config) (Client, error)
 }
 
+// ConcurrentGetSecret marks the clients as safe for concurrent use,
+// the Vault API client can send requests from several goroutines.
+func (c *connector) ConcurrentGetSecret() {}
+
 func (c *connector) NewClient(ctx context.Context, store esv1alpha1.GenericStore, kube kclient.Client, namespace string) (provider.SecretsClient, error) {
 	storeSpec := store.GetSpec()
 	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Vault == nil {
@@ -147,6 +162,81 @@ func (c *connector) NewClient(ctx context.Context, store esv1alpha1.GenericStore
 	return vStore, nil
 }
 
+// ValidateStore checks the Vault specific part of the store spec.
+func (c *connector) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "vault")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Vault == nil {
+		return field.ErrorList{field.Required(fldPath, errVaultStore)}
+	}
+	vStore := storeSpec.Provider.Vault
+	allErrs := field.ErrorList{}
+	if vStore.Server == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("server"), ""))
+	}
+	if vStore.Path == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), ""))
+	}
+	if vStore.Version != esv1alpha1.VaultKVStoreV1 && vStore.Version != esv1alpha1.VaultKVStoreV2 {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("version"), vStore.Version,
+			[]string{string(esv1alpha1.VaultKVStoreV1), string(esv1alpha1.VaultKVStoreV2)}))
+	}
+	if vStore.CAProvider != nil {
+		allErrs = append(allErrs, utils.ValidateReferentNamespace(store, vStore.CAProvider.Namespace, fldPath.Child("caProvider", "namespace"))...)
+	}
+	return append(allErrs, validateAuth(store, vStore.Auth, fldPath.Child("auth"))...)
+}
+
+// validateAuth checks the auth of the store. A ClusterSecretStore may omit the namespace
+// of the referenced objects, they are read from the namespace of the ExternalSecret.
+func validateAuth(store esv1alpha1.GenericStore, auth esv1alpha1.VaultAuth, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if auth.TokenSecretRef == nil && auth.AppRole == nil && auth.Kubernetes == nil &&
+		auth.Ldap == nil && auth.Jwt == nil && auth.Cert == nil {
+		return append(allErrs, field.Required(fldPath, errAuthFormat))
+	}
+	if auth.TokenSecretRef != nil {
+		allErrs = append(allErrs, utils.ValidateSecretSelectorWithFallback(store, *auth.TokenSecretRef, fldPath.Child("tokenSecretRef"))...)
+	}
+	if auth.AppRole != nil {
+		if auth.AppRole.RoleID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("appRole", "roleId"), ""))
+		}
+		allErrs = append(allErrs, utils.ValidateSecretSelectorWithFallback(store, auth.AppRole.SecretRef, fldPath.Child("appRole", "secretRef"))...)
+	}
+	if auth.Kubernetes != nil {
+		k8sPath := fldPath.Child("kubernetes")
+		if auth.Kubernetes.Role == "" {
+			allErrs = append(allErrs, field.Required(k8sPath.Child("role"), ""))
+		}
+		if auth.Kubernetes.ServiceAccountRef != nil {
+			allErrs = append(allErrs, utils.ValidateServiceAccountSelectorWithFallback(store, *auth.Kubernetes.ServiceAccountRef, k8sPath.Child("serviceAccountRef"))...)
+		}
+		// the key of the secretRef defaults to "token"
+		if auth.Kubernetes.SecretRef != nil {
+			refPath := k8sPath.Child("secretRef")
+			if auth.Kubernetes.SecretRef.Name == "" {
+				allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+			}
+			allErrs = append(allErrs, utils.ValidateReferentNamespaceWithFallback(store, auth.Kubernetes.SecretRef.Namespace, refPath.Child("namespace"))...)
+		}
+	}
+	if auth.Ldap != nil {
+		if auth.Ldap.Username == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("ldap", "username"), ""))
+		}
+		allErrs = append(allErrs, utils.ValidateSecretSelectorWithFallback(store, auth.Ldap.SecretRef, fldPath.Child("ldap", "secretRef"))...)
+	}
+	if auth.Jwt != nil {
+		allErrs = append(allErrs, utils.ValidateSecretSelectorWithFallback(store, auth.Jwt.SecretRef, fldPath.Child("jwt", "secretRef"))...)
+	}
+	if auth.Cert != nil {
+		allErrs = append(allErrs, utils.ValidateSecretSelectorWithFallback(store, auth.Cert.ClientCert, fldPath.Child("cert", "clientCert"))...)
+		allErrs = append(allErrs, utils.ValidateSecretSelectorWithFallback(store, auth.Cert.SecretRef, fldPath.Child("cert", "secretRef"))...)
+	}
+	return allErrs
+}
+
 func (v *client) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	data, err := v.readSecret(ctx, ref.Key, ref.Version)
 	if err != nil {
@@ -154,7 +244,7 @@ func (v *client) GetSecret(ctx context.Context, ref esv1alpha1.ExternalSecretDat
 	}
 	value, exists := data[ref.Property]
 	if !exists {
-		return nil, fmt.Errorf(errSecretKeyFmt, ref.Property)
+		return nil, provider.NewInvalidRefError(fmt.Errorf(errSecretKeyFmt, ref.Property))
 	}
 	return value, nil
 }
@@ -163,6 +253,69 @@ func (v *client) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecret
 	return v.readSecret(ctx, ref.Key, ref.Version)
 }
 
+// GetAllSecrets walks the KV engine below the path and returns every secret
+// that matches the find criteria as JSON object. Tags are matched against the
+// custom metadata of the secrets, which is only available in KV version 2.
+func (v *client) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	if len(ref.Tags) > 0 && v.store.Version != esv1alpha1.VaultKVStoreV2 {
+		return nil, errors.New(errFindTagsV1)
+	}
+	matcher, err := utils.NewFindMatcher(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// the path is a name prefix, listing starts at the last directory it contains
+	dir := ""
+	if ref.Path != nil {
+		dir = (*ref.Path)[:strings.LastIndex(*ref.Path, "/")+1]
+	}
+	names, err := v.listSecrets(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string][]byte)
+	for _, name := range names {
+		if !matcher.MatchName(name) {
+			continue
+		}
+		if len(ref.Tags) > 0 {
+			tags, err := v.readCustomMetadata(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			if !matcher.MatchTags(tags) {
+				continue
+			}
+		}
+		secretData, err := v.readSecretData(ctx, name, "")
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(secretData)
+		if err != nil {
+			return nil, err
+		}
+		secrets[name] = data
+	}
+	return secrets, nil
+}
+
+// Validate looks up the current token to ensure that
+// the Vault server is reachable and accepts our credentials.
+func (v *client) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	req := v.client.NewRequest(http.MethodGet, "/v1/auth/token/lookup-self")
+	resp, err := v.client.RawRequestWithContext(ctx, req)
+	if err != nil {
+		return provider.ValidationResultError, fmt.Errorf(errVaultValidate, err)
+	}
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return provider.ValidationResultReady, nil
+}
+
 func (v *client) Close(ctx context.Context) error {
 	// Revoke the token if we have one set and it wasn't sourced from a TokenSecretRef
 	if v.client.Token() != "" && v.store.Auth.TokenSecretRef == nil {
@@ -176,7 +329,63 @@ func (v *client) Close(ctx context.Context) error {
 	return nil
 }
 
-func (v *client) readSecret(ctx context.Context, path, version string) (map[string][]byte, error) {
+// PushSecret writes the value to the KV engine.
+// If a property is given it is merged into the existing secret data,
+// otherwise the value must be a JSON object which replaces the secret data.
+// Nothing is written if the secret data doesn't change, every write of a KV v2
+// secret creates a new version.
+func (v *client) PushSecret(ctx context.Context, value []byte, remoteRef esv1alpha1.PushSecretRemoteRef) error {
+	secretData := make(map[string]interface{})
+	if remoteRef.Property == "" {
+		if err := json.Unmarshal(value, &secretData); err != nil {
+			return fmt.Errorf(errPushFormat, err)
+		}
+	}
+
+	// a secret that does not exist yet is created
+	existing, err := v.readSecretData(ctx, remoteRef.RemoteKey, "")
+	var respErr *vault.ResponseError
+	notFound := errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound
+	if err != nil && !notFound {
+		return err
+	}
+	if remoteRef.Property != "" {
+		for k, val := range existing {
+			secretData[k] = val
+		}
+		secretData[remoteRef.Property] = string(value)
+	}
+	if !notFound && equalSecretData(existing, secretData) {
+		return nil
+	}
+	return v.writeSecret(ctx, remoteRef.RemoteKey, secretData)
+}
+
+// equalSecretData compares the secret data by its JSON representation,
+// numbers read from Vault are json.Numbers while the pushed ones are float64s.
+func equalSecretData(a, b map[string]interface{}) bool {
+	normalize := func(data map[string]interface{}) (interface{}, error) {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		var out interface{}
+		err = json.Unmarshal(raw, &out)
+		return out, err
+	}
+	na, err := normalize(a)
+	if err != nil {
+		return false
+	}
+	nb, err := normalize(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+// kvPath returns the path of the KV engine for the given API operation.
+func (v *client) kvPath() string {
 	kvPath := v.store.Path
 
 	if v.store.Version == esv1alpha1.VaultKVStoreV2 {
@@ -184,16 +393,126 @@ func (v *client) readSecret(ctx context.Context, path, version string) (map[stri
 			kvPath = fmt.Sprintf("%s/data", kvPath)
 		}
 	}
+	return kvPath
+}
+
+// metadataPath returns the path secrets are listed at and their metadata is read from.
+func (v *client) metadataPath() string {
+	mountPath := strings.TrimSuffix(v.store.Path, "/data")
+	if v.store.Version == esv1alpha1.VaultKVStoreV2 {
+		return fmt.Sprintf("%s/metadata", mountPath)
+	}
+	return mountPath
+}
+
+// listSecrets returns the names of all secrets below the directory.
+func (v *client) listSecrets(ctx context.Context, dir string) ([]string, error) {
+	// path formated according to vault docs for v1 and v2 API
+	// v1: https://www.vaultproject.io/api-docs/secret/kv/kv-v1#list-secrets
+	// v2: https://www.vaultproject.io/api-docs/secret/kv/kv-v2#list-secrets
+	req := v.client.NewRequest("LIST", fmt.Sprintf("/v1/%s/%s", v.metadataPath(), dir))
+	resp, err := v.client.RawRequestWithContext(ctx, req)
+	var respErr *vault.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+		// nothing is stored below the directory
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errListSecrets, err)
+	}
+
+	vaultSecret, err := vault.ParseSecret(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	keys, ok := vaultSecret.Data["keys"].([]interface{})
+	if !ok {
+		return nil, errors.New(errSecretFormat)
+	}
+
+	var names []string
+	for _, k := range keys {
+		key, ok := k.(string)
+		if !ok {
+			return nil, errors.New(errSecretFormat)
+		}
+		if strings.HasSuffix(key, "/") {
+			subNames, err := v.listSecrets(ctx, dir+key)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, subNames...)
+			continue
+		}
+		names = append(names, dir+key)
+	}
+	return names, nil
+}
+
+// readCustomMetadata returns the custom metadata of a KV version 2 secret.
+func (v *client) readCustomMetadata(ctx context.Context, path string) (map[string]string, error) {
+	// https://www.vaultproject.io/api-docs/secret/kv/kv-v2#read-secret-metadata
+	req := v.client.NewRequest(http.MethodGet, fmt.Sprintf("/v1/%s/%s", v.metadataPath(), path))
+	resp, err := v.client.RawRequestWithContext(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf(errReadMetadata, err)
+	}
+
+	vaultSecret, err := vault.ParseSecret(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	customMetadata, _ := vaultSecret.Data["custom_metadata"].(map[string]interface{})
+	tags := make(map[string]string, len(customMetadata))
+	for k, val := range customMetadata {
+		if str, ok := val.(string); ok {
+			tags[k] = str
+		}
+	}
+	return tags, nil
+}
 
+func (v *client) writeSecret(ctx context.Context, path string, secretData map[string]interface{}) error {
+	// path formated according to vault docs for v1 and v2 API
+	// v1: https://www.vaultproject.io/api-docs/secret/kv/kv-v1#create-update-secret
+	// v2: https://www.vaultproject.io/api/secret/kv/kv-v2#create-update-secret
+	req := v.client.NewRequest(http.MethodPost, fmt.Sprintf("/v1/%s/%s", v.kvPath(), path))
+
+	var body interface{} = secretData
+	if v.store.Version == esv1alpha1.VaultKVStoreV2 {
+		body = map[string]interface{}{
+			"data": secretData,
+		}
+	}
+	if err := req.SetJSONBody(body); err != nil {
+		return fmt.Errorf(errVaultReqParams, err)
+	}
+
+	resp, err := v.client.RawRequestWithContext(ctx, req)
+	if err != nil {
+		return fmt.Errorf(errWriteSecret, err)
+	}
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// readSecretData returns the raw secret data stored at the given path.
+func (v *client) readSecretData(ctx context.Context, path, version string) (map[string]interface{}, error) {
 	// path formated according to vault docs for v1 and v2 API
 	// v1: https://www.vaultproject.io/api-docs/secret/kv/kv-v1#read-secret
 	// v2: https://www.vaultproject.io/api/secret/kv/kv-v2#read-secret-version
-	req := v.client.NewRequest(http.MethodGet, fmt.Sprintf("/v1/%s/%s", kvPath, path))
+	req := v.client.NewRequest(http.MethodGet, fmt.Sprintf("/v1/%s/%s", v.kvPath(), path))
 	if version != "" {
 		req.Params.Set("version", version)
 	}
 
 	resp, err := v.client.RawRequestWithContext(ctx, req)
+	var respErr *vault.ResponseError
+	if errors.As(err, &respErr) {
+		return nil, provider.FromHTTPStatus(respErr.StatusCode, fmt.Errorf(errReadSecret, err))
+	}
 	if err != nil {
 		return nil, fmt.Errorf(errReadSecret, err)
 	}
@@ -216,6 +535,17 @@ func (v *client) readSecret(ctx context.Context, path, version string) (map[stri
 		if !ok {
 			return nil, errors.New(errJSONUnmarshall)
 		}
+		if metadata, ok := vaultSecret.Data["metadata"].(map[string]interface{}); ok && metadata["version"] != nil {
+			provider.RecordVersion(ctx, path, version, fmt.Sprint(metadata["version"]))
+		}
+	}
+	return secretData, nil
+}
+
+func (v *client) readSecret(ctx context.Context, path, version string) (map[string][]byte, error) {
+	secretData, err := v.readSecretData(ctx, path, version)
+	if err != nil {
+		return nil, err
 	}
 
 	byteMap := make(map[string][]byte, len(secretData))
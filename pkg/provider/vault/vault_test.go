@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	vault "github.com/hashicorp/vault/api"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,6 +35,7 @@ import (
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/vault/fake"
 )
 
@@ -657,6 +660,32 @@ func TestGetSecretMap(t *testing.T) {
 				err: fmt.Errorf(errReadSecret, errBoom),
 			},
 		},
+		"ReadSecretNotFound": {
+			reason: "Should return a not found error if the secret does not exist.",
+			args: args{
+				store: makeSecretStore().Spec.Provider.Vault,
+				vClient: &fake.VaultClient{
+					MockNewRequest:            fake.NewMockNewRequestFn(&vault.Request{}),
+					MockRawRequestWithContext: fake.NewMockRawRequestWithContextFn(nil, &vault.ResponseError{StatusCode: http.StatusNotFound}),
+				},
+			},
+			want: want{
+				err: provider.NewNotFoundError(fmt.Errorf(errReadSecret, &vault.ResponseError{StatusCode: http.StatusNotFound})),
+			},
+		},
+		"ReadSecretPermissionDenied": {
+			reason: "Should return a permission denied error if the token may not read the secret.",
+			args: args{
+				store: makeSecretStore().Spec.Provider.Vault,
+				vClient: &fake.VaultClient{
+					MockNewRequest:            fake.NewMockNewRequestFn(&vault.Request{}),
+					MockRawRequestWithContext: fake.NewMockRawRequestWithContextFn(nil, &vault.ResponseError{StatusCode: http.StatusForbidden}),
+				},
+			},
+			want: want{
+				err: provider.NewPermissionDeniedError(fmt.Errorf(errReadSecret, &vault.ResponseError{StatusCode: http.StatusForbidden})),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -674,3 +703,502 @@ func TestGetSecretMap(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		vClient Client
+	}
+
+	type want struct {
+		result provider.ValidationResult
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ValidToken": {
+			reason: "Should return ready if the token can be looked up.",
+			args: args{
+				vClient: &fake.VaultClient{
+					MockNewRequest: fake.NewMockNewRequestFn(&vault.Request{}),
+					MockRawRequestWithContext: fake.NewMockRawRequestWithContextFn(
+						newVaultTokenIDResponse("test-token"), nil,
+					),
+				},
+			},
+			want: want{
+				result: provider.ValidationResultReady,
+			},
+		},
+		"LookupError": {
+			reason: "Should return an error if the token lookup fails.",
+			args: args{
+				vClient: &fake.VaultClient{
+					MockNewRequest:            fake.NewMockNewRequestFn(&vault.Request{}),
+					MockRawRequestWithContext: fake.NewMockRawRequestWithContextFn(nil, errBoom),
+				},
+			},
+			want: want{
+				result: provider.ValidationResultError,
+				err:    fmt.Errorf(errVaultValidate, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			vStore := &client{
+				client: tc.args.vClient,
+				store:  makeValidSecretStore().Spec.Provider.Vault,
+			}
+			res, err := vStore.Validate(context.Background())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nvault.Validate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if res != tc.want.result {
+				t.Errorf("\n%s\nvault.Validate(...): want result %v, got %v", tc.reason, tc.want.result, res)
+			}
+		})
+	}
+}
+
+func TestValidateStore(t *testing.T) {
+	namespace := "default"
+
+	cases := map[string]struct {
+		reason string
+		store  esv1alpha1.GenericStore
+		want   []string
+	}{
+		"ValidStore": {
+			reason: "Should not return errors for a valid store.",
+			store:  makeValidSecretStore(),
+		},
+		"ValidCertStore": {
+			reason: "Should not return errors for a valid store using cert auth.",
+			store:  makeValidSecretStoreWithCerts(),
+		},
+		"MissingServer": {
+			reason: "Should require the server and path fields.",
+			store: makeSecretStore(func(s *esv1alpha1.SecretStore) {
+				s.Spec.Provider.Vault.Server = ""
+				s.Spec.Provider.Vault.Path = ""
+			}),
+			want: []string{"spec.provider.vault.server", "spec.provider.vault.path"},
+		},
+		"MissingAuth": {
+			reason: "Should require an auth method.",
+			store: makeSecretStore(func(s *esv1alpha1.SecretStore) {
+				s.Spec.Provider.Vault.Auth = esv1alpha1.VaultAuth{}
+			}),
+			want: []string{"spec.provider.vault.auth"},
+		},
+		"NamespaceInSecretStore": {
+			reason: "Should not allow a namespace in a SecretStore reference.",
+			store: makeSecretStore(func(s *esv1alpha1.SecretStore) {
+				s.Spec.Provider.Vault.Auth.Kubernetes.ServiceAccountRef.Namespace = &namespace
+			}),
+			want: []string{"spec.provider.vault.auth.kubernetes.serviceAccountRef.namespace"},
+		},
+		"MissingNamespaceInClusterSecretStore": {
+			reason: "Should require the namespace of the CA provider, but not of the auth references in a ClusterSecretStore.",
+			store:  makeInvalidClusterSecretStoreWithK8sCerts(),
+			want:   []string{"spec.provider.vault.caProvider.namespace"},
+		},
+		"ClusterSecretStoreTokenWithoutNamespace": {
+			reason: "Should allow a token reference without namespace in a ClusterSecretStore, it is read from the namespace of the ExternalSecret.",
+			store: &esv1alpha1.ClusterSecretStore{
+				Spec: esv1alpha1.SecretStoreSpec{
+					Provider: &esv1alpha1.SecretStoreProvider{
+						Vault: &esv1alpha1.VaultProvider{
+							Server:  "vault.example.com",
+							Path:    "secret",
+							Version: esv1alpha1.VaultKVStoreV2,
+							Auth: esv1alpha1.VaultAuth{
+								TokenSecretRef: &esmeta.SecretKeySelector{Name: "vault-token", Key: "token"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn := &connector{}
+			errs := conn.ValidateStore(tc.store)
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Field)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nvault.ValidateStore(...): -want fields, +got fields:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPushSecret(t *testing.T) {
+	errBoom := errors.New("boom")
+	existing := map[string]interface{}{
+		"access_key": "access_key",
+	}
+
+	type args struct {
+		version  esv1alpha1.VaultKVStoreVersion
+		read     *vault.Response
+		readErr  error
+		writeErr error
+		value    []byte
+		ref      esv1alpha1.PushSecretRemoteRef
+	}
+
+	type want struct {
+		body interface{}
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"MergePropertyKV1": {
+			reason: "Should merge the property into the existing secret data.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV1,
+				read:    newVaultResponseWithData(existing),
+				value:   []byte("access_secret"),
+				ref:     esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret", Property: "access_secret"},
+			},
+			want: want{
+				body: map[string]interface{}{
+					"access_key":    "access_key",
+					"access_secret": "access_secret",
+				},
+			},
+		},
+		"MergePropertyKV2": {
+			reason: "Should merge the property into the existing secret data and embed it in the data field.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV2,
+				read: newVaultResponseWithData(map[string]interface{}{
+					"data": existing,
+				}),
+				value: []byte("access_secret"),
+				ref:   esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret", Property: "access_secret"},
+			},
+			want: want{
+				body: map[string]interface{}{
+					"data": map[string]interface{}{
+						"access_key":    "access_key",
+						"access_secret": "access_secret",
+					},
+				},
+			},
+		},
+		"CreatePropertyIfNotFound": {
+			reason: "Should create the secret if it does not exist yet.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV2,
+				readErr: &vault.ResponseError{StatusCode: http.StatusNotFound},
+				value:   []byte("access_secret"),
+				ref:     esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret", Property: "access_secret"},
+			},
+			want: want{
+				body: map[string]interface{}{
+					"data": map[string]interface{}{
+						"access_secret": "access_secret",
+					},
+				},
+			},
+		},
+		"ReplaceWithoutProperty": {
+			reason: "Should replace the secret data with the JSON value if no property is given.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV1,
+				read:    newVaultResponseWithData(existing),
+				value:   []byte(`{"access_key":"new_key"}`),
+				ref:     esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret"},
+			},
+			want: want{
+				body: map[string]interface{}{
+					"access_key": "new_key",
+				},
+			},
+		},
+		"UnchangedProperty": {
+			reason: "Should not write the secret if the property already has the value.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV2,
+				read: newVaultResponseWithData(map[string]interface{}{
+					"data": existing,
+				}),
+				value: []byte("access_key"),
+				ref:   esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret", Property: "access_key"},
+			},
+		},
+		"UnchangedWithoutProperty": {
+			reason: "Should not write the secret if the JSON value equals the secret data.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV1,
+				read: newVaultResponseWithData(map[string]interface{}{
+					"access_key": "access_key",
+					"rotations":  json.Number("3"),
+				}),
+				value: []byte(`{"access_key":"access_key","rotations":3}`),
+				ref:   esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret"},
+			},
+		},
+		"InvalidValueWithoutProperty": {
+			reason: "Should return an error if no property is given and the value is not a JSON object.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV1,
+				value:   []byte("access_secret"),
+				ref:     esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret"},
+			},
+			want: want{
+				err: fmt.Errorf(errPushFormat, json.Unmarshal([]byte("access_secret"), &map[string]interface{}{})),
+			},
+		},
+		"ReadError": {
+			reason: "Should return an error if the existing secret can not be read.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV1,
+				readErr: errBoom,
+				value:   []byte("access_secret"),
+				ref:     esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret", Property: "access_secret"},
+			},
+			want: want{
+				err: fmt.Errorf(errReadSecret, errBoom),
+			},
+		},
+		"WriteError": {
+			reason: "Should return an error if the secret can not be written.",
+			args: args{
+				version:  esv1alpha1.VaultKVStoreV1,
+				read:     newVaultResponseWithData(existing),
+				writeErr: errBoom,
+				value:    []byte("access_secret"),
+				ref:      esv1alpha1.PushSecretRemoteRef{RemoteKey: "secret", Property: "access_secret"},
+			},
+			want: want{
+				err: fmt.Errorf(errWriteSecret, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var body interface{}
+			vStore := &client{
+				client: &fake.VaultClient{
+					MockNewRequest: func(method, requestPath string) *vault.Request {
+						return &vault.Request{Method: method, Params: url.Values{}}
+					},
+					MockRawRequestWithContext: func(_ context.Context, r *vault.Request) (*vault.Response, error) {
+						if r.Method == http.MethodGet {
+							return tc.args.read, tc.args.readErr
+						}
+						body = r.Obj
+						return nil, tc.args.writeErr
+					},
+				},
+				store: makeValidSecretStoreWithVersion(tc.args.version).Spec.Provider.Vault,
+			}
+			err := vStore.PushSecret(context.Background(), tc.args.value, tc.args.ref)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nvault.PushSecret(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("\n%s\nvault.PushSecret(...): -want body, +got body:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetAllSecrets(t *testing.T) {
+	errBoom := errors.New("boom")
+	path := "app/"
+
+	// responses of the fake Vault server by method and request path
+	kv2 := map[string]map[string]interface{}{
+		"LIST /v1/secret/metadata/": {
+			"keys": []interface{}{"app/", "other"},
+		},
+		"LIST /v1/secret/metadata/app/": {
+			"keys": []interface{}{"db", "api"},
+		},
+		"GET /v1/secret/metadata/app/db": {
+			"custom_metadata": map[string]interface{}{"env": "prod"},
+		},
+		"GET /v1/secret/metadata/app/api": {
+			"custom_metadata": map[string]interface{}{"env": "dev"},
+		},
+		"GET /v1/secret/data/app/db":  {"data": map[string]interface{}{"user": "db"}},
+		"GET /v1/secret/data/app/api": {"data": map[string]interface{}{"user": "api"}},
+		"GET /v1/secret/data/other":   {"data": map[string]interface{}{"user": "other"}},
+	}
+	kv1 := map[string]map[string]interface{}{
+		"LIST /v1/secret/": {
+			"keys": []interface{}{"app/", "other"},
+		},
+		"LIST /v1/secret/app/": {
+			"keys": []interface{}{"db", "api"},
+		},
+		"GET /v1/secret/app/db":  {"user": "db"},
+		"GET /v1/secret/app/api": {"user": "api"},
+		"GET /v1/secret/other":   {"user": "other"},
+	}
+
+	type args struct {
+		version   esv1alpha1.VaultKVStoreVersion
+		responses map[string]map[string]interface{}
+		err       error
+		find      esv1alpha1.ExternalSecretFind
+	}
+
+	type want struct {
+		data map[string][]byte
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"FindAllKV2": {
+			reason: "Should walk the metadata of the KV engine and return all secrets.",
+			args: args{
+				version:   esv1alpha1.VaultKVStoreV2,
+				responses: kv2,
+			},
+			want: want{
+				data: map[string][]byte{
+					"app/db":  []byte(`{"user":"db"}`),
+					"app/api": []byte(`{"user":"api"}`),
+					"other":   []byte(`{"user":"other"}`),
+				},
+			},
+		},
+		"FindByPathAndNameKV1": {
+			reason: "Should only return the secrets below the path that match the name.",
+			args: args{
+				version:   esv1alpha1.VaultKVStoreV1,
+				responses: kv1,
+				find: esv1alpha1.ExternalSecretFind{
+					Path: &path,
+					Name: &esv1alpha1.FindName{RegExp: "db$"},
+				},
+			},
+			want: want{
+				data: map[string][]byte{
+					"app/db": []byte(`{"user":"db"}`),
+				},
+			},
+		},
+		"FindByTagsKV2": {
+			reason: "Should match the tags against the custom metadata.",
+			args: args{
+				version:   esv1alpha1.VaultKVStoreV2,
+				responses: kv2,
+				find: esv1alpha1.ExternalSecretFind{
+					Path: &path,
+					Tags: map[string]string{"env": "prod"},
+				},
+			},
+			want: want{
+				data: map[string][]byte{
+					"app/db": []byte(`{"user":"db"}`),
+				},
+			},
+		},
+		"FindByTagsKV1": {
+			reason: "Should return an error if tags are used with KV version 1.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV1,
+				find: esv1alpha1.ExternalSecretFind{
+					Tags: map[string]string{"env": "prod"},
+				},
+			},
+			want: want{
+				err: errors.New(errFindTagsV1),
+			},
+		},
+		"ListError": {
+			reason: "Should return an error if the secrets can not be listed.",
+			args: args{
+				version: esv1alpha1.VaultKVStoreV2,
+				err:     errBoom,
+			},
+			want: want{
+				err: fmt.Errorf(errListSecrets, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			vStore := &client{
+				client: &fake.VaultClient{
+					MockNewRequest: func(method, requestPath string) *vault.Request {
+						return &vault.Request{Method: method, URL: &url.URL{Path: requestPath}, Params: url.Values{}}
+					},
+					MockRawRequestWithContext: func(_ context.Context, r *vault.Request) (*vault.Response, error) {
+						if tc.args.err != nil {
+							return nil, tc.args.err
+						}
+						data, ok := tc.args.responses[r.Method+" "+r.URL.Path]
+						if !ok {
+							return nil, &vault.ResponseError{StatusCode: http.StatusNotFound}
+						}
+						return newVaultResponseWithData(data), nil
+					},
+				},
+				store: makeValidSecretStoreWithVersion(tc.args.version).Spec.Provider.Vault,
+			}
+			data, err := vStore.GetAllSecrets(context.Background(), tc.args.find)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nvault.GetAllSecrets(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nvault.GetAllSecrets(...): -want data, +got data:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetSecretRecordsVersion(t *testing.T) {
+	vStore := &client{
+		client: &fake.VaultClient{
+			MockNewRequest: func(method, requestPath string) *vault.Request {
+				return &vault.Request{Method: method, URL: &url.URL{Path: requestPath}, Params: url.Values{}}
+			},
+			MockRawRequestWithContext: func(_ context.Context, r *vault.Request) (*vault.Response, error) {
+				return newVaultResponseWithData(map[string]interface{}{
+					"data":     map[string]interface{}{"user": "db"},
+					"metadata": map[string]interface{}{"version": 4},
+				}), nil
+			},
+		},
+		store: makeValidSecretStoreWithVersion(esv1alpha1.VaultKVStoreV2).Spec.Provider.Vault,
+	}
+	ref := esv1alpha1.ExternalSecretDataRemoteRef{Key: "app/db", Property: "user"}
+	ctx, rec := provider.WithVersionRecorder(context.Background())
+	if _, err := vStore.GetSecret(ctx, ref); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rec.Version(ref.Key, ref.Version); got != "4" {
+		t.Errorf("unexpected version: %q", got)
+	}
+}
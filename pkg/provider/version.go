@@ -0,0 +1,63 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+type versionRecorderKey struct{}
+
+type versionKey struct {
+	key     string
+	version string
+}
+
+// VersionRecorder collects the provider version IDs of the secrets read with a context.
+// Providers report the version they resolved a remote ref to with RecordVersion,
+// so callers can tell which version of a secret they received.
+type VersionRecorder struct {
+	mu       sync.Mutex
+	versions map[versionKey]string
+}
+
+// WithVersionRecorder returns a context that records the versions reported by the
+// providers called with it, and the recorder they are written to.
+func WithVersionRecorder(ctx context.Context) (context.Context, *VersionRecorder) {
+	rec := &VersionRecorder{versions: make(map[versionKey]string)}
+	return context.WithValue(ctx, versionRecorderKey{}, rec), rec
+}
+
+// RecordVersion reports that the secret with the key and requested version was
+// resolved to the provider version versionID.
+// It does nothing if the context has no VersionRecorder or versionID is empty.
+func RecordVersion(ctx context.Context, key, version, versionID string) {
+	rec, ok := ctx.Value(versionRecorderKey{}).(*VersionRecorder)
+	if !ok || versionID == "" {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.versions[versionKey{key: key, version: version}] = versionID
+}
+
+// Version returns the provider version ID recorded for the key and requested version,
+// or an empty string if the provider didn't report one.
+func (r *VersionRecorder) Version(key, version string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.versions[versionKey{key: key, version: version}]
+}
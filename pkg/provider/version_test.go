@@ -0,0 +1,40 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestVersionRecorder(t *testing.T) {
+	// no recorder in the context
+	RecordVersion(context.Background(), "foo", "", "1")
+
+	ctx, rec := WithVersionRecorder(context.Background())
+	RecordVersion(ctx, "foo", "", "3")
+	RecordVersion(ctx, "foo", "1", "1")
+	RecordVersion(ctx, "bar", "", "")
+
+	if got := rec.Version("foo", ""); got != "3" {
+		t.Errorf("unexpected version of foo: %q", got)
+	}
+	if got := rec.Version("foo", "1"); got != "1" {
+		t.Errorf("unexpected version of foo@1: %q", got)
+	}
+	if got := rec.Version("bar", ""); got != "" {
+		t.Errorf("unexpected version of bar: %q", got)
+	}
+}
@@ -28,8 +28,10 @@ import (
 
 	"github.com/Masterminds/sprig"
 	"github.com/PaesslerAG/jsonpath"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
@@ -37,8 +39,12 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
 	"github.com/external-secrets/external-secrets/pkg/template"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
+// maxErrorBodySize limits how much of an error response is read to match it against notFound.
+const maxErrorBodySize = 1 << 20
+
 // Provider satisfies the provider interface.
 type Provider struct{}
 
@@ -74,6 +80,36 @@ func (p *Provider) NewClient(ctx context.Context, store esv1alpha1.GenericStore,
 	return whClient, nil
 }
 
+// ValidateStore checks the webhook specific part of the store spec.
+func (p *Provider) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "webhook")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.Webhook == nil {
+		return field.ErrorList{field.Required(fldPath, "missing webhook provider")}
+	}
+	spec := storeSpec.Provider.Webhook
+	allErrs := field.ErrorList{}
+	if spec.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), ""))
+	} else if _, err := tpl.New("url").Funcs(template.FuncMap()).Parse(spec.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), spec.URL, err.Error()))
+	}
+	for i, secret := range spec.Secrets {
+		secretPath := fldPath.Child("secrets").Index(i)
+		if secret.Name == "" {
+			allErrs = append(allErrs, field.Required(secretPath.Child("name"), ""))
+		}
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, secret.SecretRef, secretPath.Child("secretRef"))...)
+	}
+	if spec.CAProvider != nil {
+		allErrs = append(allErrs, utils.ValidateReferentNamespace(store, spec.CAProvider.Namespace, fldPath.Child("caProvider", "namespace"))...)
+	}
+	if nf := spec.NotFound; nf != nil && nf.StatusCode == 0 && nf.JSONPath == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("notFound"), "statusCode or jsonPath must be set"))
+	}
+	return allErrs
+}
+
 func getProvider(store esv1alpha1.GenericStore) (*esv1alpha1.WebhookProvider, error) {
 	spc := store.GetSpec()
 	if spc == nil || spc.Provider == nil || spc.Provider.Webhook == nil {
@@ -178,6 +214,11 @@ func (w *WebHook) GetSecretMap(ctx context.Context, ref esv1alpha1.ExternalSecre
 	return values, nil
 }
 
+// GetAllSecrets is not supported by the webhook provider.
+func (w *WebHook) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
+
 func (w *WebHook) getTemplateData(ctx context.Context, ref esv1alpha1.ExternalSecretDataRemoteRef, secrets []esv1alpha1.WebhookSecret) (map[string]map[string]string, error) {
 	data := map[string]map[string]string{
 		"remoteRef": {
@@ -201,23 +242,23 @@ func (w *WebHook) getTemplateData(ctx context.Context, ref esv1alpha1.ExternalSe
 	return data, nil
 }
 
-func (w *WebHook) getWebhookData(ctx context.Context, provider *esv1alpha1.WebhookProvider, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
+func (w *WebHook) getWebhookData(ctx context.Context, prov *esv1alpha1.WebhookProvider, ref esv1alpha1.ExternalSecretDataRemoteRef) ([]byte, error) {
 	if w.http == nil {
 		return nil, fmt.Errorf("http client not initialized")
 	}
-	data, err := w.getTemplateData(ctx, ref, provider.Secrets)
+	data, err := w.getTemplateData(ctx, ref, prov.Secrets)
 	if err != nil {
 		return nil, err
 	}
-	method := provider.Method
+	method := prov.Method
 	if method == "" {
 		method = http.MethodGet
 	}
-	url, err := executeTemplateString(provider.URL, data)
+	url, err := executeTemplateString(prov.URL, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse url: %w", err)
 	}
-	body, err := executeTemplate(provider.Body, data)
+	body, err := executeTemplate(prov.Body, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse body: %w", err)
 	}
@@ -226,7 +267,7 @@ func (w *WebHook) getWebhookData(ctx context.Context, provider *esv1alpha1.Webho
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	for hKey, hValueTpl := range provider.Headers {
+	for hKey, hValueTpl := range prov.Headers {
 		hValue, err := executeTemplateString(hValueTpl, data)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse header %s: %w", hKey, err)
@@ -240,13 +281,45 @@ func (w *WebHook) getWebhookData(ctx context.Context, provider *esv1alpha1.Webho
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("endpoint gave error %s", resp.Status)
+		err := fmt.Errorf("endpoint gave error %s", resp.Status)
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		if isNotFound(prov.NotFound, resp.StatusCode, respBody) {
+			return nil, provider.NewNotFoundError(err)
+		}
+		// a 404 may come from a wrong url as well, which must not delete the target
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, provider.NewInvalidRefError(err)
+		}
+		return nil, provider.FromHTTPStatus(resp.StatusCode, err)
 	}
 	return io.ReadAll(resp.Body)
 }
 
+// isNotFound returns true if the error response matches the notFound spec of the provider.
+func isNotFound(notFound *esv1alpha1.WebhookNotFound, statusCode int, body []byte) bool {
+	if notFound == nil || (notFound.StatusCode == 0 && notFound.JSONPath == "") {
+		return false
+	}
+	if notFound.StatusCode != 0 && notFound.StatusCode != statusCode {
+		return false
+	}
+	if notFound.JSONPath == "" {
+		return true
+	}
+	jsondata := interface{}(nil)
+	if err := yaml.Unmarshal(body, &jsondata); err != nil {
+		return false
+	}
+	value, err := jsonpath.Get(notFound.JSONPath, jsondata)
+	if err != nil || value == nil {
+		return false
+	}
+	return notFound.Value == "" || fmt.Sprint(value) == notFound.Value
+}
+
 func (w *WebHook) getHTTPClient(provider *esv1alpha1.WebhookProvider) (*http.Client, error) {
-	client := &http.Client{}
+	// propagate the trace context of the reconcile to the endpoint
+	client := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
 	if provider.Timeout != nil {
 		client.Timeout = provider.Timeout.Duration
 	}
@@ -263,7 +336,7 @@ func (w *WebHook) getHTTPClient(provider *esv1alpha1.WebhookProvider) (*http.Cli
 		RootCAs:    caCertPool,
 		MinVersion: tls.VersionTLS12,
 	}
-	client.Transport = &http.Transport{TLSClientConfig: tlsConf}
+	client.Transport = otelhttp.NewTransport(&http.Transport{TLSClientConfig: tlsConf})
 	return client, nil
 }
 
@@ -373,6 +446,12 @@ func (w *WebHook) getCertFromConfigMap(provider *esv1alpha1.WebhookProvider) ([]
 	return []byte(val), nil
 }
 
+// Validate returns ValidationResultUnknown: the url and body of the request are
+// templated with the remoteRef of an ExternalSecret, the store alone can't build one.
+func (w *WebHook) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	return provider.ValidationResultUnknown, nil
+}
+
 func (w *WebHook) Close(ctx context.Context) error {
 	return nil
 }
@@ -24,6 +24,9 @@ import (
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gopkg.in/yaml.v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -46,11 +49,17 @@ type args struct {
 	JSONPath   string `json:"jsonpath,omitempty"`
 	Response   string `json:"response,omitempty"`
 	StatusCode int    `json:"statuscode,omitempty"`
+
+	NotFoundStatusCode int    `json:"notfoundstatuscode,omitempty"`
+	NotFoundJSONPath   string `json:"notfoundjsonpath,omitempty"`
+	NotFoundValue      string `json:"notfoundvalue,omitempty"`
 }
 
 type want struct {
 	Path      string            `json:"path,omitempty"`
 	Err       string            `json:"err,omitempty"`
+	NotFound  bool              `json:"notfound,omitempty"`
+	Category  string            `json:"category,omitempty"`
 	Result    string            `json:"result,omitempty"`
 	ResultMap map[string]string `json:"resultmap,omitempty"`
 }
@@ -82,9 +91,78 @@ args:
   version: 1
   statuscode: 404
   response: not found
+  notfoundstatuscode: 404
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: endpoint gave error 404
+  notfound: true
+---
+case: error plain 404
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  statuscode: 404
+  response: no such route
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: endpoint gave error 404
+  category: InvalidRef
+---
+case: error not found by json field
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  statuscode: 404
+  response: '{"error":{"code":"SecretNotFound"}}'
+  notfoundstatuscode: 404
+  notfoundjsonpath: $.error.code
+  notfoundvalue: SecretNotFound
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: endpoint gave error 404
+  notfound: true
+---
+case: error other json field
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  statuscode: 404
+  response: '{"error":{"code":"NoSuchRoute"}}'
+  notfoundjsonpath: $.error.code
+  notfoundvalue: SecretNotFound
 want:
   path: /api/getsecret?id=testkey&version=1
   err: endpoint gave error 404
+  category: InvalidRef
+---
+case: error not found with other status
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  statuscode: 410
+  response: '{"missing":true}'
+  notfoundjsonpath: $.missing
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: endpoint gave error 410
+  notfound: true
+---
+case: error server
+args:
+  url: /api/getsecret?id={{ .remoteRef.key }}&version={{ .remoteRef.version }}
+  key: testkey
+  version: 1
+  statuscode: 503
+  response: unavailable
+  notfoundstatuscode: 404
+want:
+  path: /api/getsecret?id=testkey&version=1
+  err: endpoint gave error 503
+  category: Transient
 ---
 case: error bad json
 args:
@@ -210,7 +288,7 @@ func TestWebhookGetSecret(t *testing.T) {
 		var tc testCase
 		if err := ydec.Decode(&tc); err != nil {
 			if !errors.Is(err, io.EOF) {
-				t.Errorf("testcase decode error %w", err)
+				t.Errorf("testcase decode error %v", err)
 			}
 			break
 		}
@@ -218,6 +296,32 @@ func TestWebhookGetSecret(t *testing.T) {
 	}
 }
 
+func TestWebhookPropagatesTraceContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "reconcile")
+	defer span.End()
+
+	var traceparent string
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		traceparent = req.Header.Get("traceparent")
+		rw.Write([]byte("secret-value"))
+	}))
+	defer ts.Close()
+
+	client, err := (&Provider{}).NewClient(ctx, makeClusterSecretStore(ts.URL, args{URL: "/api/getsecret"}), nil, "testnamespace")
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	if _, err := client.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: "testkey"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(traceparent, span.SpanContext().TraceID().String()) {
+		t.Errorf("expected the traceparent header of trace %s, got %q", span.SpanContext().TraceID(), traceparent)
+	}
+}
+
 func testCaseServer(tc testCase, t *testing.T) *httptest.Server {
 	// Start a new server for every test case because the server wants to check the expected api path
 	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -306,6 +410,12 @@ func testGetSecret(tc testCase, t *testing.T, client provider.SecretsClient) {
 	if !strings.Contains(errStr, tc.Want.Err) {
 		t.Errorf("%s: unexpected error: '%s' (expected '%s')", tc.Case, errStr, tc.Want.Err)
 	}
+	if provider.IsNotFound(err) != tc.Want.NotFound {
+		t.Errorf("%s: unexpected not found error: '%s' (expected %t)", tc.Case, errStr, tc.Want.NotFound)
+	}
+	if tc.Want.Category != "" && string(provider.GetErrorCategory(err)) != tc.Want.Category {
+		t.Errorf("%s: unexpected error category: %s (expected %s)", tc.Case, provider.GetErrorCategory(err), tc.Want.Category)
+	}
 	if err == nil && string(secret) != tc.Want.Result {
 		t.Errorf("%s: unexpected response: '%s' (expected '%s')", tc.Case, secret, tc.Want.Result)
 	}
@@ -336,5 +446,12 @@ func makeClusterSecretStore(url string, args args) *esv1alpha1.ClusterSecretStor
 			},
 		},
 	}
+	if args.NotFoundStatusCode != 0 || args.NotFoundJSONPath != "" {
+		store.Spec.Provider.Webhook.NotFound = &esv1alpha1.WebhookNotFound{
+			StatusCode: args.NotFoundStatusCode,
+			JSONPath:   args.NotFoundJSONPath,
+			Value:      args.NotFoundValue,
+		}
+	}
 	return store
 }
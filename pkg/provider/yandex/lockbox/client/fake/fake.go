@@ -23,6 +23,7 @@ import (
 	"github.com/yandex-cloud/go-genproto/yandex/cloud/lockbox/v1"
 	"github.com/yandex-cloud/go-sdk/iamkey"
 
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox/client"
 )
 
@@ -131,20 +132,20 @@ func (lb *LockboxBackend) getToken(authorizedKey *iamkey.Key) (*client.IamToken,
 
 func (lb *LockboxBackend) getEntries(iamToken, secretID, versionID string) ([]*lockbox.Payload_Entry, error) {
 	if _, ok := lb.secretMap[secretKey{secretID}]; !ok {
-		return nil, fmt.Errorf("secret not found")
+		return nil, provider.NewNotFoundError(fmt.Errorf("secret not found"))
 	}
 	if _, ok := lb.versionMap[versionKey{secretID, versionID}]; !ok {
-		return nil, fmt.Errorf("version not found")
+		return nil, provider.NewNotFoundError(fmt.Errorf("version not found"))
 	}
 	if _, ok := lb.tokenMap[tokenKey{iamToken}]; !ok {
-		return nil, fmt.Errorf("unauthenticated")
+		return nil, provider.NewUnauthenticatedError(fmt.Errorf("unauthenticated"))
 	}
 
 	if lb.tokenMap[tokenKey{iamToken}].expiresAt.Before(lb.now) {
-		return nil, fmt.Errorf("iam token expired")
+		return nil, provider.NewUnauthenticatedError(fmt.Errorf("iam token expired"))
 	}
 	if !cmp.Equal(lb.tokenMap[tokenKey{iamToken}].authorizedKey, lb.secretMap[secretKey{secretID}].expectedAuthorizedKey) {
-		return nil, fmt.Errorf("permission denied")
+		return nil, provider.NewPermissionDeniedError(fmt.Errorf("permission denied"))
 	}
 
 	return lb.versionMap[versionKey{secretID, versionID}].entries, nil
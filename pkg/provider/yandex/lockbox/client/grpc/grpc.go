@@ -28,6 +28,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox/client"
 )
 
@@ -139,7 +140,7 @@ func (lc *LockboxClient) GetPayloadEntries(ctx context.Context, iamToken, secret
 		grpc.PerRPCCredentials(perRPCCredentials{iamToken: iamToken}),
 	)
 	if err != nil {
-		return nil, err
+		return nil, provider.FromGRPCError(err)
 	}
 	return payload.Entries, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/yandex-cloud/go-sdk/iamkey"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox/client"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox/client/grpc"
+	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
 const maxSecretsClientLifetime = 5 * time.Minute // supposed SecretsClient lifetime is quite short
@@ -147,6 +149,21 @@ func (p *lockboxProvider) NewClient(ctx context.Context, store esv1alpha1.Generi
 	return &lockboxSecretsClient{lockboxClient, iamToken.Token}, nil
 }
 
+// ValidateStore checks the Yandex Lockbox specific part of the store spec.
+func (p *lockboxProvider) ValidateStore(store esv1alpha1.GenericStore) field.ErrorList {
+	fldPath := field.NewPath("spec", "provider", "yandexlockbox")
+	storeSpec := store.GetSpec()
+	if storeSpec == nil || storeSpec.Provider == nil || storeSpec.Provider.YandexLockbox == nil {
+		return field.ErrorList{field.Required(fldPath, "received invalid Yandex Lockbox SecretStore resource")}
+	}
+	spec := storeSpec.Provider.YandexLockbox
+	allErrs := utils.ValidateSecretSelector(store, spec.Auth.AuthorizedKey, fldPath.Child("auth", "authorizedKeySecretRef"))
+	if spec.CAProvider != nil {
+		allErrs = append(allErrs, utils.ValidateSecretSelector(store, spec.CAProvider.Certificate, fldPath.Child("caProvider", "certSecretRef"))...)
+	}
+	return allErrs
+}
+
 func (p *lockboxProvider) getOrCreateLockboxClient(ctx context.Context, apiEndpoint string, authorizedKey *iamkey.Key, caCertificate []byte) (client.LockboxClient, error) {
 	p.lockboxClientMapMutex.Lock()
 	defer p.lockboxClientMapMutex.Unlock()
@@ -272,6 +289,18 @@ func (c *lockboxSecretsClient) GetSecretMap(ctx context.Context, ref esv1alpha1.
 	return secretMap, nil
 }
 
+// GetAllSecrets is not supported by the Lockbox provider.
+func (c *lockboxSecretsClient) GetAllSecrets(ctx context.Context, ref esv1alpha1.ExternalSecretFind) (map[string][]byte, error) {
+	return nil, provider.ErrGetAllSecretsNotImplemented
+}
+
+// Validate returns ValidationResultUnknown. The IAM token obtained by NewClient
+// proves the authorized key, but Lockbox can only list the secrets of a folder
+// and the store has no folder ID, so the access can't be checked before a secret is read.
+func (c *lockboxSecretsClient) Validate(ctx context.Context) (provider.ValidationResult, error) {
+	return provider.ValidationResultUnknown, nil
+}
+
 func (c *lockboxSecretsClient) Close(ctx context.Context) error {
 	return nil
 }
@@ -304,7 +333,7 @@ func findEntryByKey(entries []*lockbox.Payload_Entry, key string) (*lockbox.Payl
 			return entries[i], nil
 		}
 	}
-	return nil, fmt.Errorf("payload entry with key '%s' not found", key)
+	return nil, provider.NewInvalidRefError(fmt.Errorf("payload entry with key '%s' not found", key))
 }
 
 func init() {
@@ -34,6 +34,7 @@ import (
 
 	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
 	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+	"github.com/external-secrets/external-secrets/pkg/provider"
 	"github.com/external-secrets/external-secrets/pkg/provider/schema"
 	"github.com/external-secrets/external-secrets/pkg/provider/yandex/lockbox/client/fake"
 )
@@ -266,13 +267,14 @@ func TestGetSecretUnauthorized(t *testing.T) {
 	tassert.Nil(t, err)
 	store := newYandexLockboxSecretStore("", namespace, authorizedKeySecretName, authorizedKeySecretKey)
 
-	provider := newLockboxProvider(&fake.YandexCloudCreator{
+	lockboxProvider := newLockboxProvider(&fake.YandexCloudCreator{
 		Backend: lockboxBackend,
 	})
-	secretsClient, err := provider.NewClient(ctx, store, k8sClient, namespace)
+	secretsClient, err := lockboxProvider.NewClient(ctx, store, k8sClient, namespace)
 	tassert.Nil(t, err)
 	_, err = secretsClient.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: secretID})
 	tassert.EqualError(t, err, errSecretPayloadPermissionDenied)
+	tassert.Equal(t, provider.ErrorCategoryPermissionDenied, provider.GetErrorCategory(err))
 }
 
 func TestGetSecretNotFound(t *testing.T) {
@@ -289,19 +291,21 @@ func TestGetSecretNotFound(t *testing.T) {
 	tassert.Nil(t, err)
 	store := newYandexLockboxSecretStore("", namespace, authorizedKeySecretName, authorizedKeySecretKey)
 
-	provider := newLockboxProvider(&fake.YandexCloudCreator{
+	lockboxProvider := newLockboxProvider(&fake.YandexCloudCreator{
 		Backend: lockboxBackend,
 	})
-	secretsClient, err := provider.NewClient(ctx, store, k8sClient, namespace)
+	secretsClient, err := lockboxProvider.NewClient(ctx, store, k8sClient, namespace)
 	tassert.Nil(t, err)
 	_, err = secretsClient.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: "no-secret-with-this-id"})
 	tassert.EqualError(t, err, errSecretPayloadNotFound)
+	tassert.True(t, provider.IsNotFound(err))
 
 	secretID, _ := lockboxBackend.CreateSecret(authorizedKey,
 		textEntry("k1", "v1"),
 	)
 	_, err = secretsClient.GetSecret(ctx, esv1alpha1.ExternalSecretDataRemoteRef{Key: secretID, Version: "no-version-with-this-id"})
 	tassert.EqualError(t, err, "unable to request secret payload to get secret: version not found")
+	tassert.True(t, provider.IsNotFound(err))
 }
 
 func TestGetSecretWithTwoNamespaces(t *testing.T) {
@@ -27,6 +27,7 @@ import (
 	"github.com/youmark/pkcs8"
 	"golang.org/x/crypto/pkcs12"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
 var tplFuncs = tpl.FuncMap{
@@ -67,6 +68,10 @@ const (
 	errDecodeBase64         = "unable to decode base64: %s"
 	errUnmarshalJSON        = "unable to unmarshal json: %s"
 	errMarshalJSON          = "unable to marshal json: %s"
+	errUnmarshalManifest    = "unable to decode rendered manifest: %s"
+
+	// ManifestKey names the manifest template in errors.
+	ManifestKey = "manifest"
 )
 
 // Execute renders the secret data as template. If an error occurs processing is stopped immediately.
@@ -84,6 +89,32 @@ func Execute(tpl, data map[string][]byte, secret *corev1.Secret) error {
 	return nil
 }
 
+// ExecuteManifest renders the manifest template with the secret data and
+// decodes the resulting YAML or JSON object.
+func ExecuteManifest(manifest string, data map[string][]byte) (map[string]interface{}, error) {
+	out, err := execute(ManifestKey, manifest, data)
+	if err != nil {
+		return nil, err
+	}
+	obj := make(map[string]interface{})
+	err = yaml.Unmarshal(out, &obj)
+	if err != nil {
+		return nil, fmt.Errorf(errUnmarshalManifest, err)
+	}
+	return obj, nil
+}
+
+// Validate checks if the template at key k can be parsed.
+func Validate(k, val string) error {
+	_, err := tpl.New(k).
+		Funcs(tplFuncs).
+		Parse(val)
+	if err != nil {
+		return fmt.Errorf(errParse, k, err)
+	}
+	return nil
+}
+
 func execute(k, val string, data map[string][]byte) ([]byte, error) {
 	t, err := tpl.New(k).
 		Funcs(tplFuncs).
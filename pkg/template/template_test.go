@@ -359,6 +359,99 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	tbl := []struct {
+		name   string
+		tpl    string
+		expErr string
+	}{
+		{
+			name: "valid template",
+			tpl:  "{{ .secret | base64decode | toString }}",
+		},
+		{
+			name:   "unknown function",
+			tpl:    "{{ .secret | nope }}",
+			expErr: `function "nope" not defined`,
+		},
+		{
+			name:   "unclosed action",
+			tpl:    "{{ .secret ",
+			expErr: "unable to parse template at key foo",
+		},
+	}
+
+	for i := range tbl {
+		row := tbl[i]
+		t.Run(row.name, func(t *testing.T) {
+			err := Validate("foo", row.tpl)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+		})
+	}
+}
+
+func TestExecuteManifest(t *testing.T) {
+	tbl := []struct {
+		name     string
+		manifest string
+		data     map[string][]byte
+		expected map[string]interface{}
+		expErr   string
+	}{
+		{
+			name: "yaml manifest",
+			manifest: `spec:
+  server: {{ .server | toString }}
+  password: {{ .password | toString | toJSON }}`,
+			data: map[string][]byte{
+				"server":   []byte("https://example.com"),
+				"password": []byte("a: b"),
+			},
+			expected: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"server":   "https://example.com",
+					"password": "a: b",
+				},
+			},
+		},
+		{
+			name:     "json manifest",
+			manifest: `{"data": {"user": "{{ .user | toString }}"}}`,
+			data:     map[string][]byte{"user": []byte("admin")},
+			expected: map[string]interface{}{
+				"data": map[string]interface{}{"user": "admin"},
+			},
+		},
+		{
+			name:     "not an object",
+			manifest: `- {{ .user | toString }}`,
+			data:     map[string][]byte{"user": []byte("admin")},
+			expErr:   "unable to decode rendered manifest",
+		},
+		{
+			name:     "unknown function",
+			manifest: `{{ .user | nope }}`,
+			expErr:   "unable to parse template at key manifest",
+		},
+	}
+
+	for i := range tbl {
+		row := tbl[i]
+		t.Run(row.name, func(t *testing.T) {
+			obj, err := ExecuteManifest(row.manifest, row.data)
+			if !ErrorContains(err, row.expErr) {
+				t.Errorf("unexpected error: %s, expected: %s", err, row.expErr)
+			}
+			if row.expected == nil {
+				return
+			}
+			assert.Equal(t, row.expected, obj)
+		})
+	}
+}
+
 func ErrorContains(out error, want string) bool {
 	if out == nil {
 		return want == ""
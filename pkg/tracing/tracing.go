@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing exports OpenTelemetry spans of the controllers to an OTLP collector.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// InstrumentationName is the name of the tracer of the spans.
+	InstrumentationName = "github.com/external-secrets/external-secrets"
+
+	// DefaultServiceName is the service.name resource attribute of the spans.
+	DefaultServiceName = "external-secrets"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var errSampleRatio = errors.New("the sample ratio must be between 0 and 1")
+
+// Options configures the export of the spans.
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool
+	// SampleRatio is the ratio of the traces that are sampled,
+	// unless the parent span is sampled already.
+	SampleRatio float64
+	// ServiceName defaults to DefaultServiceName.
+	ServiceName string
+}
+
+// Provider exports the spans of the controllers. It implements manager.Runnable,
+// pending spans are flushed when the manager stops.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// Setup exports the spans to the OTLP collector and installs the
+// W3C trace context propagator for outgoing requests.
+// Without Setup spans are not recorded.
+func Setup(ctx context.Context, opts Options) (*Provider, error) {
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, errSampleRatio
+	}
+	clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	p := newProvider(sdktrace.NewBatchSpanProcessor(exporter), opts)
+	otel.SetTracerProvider(p.tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return p, nil
+}
+
+func newProvider(processor sdktrace.SpanProcessor, opts Options) *Provider {
+	serviceName := opts.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	return &Provider{
+		tp: sdktrace.NewTracerProvider(
+			sdktrace.WithSpanProcessor(processor),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+			sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+		),
+	}
+}
+
+// Start implements manager.Runnable, it flushes the pending spans once ctx is done.
+func (p *Provider) Start(ctx context.Context) error {
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return p.tp.Shutdown(shutdownCtx)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable,
+// spans are exported by every replica.
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// Start starts a span as child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, marking it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	SetError(span, err)
+	span.End()
+}
+
+// SetError marks the span as failed if err is not nil.
+func SetError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
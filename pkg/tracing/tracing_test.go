@@ -0,0 +1,60 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndRecordsError(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	p := newProvider(sdktrace.NewSimpleSpanProcessor(exporter), Options{SampleRatio: 1})
+	otel.SetTracerProvider(p.tp)
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("boom"))
+	End(parent, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name != "child" || spans[0].Status.Code != codes.Error || spans[0].Status.Description != "boom" {
+		t.Errorf("unexpected child span: %s %v", spans[0].Name, spans[0].Status)
+	}
+	if spans[0].Parent.SpanID() != spans[1].SpanContext.SpanID() {
+		t.Errorf("expected child of the parent span")
+	}
+	if spans[1].Name != "parent" || spans[1].Status.Code != codes.Unset {
+		t.Errorf("unexpected parent span: %s %v", spans[1].Name, spans[1].Status)
+	}
+}
+
+func TestSetupRejectsSampleRatio(t *testing.T) {
+	for _, ratio := range []float64{-0.1, 1.1} {
+		if _, err := Setup(context.Background(), Options{Endpoint: "localhost:4317", SampleRatio: ratio}); !errors.Is(err, errSampleRatio) {
+			t.Errorf("expected an error for sample ratio %v, got %v", ratio, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// Decode decodes the value with the given strategy.
+// An empty strategy leaves the value as it is.
+func Decode(strategy esv1alpha1.ExternalSecretDecodingStrategy, in []byte) ([]byte, error) {
+	switch strategy {
+	case esv1alpha1.ExternalSecretDecodeNone, "":
+		return in, nil
+	case esv1alpha1.ExternalSecretDecodeBase64:
+		return decodeWith(base64.StdEncoding, in)
+	case esv1alpha1.ExternalSecretDecodeBase64URL:
+		return decodeWith(base64.URLEncoding, in)
+	case esv1alpha1.ExternalSecretDecodeAuto:
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding} {
+			if out, err := decodeWith(enc, in); err == nil {
+				return out, nil
+			}
+		}
+		return in, nil
+	}
+	return nil, fmt.Errorf("unknown decoding strategy %q", strategy)
+}
+
+// DecodeMap decodes all values of the map with the given strategy.
+func DecodeMap(strategy esv1alpha1.ExternalSecretDecodingStrategy, in map[string][]byte) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		val, err := Decode(strategy, v)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k, err)
+		}
+		out[k] = val
+	}
+	return out, nil
+}
+
+func decodeWith(enc *base64.Encoding, in []byte) ([]byte, error) {
+	out := make([]byte, enc.DecodedLen(len(in)))
+	n, err := enc.Decode(out, in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode base64: %w", err)
+	}
+	return out[:n], nil
+}
@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+func TestDecode(t *testing.T) {
+	tbl := []struct {
+		name     string
+		strategy esv1alpha1.ExternalSecretDecodingStrategy
+		in       string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "empty strategy keeps the value",
+			strategy: "",
+			in:       "Zm9vYmFy",
+			want:     "Zm9vYmFy",
+		},
+		{
+			name:     "none keeps the value",
+			strategy: esv1alpha1.ExternalSecretDecodeNone,
+			in:       "Zm9vYmFy",
+			want:     "Zm9vYmFy",
+		},
+		{
+			name:     "base64",
+			strategy: esv1alpha1.ExternalSecretDecodeBase64,
+			in:       "Pz4/Pg==",
+			want:     "?>?>",
+		},
+		{
+			name:     "base64 rejects url encoding",
+			strategy: esv1alpha1.ExternalSecretDecodeBase64,
+			in:       "Pz4_Pg==",
+			wantErr:  true,
+		},
+		{
+			name:     "base64url",
+			strategy: esv1alpha1.ExternalSecretDecodeBase64URL,
+			in:       "Pz4_Pg==",
+			want:     "?>?>",
+		},
+		{
+			name:     "base64url rejects std encoding",
+			strategy: esv1alpha1.ExternalSecretDecodeBase64URL,
+			in:       "Pz4/Pg==",
+			wantErr:  true,
+		},
+		{
+			name:     "auto decodes std encoding",
+			strategy: esv1alpha1.ExternalSecretDecodeAuto,
+			in:       "Pz4/Pg==",
+			want:     "?>?>",
+		},
+		{
+			name:     "auto decodes url encoding",
+			strategy: esv1alpha1.ExternalSecretDecodeAuto,
+			in:       "Pz4_Pg==",
+			want:     "?>?>",
+		},
+		{
+			name:     "auto keeps values that are not base64",
+			strategy: esv1alpha1.ExternalSecretDecodeAuto,
+			in:       "not base64!",
+			want:     "not base64!",
+		},
+		{
+			name:     "unknown strategy",
+			strategy: "Hex",
+			in:       "666f6f",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tbl {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.strategy, []byte(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("Decode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMap(t *testing.T) {
+	got, err := DecodeMap(esv1alpha1.ExternalSecretDecodeBase64, map[string][]byte{
+		"foo": []byte("YmFy"),
+		"baz": []byte("cXV4"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got["foo"]) != "bar" || string(got["baz"]) != "qux" {
+		t.Errorf("unexpected result %q", got)
+	}
+	_, err = DecodeMap(esv1alpha1.ExternalSecretDecodeBase64, map[string][]byte{"foo": []byte("%%")})
+	if err == nil {
+		t.Errorf("expected an error for invalid base64")
+	}
+}
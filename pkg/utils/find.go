@@ -0,0 +1,77 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+var invalidSecretKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// FindMatcher matches the name and tags of provider secrets against the find criteria.
+// Providers use it for every criteria they can not filter on server side.
+type FindMatcher struct {
+	name *regexp.Regexp
+	path string
+	tags map[string]string
+}
+
+// NewFindMatcher returns a FindMatcher for the given criteria.
+func NewFindMatcher(ref esv1alpha1.ExternalSecretFind) (*FindMatcher, error) {
+	m := &FindMatcher{
+		tags: ref.Tags,
+	}
+	if ref.Path != nil {
+		m.path = *ref.Path
+	}
+	if ref.Name != nil && ref.Name.RegExp != "" {
+		re, err := regexp.Compile(ref.Name.RegExp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name regexp %q: %w", ref.Name.RegExp, err)
+		}
+		m.name = re
+	}
+	return m, nil
+}
+
+// MatchName returns true if the name starts with the path and matches the name regexp.
+func (m *FindMatcher) MatchName(name string) bool {
+	if !strings.HasPrefix(name, m.path) {
+		return false
+	}
+	return m.name == nil || m.name.MatchString(name)
+}
+
+// MatchTags returns true if all tags of the criteria are part of the given tags.
+func (m *FindMatcher) MatchTags(tags map[string]string) bool {
+	for k, v := range m.tags {
+		if val, ok := tags[k]; !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
+// FindKeyName turns the name of a found secret into a valid Secret key.
+func FindKeyName(name string, naming esv1alpha1.ExternalSecretFindKeyNaming) string {
+	if naming == esv1alpha1.FindKeyNamingBase {
+		name = name[strings.LastIndex(name, "/")+1:]
+	}
+	return invalidSecretKeyChars.ReplaceAllString(strings.TrimPrefix(name, "/"), "_")
+}
@@ -0,0 +1,121 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+func TestFindMatcher(t *testing.T) {
+	path := "app/"
+	tests := []struct {
+		name      string
+		find      esv1alpha1.ExternalSecretFind
+		secret    string
+		tags      map[string]string
+		wantName  bool
+		wantTags  bool
+		wantError bool
+	}{
+		{
+			name:     "empty criteria match everything",
+			secret:   "foo",
+			wantName: true,
+			wantTags: true,
+		},
+		{
+			name:     "name regexp matches",
+			find:     esv1alpha1.ExternalSecretFind{Name: &esv1alpha1.FindName{RegExp: "^db-.*"}},
+			secret:   "db-password",
+			wantName: true,
+			wantTags: true,
+		},
+		{
+			name:     "name regexp does not match",
+			find:     esv1alpha1.ExternalSecretFind{Name: &esv1alpha1.FindName{RegExp: "^db-.*"}},
+			secret:   "api-token",
+			wantName: false,
+			wantTags: true,
+		},
+		{
+			name:     "path prefix does not match",
+			find:     esv1alpha1.ExternalSecretFind{Path: &path},
+			secret:   "other/foo",
+			wantName: false,
+			wantTags: true,
+		},
+		{
+			name:     "path prefix and tags match",
+			find:     esv1alpha1.ExternalSecretFind{Path: &path, Tags: map[string]string{"env": "prod"}},
+			secret:   "app/foo",
+			tags:     map[string]string{"env": "prod", "team": "a"},
+			wantName: true,
+			wantTags: true,
+		},
+		{
+			name:     "tag value does not match",
+			find:     esv1alpha1.ExternalSecretFind{Tags: map[string]string{"env": "prod"}},
+			secret:   "foo",
+			tags:     map[string]string{"env": "dev"},
+			wantName: true,
+			wantTags: false,
+		},
+		{
+			name:      "invalid regexp",
+			find:      esv1alpha1.ExternalSecretFind{Name: &esv1alpha1.FindName{RegExp: "("}},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewFindMatcher(tt.find)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("NewFindMatcher() error = %v, wantError %v", err, tt.wantError)
+			}
+			if err != nil {
+				return
+			}
+			if got := m.MatchName(tt.secret); got != tt.wantName {
+				t.Errorf("MatchName() = %v, want %v", got, tt.wantName)
+			}
+			if got := m.MatchTags(tt.tags); got != tt.wantTags {
+				t.Errorf("MatchTags() = %v, want %v", got, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestFindKeyName(t *testing.T) {
+	tests := []struct {
+		name   string
+		naming esv1alpha1.ExternalSecretFindKeyNaming
+		want   string
+	}{
+		{name: "db-password", naming: esv1alpha1.FindKeyNamingDefault, want: "db-password"},
+		{name: "/app/db/password", naming: esv1alpha1.FindKeyNamingDefault, want: "app_db_password"},
+		{name: "app/db/password", naming: esv1alpha1.FindKeyNamingBase, want: "password"},
+		{name: "app/db pass", naming: esv1alpha1.FindKeyNamingBase, want: "db_pass"},
+		{name: "app/db:pass", naming: "", want: "app_db_pass"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindKeyName(tt.name, tt.naming); got != tt.want {
+				t.Errorf("FindKeyName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	tpl "text/template"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/template"
+)
+
+const (
+	errRewriteRule     = "rewrite[%d]: exactly one of regexp, prefix or transform must be set"
+	errRewriteRegexp   = "rewrite[%d]: invalid regexp %q: %w"
+	errRewriteTemplate = "rewrite[%d]: invalid template: %w"
+	errRewriteExecute  = "rewrite[%d]: unable to transform key %q: %w"
+	errRewriteConflict = "keys %q and %q are both rewritten to %q"
+)
+
+type keyRewriter func(key string) (string, error)
+
+// RewriteMap applies the rewrite rules in the given order to the keys of the map.
+// It fails if two keys are rewritten to the same key.
+func RewriteMap(rules []esv1alpha1.ExternalSecretRewrite, in map[string][]byte) (map[string][]byte, error) {
+	if len(rules) == 0 {
+		return in, nil
+	}
+	out, _, err := RewriteMapWithOrigins(rules, in)
+	return out, err
+}
+
+// RewriteMapWithOrigins works like RewriteMap and also returns the original key
+// of every rewritten key.
+func RewriteMapWithOrigins(rules []esv1alpha1.ExternalSecretRewrite, in map[string][]byte) (map[string][]byte, map[string]string, error) {
+	rewriters := make([]keyRewriter, 0, len(rules))
+	for i, rule := range rules {
+		rw, err := newKeyRewriter(i, rule)
+		if err != nil {
+			return nil, nil, err
+		}
+		rewriters = append(rewriters, rw)
+	}
+
+	// iterate in order, so a conflict is always reported for the same keys
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := make(map[string][]byte, len(in))
+	origins := make(map[string]string, len(in))
+	for _, k := range keys {
+		newKey := k
+		for _, rw := range rewriters {
+			var err error
+			newKey, err = rw(newKey)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if origin, exists := origins[newKey]; exists {
+			return nil, nil, fmt.Errorf(errRewriteConflict, origin, k, newKey)
+		}
+		origins[newKey] = k
+		out[newKey] = in[k]
+	}
+	return out, origins, nil
+}
+
+func newKeyRewriter(i int, rule esv1alpha1.ExternalSecretRewrite) (keyRewriter, error) {
+	set := 0
+	for _, isSet := range []bool{rule.Regexp != nil, rule.Prefix != "", rule.Transform != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf(errRewriteRule, i)
+	}
+
+	switch {
+	case rule.Regexp != nil:
+		re, err := regexp.Compile(rule.Regexp.Source)
+		if err != nil {
+			return nil, fmt.Errorf(errRewriteRegexp, i, rule.Regexp.Source, err)
+		}
+		return func(key string) (string, error) {
+			return re.ReplaceAllString(key, rule.Regexp.Target), nil
+		}, nil
+	case rule.Prefix != "":
+		return func(key string) (string, error) {
+			return rule.Prefix + key, nil
+		}, nil
+	default:
+		t, err := tpl.New("rewrite").
+			Funcs(template.FuncMap()).
+			Option("missingkey=error").
+			Parse(rule.Transform.Template)
+		if err != nil {
+			return nil, fmt.Errorf(errRewriteTemplate, i, err)
+		}
+		return func(key string) (string, error) {
+			buf := bytes.NewBuffer(nil)
+			err := t.Execute(buf, map[string]string{"value": key})
+			if err != nil {
+				return "", fmt.Errorf(errRewriteExecute, i, key, err)
+			}
+			return buf.String(), nil
+		}, nil
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+func TestRewriteMap(t *testing.T) {
+	tbl := []struct {
+		name    string
+		rules   []esv1alpha1.ExternalSecretRewrite
+		in      map[string][]byte
+		want    map[string][]byte
+		wantErr string
+	}{
+		{
+			name: "no rules keep the keys",
+			in:   map[string][]byte{"db/password": []byte("foo")},
+			want: map[string][]byte{"db/password": []byte("foo")},
+		},
+		{
+			name: "regexp with capture group",
+			rules: []esv1alpha1.ExternalSecretRewrite{
+				{Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: `^db/(.*)$`, Target: "DB_$1"}},
+			},
+			in:   map[string][]byte{"db/password": []byte("foo"), "other": []byte("bar")},
+			want: map[string][]byte{"DB_password": []byte("foo"), "other": []byte("bar")},
+		},
+		{
+			name: "rules are applied in order",
+			rules: []esv1alpha1.ExternalSecretRewrite{
+				{Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: `[./]`, Target: "_"}},
+				{Prefix: "app_"},
+				{Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .value | upper }}"}},
+			},
+			in:   map[string][]byte{"app.config": []byte("foo"), "db/user": []byte("bar")},
+			want: map[string][]byte{"APP_APP_CONFIG": []byte("foo"), "APP_DB_USER": []byte("bar")},
+		},
+		{
+			name: "conflicting keys",
+			rules: []esv1alpha1.ExternalSecretRewrite{
+				{Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .value | lower }}"}},
+			},
+			in:      map[string][]byte{"FOO": []byte("foo"), "foo": []byte("bar")},
+			wantErr: `keys "FOO" and "foo" are both rewritten to "foo"`,
+		},
+		{
+			name:    "empty rule",
+			rules:   []esv1alpha1.ExternalSecretRewrite{{}},
+			in:      map[string][]byte{"foo": []byte("foo")},
+			wantErr: "rewrite[0]: exactly one of regexp, prefix or transform must be set",
+		},
+		{
+			name: "more than one rule",
+			rules: []esv1alpha1.ExternalSecretRewrite{
+				{Prefix: "x"},
+				{Prefix: "y", Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .value }}"}},
+			},
+			in:      map[string][]byte{"foo": []byte("foo")},
+			wantErr: "rewrite[1]: exactly one of regexp, prefix or transform must be set",
+		},
+		{
+			name: "invalid regexp",
+			rules: []esv1alpha1.ExternalSecretRewrite{
+				{Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: "(foo"}},
+			},
+			in:      map[string][]byte{"foo": []byte("foo")},
+			wantErr: `rewrite[0]: invalid regexp "(foo"`,
+		},
+		{
+			name: "template fails",
+			rules: []esv1alpha1.ExternalSecretRewrite{
+				{Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .nope }}"}},
+			},
+			in:      map[string][]byte{"foo": []byte("foo")},
+			wantErr: `rewrite[0]: unable to transform key "foo"`,
+		},
+	}
+	for _, tt := range tbl {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RewriteMap(tt.rules, tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RewriteMap() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RewriteMap() unexpected result: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRewriteMapWithOrigins(t *testing.T) {
+	rules := []esv1alpha1.ExternalSecretRewrite{
+		{Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: `[./]`, Target: "_"}},
+		{Prefix: "app_"},
+	}
+	in := map[string][]byte{"db/user": []byte("foo"), "plain": []byte("bar")}
+	got, origins, err := RewriteMapWithOrigins(rules, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"app_db_user": []byte("foo"), "app_plain": []byte("bar")}, got); diff != "" {
+		t.Errorf("RewriteMapWithOrigins() unexpected result: %s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"app_db_user": "db/user", "app_plain": "plain"}, origins); diff != "" {
+		t.Errorf("RewriteMapWithOrigins() unexpected origins: %s", diff)
+	}
+}
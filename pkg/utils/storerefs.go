@@ -0,0 +1,94 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	// KindSecret is the kind of a StoreRef to a Secret.
+	KindSecret = "Secret"
+	// KindConfigMap is the kind of a StoreRef to a ConfigMap.
+	KindConfigMap = "ConfigMap"
+)
+
+var (
+	secretKeySelectorType = reflect.TypeOf(esmeta.SecretKeySelector{})
+	caProviderType        = reflect.TypeOf(esv1alpha1.CAProvider{})
+	webhookCAProviderType = reflect.TypeOf(esv1alpha1.WebhookCAProvider{})
+)
+
+// StoreRef is a Secret or ConfigMap referenced by the provider spec of a store.
+type StoreRef struct {
+	Kind string
+	Name string
+	// Namespace is nil if the reference doesn't name one.
+	Namespace *string
+}
+
+// StoreRefs returns the Secrets and ConfigMaps referenced by the provider spec
+// of the store, i.e. its auth secrets and CA bundles, in the order of the spec.
+func StoreRefs(store esv1alpha1.GenericStore) []StoreRef {
+	var refs []StoreRef
+	walkStoreRefs(reflect.ValueOf(store.GetSpec().Provider), func(ref StoreRef) {
+		if ref.Name != "" {
+			refs = append(refs, ref)
+		}
+	})
+	return refs
+}
+
+// walkStoreRefs calls fn for every Secret and ConfigMap reference within v.
+func walkStoreRefs(v reflect.Value, fn func(StoreRef)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walkStoreRefs(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case secretKeySelectorType:
+			sel := v.Interface().(esmeta.SecretKeySelector)
+			fn(StoreRef{Kind: KindSecret, Name: sel.Name, Namespace: sel.Namespace})
+			return
+		case caProviderType:
+			ca := v.Interface().(esv1alpha1.CAProvider)
+			fn(StoreRef{Kind: string(ca.Type), Name: ca.Name, Namespace: ca.Namespace})
+			return
+		case webhookCAProviderType:
+			ca := v.Interface().(esv1alpha1.WebhookCAProvider)
+			fn(StoreRef{Kind: string(ca.Type), Name: ca.Name, Namespace: ca.Namespace})
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkStoreRefs(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkStoreRefs(v.Index(i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkStoreRefs(iter.Value(), fn)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestStoreRefs(t *testing.T) {
+	ns := "auth"
+	store := &esv1alpha1.SecretStore{
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				Vault: &esv1alpha1.VaultProvider{
+					Auth: esv1alpha1.VaultAuth{
+						TokenSecretRef: &esmeta.SecretKeySelector{Name: "token"},
+						AppRole: &esv1alpha1.VaultAppRole{
+							SecretRef: esmeta.SecretKeySelector{Name: "approle", Namespace: &ns},
+						},
+						Kubernetes: &esv1alpha1.VaultKubernetesAuth{
+							SecretRef: &esmeta.SecretKeySelector{},
+						},
+					},
+					CAProvider: &esv1alpha1.CAProvider{
+						Type: esv1alpha1.CAProviderTypeConfigMap,
+						Name: "ca",
+					},
+				},
+			},
+		},
+	}
+	want := []StoreRef{
+		{Kind: KindSecret, Name: "token"},
+		{Kind: KindSecret, Name: "approle", Namespace: &ns},
+		{Kind: KindConfigMap, Name: "ca"},
+	}
+	if got := StoreRefs(store); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected refs: %+v, want: %+v", got, want)
+	}
+}
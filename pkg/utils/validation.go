@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	errNamespaceNotAllowed = "namespace is only allowed in a ClusterSecretStore"
+	errNamespaceRequired   = "namespace is required in a ClusterSecretStore"
+)
+
+// ValidateSecretSelector checks that the selector names a Secret and key
+// and that its namespace is consistent with the kind of store.
+// A ClusterSecretStore must set the namespace.
+func ValidateSecretSelector(store esv1alpha1.GenericStore, ref esmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	return append(validateSecretKey(ref, fldPath), ValidateReferentNamespace(store, ref.Namespace, fldPath.Child("namespace"))...)
+}
+
+// ValidateSecretSelectorWithFallback is ValidateSecretSelector for providers that read
+// the Secret from the namespace of the ExternalSecret if a ClusterSecretStore doesn't set one.
+func ValidateSecretSelectorWithFallback(store esv1alpha1.GenericStore, ref esmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	return append(validateSecretKey(ref, fldPath), ValidateReferentNamespaceWithFallback(store, ref.Namespace, fldPath.Child("namespace"))...)
+}
+
+func validateSecretKey(ref esmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	if ref.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), ""))
+	}
+	return allErrs
+}
+
+// ValidateServiceAccountSelector checks that the selector names a ServiceAccount
+// and that its namespace is consistent with the kind of store.
+// A ClusterSecretStore must set the namespace.
+func ValidateServiceAccountSelector(store esv1alpha1.GenericStore, ref esmeta.ServiceAccountSelector, fldPath *field.Path) field.ErrorList {
+	return append(validateServiceAccountName(ref, fldPath), ValidateReferentNamespace(store, ref.Namespace, fldPath.Child("namespace"))...)
+}
+
+// ValidateServiceAccountSelectorWithFallback is ValidateServiceAccountSelector for providers that read
+// the ServiceAccount from the namespace of the ExternalSecret if a ClusterSecretStore doesn't set one.
+func ValidateServiceAccountSelectorWithFallback(store esv1alpha1.GenericStore, ref esmeta.ServiceAccountSelector, fldPath *field.Path) field.ErrorList {
+	return append(validateServiceAccountName(ref, fldPath), ValidateReferentNamespaceWithFallback(store, ref.Namespace, fldPath.Child("namespace"))...)
+}
+
+func validateServiceAccountName(ref esmeta.ServiceAccountSelector, fldPath *field.Path) field.ErrorList {
+	if ref.Name == "" {
+		return field.ErrorList{field.Required(fldPath.Child("name"), "")}
+	}
+	return nil
+}
+
+// ValidateReferentNamespace ensures that a namespaced SecretStore does not
+// reference objects by namespace and that a ClusterSecretStore always does.
+func ValidateReferentNamespace(store esv1alpha1.GenericStore, namespace *string, fldPath *field.Path) field.ErrorList {
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok {
+		if namespace == nil || *namespace == "" {
+			return field.ErrorList{field.Required(fldPath, errNamespaceRequired)}
+		}
+		return nil
+	}
+	return ValidateReferentNamespaceWithFallback(store, namespace, fldPath)
+}
+
+// ValidateReferentNamespaceWithFallback ensures that a namespaced SecretStore does not
+// reference objects by namespace. A ClusterSecretStore may omit the namespace,
+// the object is then read from the namespace of the ExternalSecret.
+func ValidateReferentNamespaceWithFallback(store esv1alpha1.GenericStore, namespace *string, fldPath *field.Path) field.ErrorList {
+	if _, ok := store.(*esv1alpha1.ClusterSecretStore); ok {
+		return nil
+	}
+	if namespace != nil {
+		return field.ErrorList{field.Forbidden(fldPath, errNamespaceNotAllowed)}
+	}
+	return nil
+}
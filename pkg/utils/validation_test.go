@@ -0,0 +1,95 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+func TestValidateSecretSelector(t *testing.T) {
+	ns := "foo"
+	fldPath := field.NewPath("ref")
+	tests := []struct {
+		name     string
+		store    esv1alpha1.GenericStore
+		ref      esmeta.SecretKeySelector
+		fallback bool
+		want     []string
+	}{
+		{
+			name:  "valid SecretStore reference",
+			store: &esv1alpha1.SecretStore{},
+			ref:   esmeta.SecretKeySelector{Name: "secret", Key: "key"},
+		},
+		{
+			name:  "valid ClusterSecretStore reference",
+			store: &esv1alpha1.ClusterSecretStore{},
+			ref:   esmeta.SecretKeySelector{Name: "secret", Key: "key", Namespace: &ns},
+		},
+		{
+			name:  "name and key are required",
+			store: &esv1alpha1.SecretStore{},
+			ref:   esmeta.SecretKeySelector{},
+			want:  []string{"ref.name", "ref.key"},
+		},
+		{
+			name:  "namespace is not allowed in a SecretStore",
+			store: &esv1alpha1.SecretStore{},
+			ref:   esmeta.SecretKeySelector{Name: "secret", Key: "key", Namespace: &ns},
+			want:  []string{"ref.namespace"},
+		},
+		{
+			name:  "namespace is required in a ClusterSecretStore",
+			store: &esv1alpha1.ClusterSecretStore{},
+			ref:   esmeta.SecretKeySelector{Name: "secret", Key: "key"},
+			want:  []string{"ref.namespace"},
+		},
+		{
+			name:     "namespace is optional in a ClusterSecretStore with fallback",
+			store:    &esv1alpha1.ClusterSecretStore{},
+			ref:      esmeta.SecretKeySelector{Name: "secret", Key: "key"},
+			fallback: true,
+		},
+		{
+			name:     "namespace is not allowed in a SecretStore with fallback",
+			store:    &esv1alpha1.SecretStore{},
+			ref:      esmeta.SecretKeySelector{Name: "secret", Key: "key", Namespace: &ns},
+			fallback: true,
+			want:     []string{"ref.namespace"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validate := ValidateSecretSelector
+			if tt.fallback {
+				validate = ValidateSecretSelectorWithFallback
+			}
+			errs := validate(tt.store, tt.ref, fldPath)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("ValidateSecretSelector() = %v, want errors for %v", errs, tt.want)
+			}
+			for i := range errs {
+				if errs[i].Field != tt.want[i] {
+					t.Errorf("ValidateSecretSelector() error %d on %q, want %q", i, errs[i].Field, tt.want[i])
+				}
+			}
+		})
+	}
+}
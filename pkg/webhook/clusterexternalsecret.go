@@ -0,0 +1,62 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// ClusterExternalSecretValidator validates ClusterExternalSecrets on create and update.
+type ClusterExternalSecretValidator struct{}
+
+var _ admission.CustomValidator = &ClusterExternalSecretValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *ClusterExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validateClusterExternalSecret(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *ClusterExternalSecretValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return validateClusterExternalSecret(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *ClusterExternalSecretValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateClusterExternalSecret(obj runtime.Object) error {
+	ces, ok := obj.(*esv1alpha1.ClusterExternalSecret)
+	if !ok {
+		return fmt.Errorf(errUnexpectedType, &esv1alpha1.ClusterExternalSecret{}, obj)
+	}
+	specPath := field.NewPath("spec")
+	errs := metav1validation.ValidateLabelSelector(&ces.Spec.NamespaceSelector, specPath.Child("namespaceSelector"))
+	errs = append(errs, ValidateExternalSecretSpec(&ces.Spec.ExternalSecretSpec, specPath.Child("externalSecretSpec"))...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(esv1alpha1.ClusterExtSecretGroupVersionKind.GroupKind(), ces.Name, errs)
+}
@@ -0,0 +1,208 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	"github.com/external-secrets/external-secrets/pkg/template"
+)
+
+const (
+	errUnexpectedType = "expected %T, got %T"
+	errNoData         = "either data or dataFrom must be set"
+	errDataFromSource = "exactly one of key, find or generatorRef must be set"
+	errNoStoreRef     = "secretStoreRef must be set unless all data is produced by generators"
+	errRewriteRule    = "exactly one of regexp, prefix or transform must be set"
+	errDeletePolicy   = "deletionPolicy=Delete requires creationPolicy=Owner"
+	errMergePolicy    = "deletionPolicy=Merge must not be used with creationPolicy=None"
+	errManifestKind   = "a template manifest is required for kinds other than Secret and ConfigMap"
+	errManifestUnused = "a template manifest can only be used with kinds other than Secret and ConfigMap"
+	errImmutableKind  = "immutable can only be used with Secrets and ConfigMaps"
+)
+
+// ExternalSecretValidator validates ExternalSecrets on create and update.
+type ExternalSecretValidator struct{}
+
+var _ admission.CustomValidator = &ExternalSecretValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *ExternalSecretValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validateExternalSecret(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *ExternalSecretValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return validateExternalSecret(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *ExternalSecretValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateExternalSecret(obj runtime.Object) error {
+	es, ok := obj.(*esv1alpha1.ExternalSecret)
+	if !ok {
+		return fmt.Errorf(errUnexpectedType, &esv1alpha1.ExternalSecret{}, obj)
+	}
+	errs := ValidateExternalSecretSpec(&es.Spec, field.NewPath("spec"))
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(esv1alpha1.ExtSecretGroupVersionKind.GroupKind(), es.Name, errs)
+}
+
+// ValidateExternalSecretSpec returns the errors found in the given spec.
+func ValidateExternalSecretSpec(spec *esv1alpha1.ExternalSecretSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ref := spec.SecretStoreRef; ref != nil {
+		switch ref.Kind {
+		case "", esv1alpha1.SecretStoreKind, esv1alpha1.ClusterSecretStoreKind:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("secretStoreRef", "kind"), ref.Kind,
+				[]string{esv1alpha1.SecretStoreKind, esv1alpha1.ClusterSecretStoreKind}))
+		}
+	} else if spec.UsesSecretStore() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("secretStoreRef"), errNoStoreRef))
+	}
+
+	if len(spec.Data) == 0 && len(spec.DataFrom) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("data"), errNoData))
+	}
+
+	keys := make(map[string]struct{}, len(spec.Data))
+	for i, data := range spec.Data {
+		if _, exists := keys[data.SecretKey]; exists {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("data").Index(i).Child("secretKey"), data.SecretKey))
+		}
+		keys[data.SecretKey] = struct{}{}
+	}
+
+	for i, ref := range spec.DataFrom {
+		refPath := fldPath.Child("dataFrom").Index(i)
+		switch {
+		case ref.Key == "" && ref.Find == nil && ref.GeneratorRef == nil:
+			allErrs = append(allErrs, field.Required(refPath, errDataFromSource))
+		case ref.Key != "" && ref.Find != nil:
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("find"), errDataFromSource))
+		case (ref.Key != "" || ref.Find != nil) && ref.GeneratorRef != nil:
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("generatorRef"), errDataFromSource))
+		case ref.Find != nil:
+			if name := ref.Find.Name; name != nil {
+				if _, err := regexp.Compile(name.RegExp); err != nil {
+					allErrs = append(allErrs, field.Invalid(refPath.Child("find", "name", "regexp"), name.RegExp, err.Error()))
+				}
+			}
+		}
+		for j, rule := range ref.Rewrite {
+			allErrs = append(allErrs, validateRewrite(rule, refPath.Child("rewrite").Index(j))...)
+		}
+	}
+
+	deletionPath := fldPath.Child("target", "deletionPolicy")
+	switch spec.Target.DeletionPolicy {
+	case esv1alpha1.DeletionPolicyDelete:
+		if spec.Target.CreationPolicy == esv1alpha1.Merge || spec.Target.CreationPolicy == esv1alpha1.None {
+			allErrs = append(allErrs, field.Forbidden(deletionPath, errDeletePolicy))
+		}
+	case esv1alpha1.DeletionPolicyMerge:
+		if spec.Target.CreationPolicy == esv1alpha1.None {
+			allErrs = append(allErrs, field.Forbidden(deletionPath, errMergePolicy))
+		}
+	}
+
+	if tpl := spec.Target.Template; tpl != nil {
+		tplPath := fldPath.Child("target", "template", "data")
+		for k, v := range tpl.Data {
+			if err := template.Validate(k, v); err != nil {
+				allErrs = append(allErrs, field.Invalid(tplPath.Key(k), v, err.Error()))
+			}
+		}
+		if tpl.Manifest != "" {
+			if err := template.Validate(template.ManifestKey, tpl.Manifest); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("target", "template", "manifest"), tpl.Manifest, err.Error()))
+			}
+		}
+	}
+	allErrs = append(allErrs, validateTargetManifest(&spec.Target, fldPath.Child("target"))...)
+
+	return allErrs
+}
+
+// validateTargetManifest checks that targets other than Secrets and ConfigMaps are rendered from a template manifest.
+func validateTargetManifest(target *esv1alpha1.ExternalSecretTarget, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	hasManifest := target.Template != nil && target.Template.Manifest != ""
+	if target.Manifest == nil {
+		if hasManifest {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("template", "manifest"), errManifestUnused))
+		}
+		return allErrs
+	}
+	manifestPath := fldPath.Child("manifest")
+	gv, err := schema.ParseGroupVersion(target.Manifest.APIVersion)
+	if err != nil || target.Manifest.APIVersion == "" {
+		allErrs = append(allErrs, field.Invalid(manifestPath.Child("apiVersion"), target.Manifest.APIVersion, "must be a valid apiVersion"))
+	}
+	if target.Manifest.Kind == "" {
+		allErrs = append(allErrs, field.Required(manifestPath.Child("kind"), "kind must be set"))
+	}
+	dataKind := gv.Group == "" && (target.Manifest.Kind == "Secret" || target.Manifest.Kind == "ConfigMap")
+	switch {
+	case dataKind && hasManifest:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("template", "manifest"), errManifestUnused))
+	case !dataKind && !hasManifest:
+		allErrs = append(allErrs, field.Required(fldPath.Child("template", "manifest"), errManifestKind))
+	}
+	if !dataKind && target.Immutable {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("immutable"), errImmutableKind))
+	}
+	return allErrs
+}
+
+func validateRewrite(rule esv1alpha1.ExternalSecretRewrite, fldPath *field.Path) field.ErrorList {
+	set := 0
+	for _, isSet := range []bool{rule.Regexp != nil, rule.Prefix != "", rule.Transform != nil} {
+		if isSet {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return field.ErrorList{field.Required(fldPath, errRewriteRule)}
+	case set > 1:
+		return field.ErrorList{field.Forbidden(fldPath, errRewriteRule)}
+	case rule.Regexp != nil:
+		if _, err := regexp.Compile(rule.Regexp.Source); err != nil {
+			return field.ErrorList{field.Invalid(fldPath.Child("regexp", "source"), rule.Regexp.Source, err.Error())}
+		}
+	case rule.Transform != nil:
+		if err := template.Validate("rewrite", rule.Transform.Template); err != nil {
+			return field.ErrorList{field.Invalid(fldPath.Child("transform", "template"), rule.Transform.Template, err.Error())}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+
+	// Loading registered providers.
+	_ "github.com/external-secrets/external-secrets/pkg/provider/register"
+	"github.com/external-secrets/external-secrets/pkg/provider/retry"
+	"github.com/external-secrets/external-secrets/pkg/provider/schema"
+)
+
+const (
+	errUnexpectedStoreType = "expected a SecretStore or ClusterSecretStore, got %T"
+)
+
+// StoreValidator validates SecretStores and ClusterSecretStores on create and update.
+type StoreValidator struct{}
+
+var _ admission.CustomValidator = &StoreValidator{}
+
+// ValidateCreate implements admission.CustomValidator.
+func (v *StoreValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return validateStore(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator.
+func (v *StoreValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return validateStore(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator.
+func (v *StoreValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateStore(obj runtime.Object) error {
+	store, ok := obj.(esv1alpha1.GenericStore)
+	if !ok {
+		return fmt.Errorf(errUnexpectedStoreType, obj)
+	}
+	errs := schema.ValidateStore(store)
+	errs = append(errs, retry.ValidateSettings(store.GetSpec().RetrySettings, field.NewPath("spec", "retrySettings"))...)
+	if len(errs) == 0 {
+		return nil
+	}
+	gk := esv1alpha1.SecretStoreGroupVersionKind.GroupKind()
+	if _, isCluster := store.(*esv1alpha1.ClusterSecretStore); isCluster {
+		gk = esv1alpha1.ClusterSecretStoreGroupVersionKind.GroupKind()
+	}
+	return apierrors.NewInvalid(gk, store.GetName(), errs)
+}
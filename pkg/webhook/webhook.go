@@ -0,0 +1,50 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook implements the validating admission webhooks
+// for the external-secrets.io resources.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+)
+
+// SetupWithManager registers the validating webhooks with the webhook server of the manager.
+// The webhooks are served at /validate-external-secrets-io-v1alpha1-<kind>.
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&esv1alpha1.ExternalSecret{}).
+		WithValidator(&ExternalSecretValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&esv1alpha1.ClusterExternalSecret{}).
+		WithValidator(&ClusterExternalSecretValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&esv1alpha1.SecretStore{}).
+		WithValidator(&StoreValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&esv1alpha1.ClusterSecretStore{}).
+		WithValidator(&StoreValidator{}).
+		Complete()
+}
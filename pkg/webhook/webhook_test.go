@@ -0,0 +1,306 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	esv1alpha1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1alpha1"
+	esmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	testNamespace = "default"
+)
+
+var _ = Describe("ExternalSecret webhook", func() {
+	DescribeTable("validating an ExternalSecret", func(tweak func(*esv1alpha1.ExternalSecret), expectedField string) {
+		es := makeExternalSecret()
+		tweak(es)
+		err := k8sClient.Create(context.Background(), es)
+		if expectedField == "" {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Delete(context.Background(), es)).To(Succeed())
+			return
+		}
+		expectInvalid(err, expectedField)
+	},
+		Entry("should accept a valid ExternalSecret", func(es *esv1alpha1.ExternalSecret) {}, ""),
+		Entry("should accept dataFrom without data", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{Key: "foo"}}
+			es.Spec.Data = nil
+		}, ""),
+		Entry("should accept dataFrom with find", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Find: &esv1alpha1.ExternalSecretFind{
+					Name: &esv1alpha1.FindName{RegExp: "^db-.*"},
+				},
+			}}
+		}, ""),
+		Entry("should reject dataFrom without key, find or generatorRef", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{}}
+		}, "spec.dataFrom[0]"),
+		Entry("should reject dataFrom with both key and find", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Key:  "foo",
+				Find: &esv1alpha1.ExternalSecretFind{},
+			}}
+		}, "spec.dataFrom[0].find"),
+		Entry("should reject a find regexp that doesn't compile", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Find: &esv1alpha1.ExternalSecretFind{
+					Name: &esv1alpha1.FindName{RegExp: "(foo"},
+				},
+			}}
+		}, "spec.dataFrom[0].find.name.regexp"),
+		Entry("should accept a generatorRef without secretStoreRef", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.SecretStoreRef = nil
+			es.Spec.Data = nil
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				GeneratorRef: &esv1alpha1.GeneratorRef{Kind: "Password", Name: "my-password"},
+			}}
+		}, ""),
+		Entry("should reject data without secretStoreRef", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.SecretStoreRef = nil
+		}, "spec.secretStoreRef"),
+		Entry("should reject dataFrom with both key and generatorRef", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Key:          "foo",
+				GeneratorRef: &esv1alpha1.GeneratorRef{Kind: "Password", Name: "my-password"},
+			}}
+		}, "spec.dataFrom[0].generatorRef"),
+		Entry("should accept dataFrom with rewrite rules", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Key: "foo",
+				Rewrite: []esv1alpha1.ExternalSecretRewrite{
+					{Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: "[/.]", Target: "_"}},
+					{Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .value | upper }}"}},
+				},
+			}}
+		}, ""),
+		Entry("should reject a rewrite rule with more than one rewrite", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Key: "foo",
+				Rewrite: []esv1alpha1.ExternalSecretRewrite{
+					{Prefix: "foo_"},
+					{Prefix: "bar_", Regexp: &esv1alpha1.ExternalSecretRewriteRegexp{Source: "-", Target: "_"}},
+				},
+			}}
+		}, "spec.dataFrom[0].rewrite[1]"),
+		Entry("should reject a rewrite template that doesn't parse", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.DataFrom = []esv1alpha1.ExternalSecretDataFromRemoteRef{{
+				Key: "foo",
+				Rewrite: []esv1alpha1.ExternalSecretRewrite{
+					{Transform: &esv1alpha1.ExternalSecretRewriteTransform{Template: "{{ .value | nope }}"}},
+				},
+			}}
+		}, "spec.dataFrom[0].rewrite[0]"),
+		Entry("should reject neither data nor dataFrom", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Data = nil
+		}, "spec.data"),
+		Entry("should reject duplicate secretKeys", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Data = append(es.Spec.Data, esv1alpha1.ExternalSecretData{
+				SecretKey: "foo",
+				RemoteRef: esv1alpha1.ExternalSecretDataRemoteRef{Key: "other"},
+			})
+		}, "spec.data[1].secretKey"),
+		Entry("should reject a template that doesn't parse", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{
+				Data: map[string]string{
+					"config": "{{ .foo | nope }}",
+				},
+			}
+		}, "spec.target.template.data[config]"),
+		Entry("should accept deletionPolicy Delete with creationPolicy Owner", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.CreationPolicy = esv1alpha1.Owner
+			es.Spec.Target.DeletionPolicy = esv1alpha1.DeletionPolicyDelete
+		}, ""),
+		Entry("should reject deletionPolicy Delete with creationPolicy Merge", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.CreationPolicy = esv1alpha1.Merge
+			es.Spec.Target.DeletionPolicy = esv1alpha1.DeletionPolicyDelete
+		}, "spec.target.deletionPolicy"),
+		Entry("should reject deletionPolicy Merge with creationPolicy None", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.CreationPolicy = esv1alpha1.None
+			es.Spec.Target.DeletionPolicy = esv1alpha1.DeletionPolicyMerge
+		}, "spec.target.deletionPolicy"),
+		Entry("should reject an unknown store kind", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.SecretStoreRef.Kind = "Foo"
+		}, "spec.secretStoreRef.kind"),
+		Entry("should accept a ConfigMap target", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "v1", Kind: "ConfigMap"}
+		}, ""),
+		Entry("should accept a target rendered from a manifest", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "argoproj.io/v1alpha1", Kind: "Application"}
+			es.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{
+				Manifest: "spec:\n  password: {{ .foo | toString }}",
+			}
+		}, ""),
+		Entry("should reject a target of another kind without manifest", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "argoproj.io/v1alpha1", Kind: "Application"}
+		}, "spec.target.template.manifest"),
+		Entry("should reject a manifest for a ConfigMap target", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "v1", Kind: "ConfigMap"}
+			es.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{Manifest: "data: {}"}
+		}, "spec.target.template.manifest"),
+		Entry("should reject a manifest that doesn't parse", func(es *esv1alpha1.ExternalSecret) {
+			es.Spec.Target.Manifest = &esv1alpha1.ExternalSecretTargetManifest{APIVersion: "argoproj.io/v1alpha1", Kind: "Application"}
+			es.Spec.Target.Template = &esv1alpha1.ExternalSecretTemplate{Manifest: "{{ .foo | nope }}"}
+		}, "spec.target.template.manifest"),
+	)
+})
+
+var _ = Describe("ClusterExternalSecret webhook", func() {
+	DescribeTable("validating a ClusterExternalSecret", func(tweak func(*esv1alpha1.ClusterExternalSecret), expectedField string) {
+		es := makeExternalSecret()
+		ces := &esv1alpha1.ClusterExternalSecret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: es.Name,
+			},
+			Spec: esv1alpha1.ClusterExternalSecretSpec{
+				ExternalSecretSpec: es.Spec,
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+			},
+		}
+		tweak(ces)
+		err := k8sClient.Create(context.Background(), ces)
+		if expectedField == "" {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Delete(context.Background(), ces)).To(Succeed())
+			return
+		}
+		expectInvalid(err, expectedField)
+	},
+		Entry("should accept a valid ClusterExternalSecret", func(ces *esv1alpha1.ClusterExternalSecret) {}, ""),
+		Entry("should reject an invalid ExternalSecret spec", func(ces *esv1alpha1.ClusterExternalSecret) {
+			ces.Spec.ExternalSecretSpec.Data = nil
+		}, "spec.externalSecretSpec.data"),
+		Entry("should reject an invalid namespace selector", func(ces *esv1alpha1.ClusterExternalSecret) {
+			ces.Spec.NamespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+				{Key: "foo", Operator: "Nope"},
+			}
+		}, "spec.namespaceSelector.matchExpressions[0].operator"),
+	)
+})
+
+var _ = Describe("SecretStore webhook", func() {
+	DescribeTable("validating a SecretStore", func(tweak func(*esv1alpha1.SecretStore), expectedField string) {
+		store := makeSecretStore()
+		tweak(store)
+		err := k8sClient.Create(context.Background(), store)
+		if expectedField == "" {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8sClient.Delete(context.Background(), store)).To(Succeed())
+			return
+		}
+		expectInvalid(err, expectedField)
+	},
+		Entry("should accept a valid SecretStore", func(store *esv1alpha1.SecretStore) {}, ""),
+		Entry("should reject two backends", func(store *esv1alpha1.SecretStore) {
+			store.Spec.Provider.AWS = &esv1alpha1.AWSProvider{
+				Service: esv1alpha1.AWSServiceSecretsManager,
+				Region:  "eu-central-1",
+			}
+		}, "spec.provider"),
+		Entry("should reject a provider specific error", func(store *esv1alpha1.SecretStore) {
+			store.Spec.Provider.Vault.Auth = esv1alpha1.VaultAuth{}
+		}, "spec.provider.vault.auth"),
+		Entry("should reject a namespace in a secret reference", func(store *esv1alpha1.SecretStore) {
+			ns := "other"
+			store.Spec.Provider.Vault.Auth.TokenSecretRef.Namespace = &ns
+		}, "spec.provider.vault.auth.tokenSecretRef.namespace"),
+		Entry("should accept retry settings", func(store *esv1alpha1.SecretStore) {
+			retries := int32(3)
+			interval := "10s"
+			store.Spec.RetrySettings = &esv1alpha1.SecretStoreRetrySettings{MaxRetries: &retries, RetryInterval: &interval}
+		}, ""),
+		Entry("should reject a retry interval that doesn't parse", func(store *esv1alpha1.SecretStore) {
+			interval := "ten seconds"
+			store.Spec.RetrySettings = &esv1alpha1.SecretStoreRetrySettings{RetryInterval: &interval}
+		}, "spec.retrySettings.retryInterval"),
+	)
+
+	It("should reject a ClusterSecretStore without secret namespace", func() {
+		store := makeSecretStore()
+		css := &esv1alpha1.ClusterSecretStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: store.Name,
+			},
+			Spec: store.Spec,
+		}
+		expectInvalid(k8sClient.Create(context.Background(), css), "spec.provider.vault.auth.tokenSecretRef.namespace")
+	})
+})
+
+func expectInvalid(err error, expectedField string) {
+	Expect(err).To(HaveOccurred())
+	Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected invalid error, got: %v", err)
+	Expect(err.Error()).To(ContainSubstring(expectedField))
+}
+
+func makeExternalSecret() *esv1alpha1.ExternalSecret {
+	return &esv1alpha1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("test-es-%d", time.Now().UnixNano()),
+			Namespace: testNamespace,
+		},
+		Spec: esv1alpha1.ExternalSecretSpec{
+			SecretStoreRef: &esv1alpha1.SecretStoreRef{
+				Name: "test-store",
+			},
+			Data: []esv1alpha1.ExternalSecretData{
+				{
+					SecretKey: "foo",
+					RemoteRef: esv1alpha1.ExternalSecretDataRemoteRef{
+						Key: "foo",
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeSecretStore() *esv1alpha1.SecretStore {
+	return &esv1alpha1.SecretStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("test-store-%d", time.Now().UnixNano()),
+			Namespace: testNamespace,
+		},
+		Spec: esv1alpha1.SecretStoreSpec{
+			Provider: &esv1alpha1.SecretStoreProvider{
+				Vault: &esv1alpha1.VaultProvider{
+					Server:  "https://vault.example.com",
+					Path:    "secret",
+					Version: esv1alpha1.VaultKVStoreV2,
+					Auth: esv1alpha1.VaultAuth{
+						TokenSecretRef: &esmeta.SecretKeySelector{
+							Name: "vault-token",
+							Key:  "token",
+						},
+					},
+				},
+			},
+		},
+	}
+}